@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	ossignal "os/signal"
+	"syscall"
+	"time"
+
+	"app/core/config"
+	"app/core/line/fakeline"
+	"app/core/property"
+	"app/core/util"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/exp/slog"
+)
+
+var FakeLineCMD = &cli.Command{
+	Name:  "fakeline",
+	Usage: "run a fake LINE platform for end-to-end tests without network access",
+	Description: "Start the app with LINE_API_URL and LINE_DATA_API_URL set to the address of fakeline,\n" +
+		"the chat transcript is shown at the root page and /fake/transcript.",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "listen",
+			Usage: "address the fake platform listens on.",
+			Value: "localhost:9080",
+		},
+		&cli.StringFlag{
+			Name:  "webhook",
+			Usage: "webhook url of the app, defaults to /webhook/line of the configured PORT on localhost.",
+		},
+		&cli.StringFlag{
+			Name:  "secret",
+			Usage: "channel secret to sign webhook requests with, defaults to LINE_CHANNEL_SECRET.",
+		},
+		&cli.StringFlag{
+			Name:    "scenario",
+			Usage:   "path of a scenario file to run once started.",
+			Aliases: []string{"s"},
+		},
+		&cli.BoolFlag{
+			Name:  "exit",
+			Usage: "exit after the scenario is run, with a non-zero status if it failed.",
+		},
+		configFlag, workingDir,
+	},
+	Action: func(ctx *cli.Context) error {
+		if err := setup_config(); err != nil {
+			return fmt.Errorf("setup error: %s", err)
+		}
+		webhook := ctx.String("webhook")
+		if webhook == "" {
+			webhook = "http://localhost:" + config.GetString(property.PORT) + "/webhook/line"
+		}
+		secret := ctx.String("secret")
+		if secret == "" {
+			secret = config.GetString(property.LINE_CHANNEL_SECRET)
+		}
+		var sc *fakeline.Scenario
+		if file := ctx.String("scenario"); file != "" {
+			var err error
+			if sc, err = fakeline.LoadScenario(file); err != nil {
+				return err
+			}
+		}
+
+		fake := fakeline.New(&fakeline.Option{Webhook: webhook, Secret: secret})
+		lis, err := net.Listen("tcp", ctx.String("listen"))
+		if err != nil {
+			return err
+		}
+		srv := &http.Server{Handler: fake}
+		go srv.Serve(lis)
+		defer func() {
+			c, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			srv.Shutdown(c)
+		}()
+		slog.Info("fakeline started", slog.String("mod", "fakeline"), slog.String("act", "start"),
+			slog.String("addr", "http://"+lis.Addr().String()), slog.String("webhook", webhook))
+
+		runCtx, stop := ossignal.NotifyContext(ctx.Context, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if sc != nil {
+			err := fake.Run(runCtx, sc)
+			if ctx.Bool("exit") {
+				return err
+			}
+			if err != nil {
+				slog.Error("scenario failed", util.ErrAtrr(err), slog.String("mod", "fakeline"), slog.String("act", "run"))
+			} else {
+				slog.Info("scenario passed", slog.String("mod", "fakeline"), slog.String("act", "run"))
+			}
+		}
+		<-runCtx.Done()
+		return nil
+	},
+}
@@ -28,6 +28,8 @@ func init() {
 	config.SetDefault(property.DB, "sqlite")
 	config.SetDefault(property.DSN, "app.db?cache=shared&_fk=1")
 
+	config.SetDefault(property.SECRET_FILE, "secret.key")
+
 	config.SetDefault(property.PORT, "80")
 	config.SetDefault(property.ADDR, "0.0.0.0")
 	config.SetDefault(property.GRPC_PORT, "8080")
@@ -35,6 +37,50 @@ func init() {
 
 	// config.SetDefault(property.CUSTOM, "custom")
 
+	config.SetDefault(property.LINE_API_URL, "https://api.line.me")
+	config.SetDefault(property.LINE_DATA_API_URL, "https://api-data.line.me")
+	config.SetDefault(property.LINE_MAX_RETRY, 3)
+	config.SetDefault(property.LINE_DEDUP_STORE, "memory")
+	config.SetDefault(property.LINE_DEDUP_TTL, "24h")
+	config.SetDefault(property.LINE_LOGIN_AUTHORIZE_URL, "https://access.line.me/oauth2/v2.1/authorize")
+	config.SetDefault(property.LINE_LOGIN_TOKEN_URL, "https://api.line.me/oauth2/v2.1/token")
+	config.SetDefault(property.LINE_LOGIN_ISSUER, "https://access.line.me")
+	config.SetDefault(property.LIFF_VERIFY_URL, "https://api.line.me/oauth2/v2.1/verify")
+	config.SetDefault(property.LIFF_PROFILE_URL, "https://api.line.me/v2/profile")
+	config.SetDefault(property.LINE_USER_GROUP, "user")
+
+	config.SetDefault(property.CONVERSATION_CANCEL, "取消,cancel")
+	config.SetDefault(property.CONVERSATION_TTL, "10m")
+	config.SetDefault(property.POSTBACK_TTL, "168h")
+
+	config.SetDefault(property.OUTBOX_MAX_ATTEMPTS, 8)
+	config.SetDefault(property.OUTBOX_BACKOFF, "30s")
+	config.SetDefault(property.OUTBOX_FLUSH_TIMEOUT, "5s")
+
+	config.SetDefault(property.WEBHOOK_MAX_ATTEMPTS, 8)
+	config.SetDefault(property.WEBHOOK_BACKOFF, "30s")
+	config.SetDefault(property.WEBHOOK_TIMEOUT, "10s")
+	config.SetDefault(property.WEBHOOK_RETENTION, "720h")
+	config.SetDefault(property.WEBHOOK_ALLOW_PRIVATE, false)
+
+	config.SetDefault(property.REMINDER_GRACE, "1h")
+	config.SetDefault(property.REMINDER_SNOOZE, "10m")
+	config.SetDefault(property.REMINDER_INTERVAL, "1m")
+
+	config.SetDefault(property.DIGEST_AT, "07:00")
+
+	config.SetDefault(property.CALENDAR_REFRESH, "1h")
+
+	config.SetDefault(property.NOTE_AUTO_SAVE, true)
+
+	config.SetDefault(property.EXPENSE_CURRENCY, "TWD")
+	config.SetDefault(property.EXPENSE_REPORT_TTL, "168h")
+
+	config.SetDefault(property.STORAGE_BACKEND, "local")
+	config.SetDefault(property.STORAGE_DIR, "data")
+	config.SetDefault(property.S3_REGION, "us-east-1")
+	config.SetDefault(property.MEDIA_URL_TTL, "1h")
+
 	config.SetDefault(property.LOG_LEVEL, "info")
 	config.SetDefault(property.LOG_FORMAT, "text")
 	config.SetDefault(property.LOG_STD, true)
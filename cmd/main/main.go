@@ -9,6 +9,7 @@ import (
 	"syscall"
 	"time"
 
+	"app/core/config"
 	"app/core/property"
 	"app/core/service"
 	"app/core/signal"
@@ -25,6 +26,8 @@ func main() {
 		Version: fmt.Sprintf("%s-%s %s", Version, ID, Build),
 		Commands: []*cli.Command{
 			StartCMD,
+			RichMenuCMD,
+			FakeLineCMD,
 		},
 	}
 
@@ -59,10 +62,10 @@ var StartCMD = &cli.Command{
 		//-------------------------------------------------
 		//- Connection to database                        -
 		//-------------------------------------------------
-		// client, err := connect(ctx.Bool("migrate"))
-		// if err != nil {
-		// 	return fmt.Errorf("connection failed: %s", err)
-		// }
+		client, err := connect(ctx.Bool("migrate"))
+		if err != nil {
+			return fmt.Errorf("connection failed: %s", err)
+		}
 
 		//-------------------------------------------------
 		//- Setup Server                                  -
@@ -79,7 +82,12 @@ var StartCMD = &cli.Command{
 			return fmt.Errorf("lifecycle.Initiate failed: %s", err)
 		}
 		service.Load()
-		signal.Handle(property.RELOAD, func(e signal.Event) { service.Load() })
+		signal.Handle(property.RELOAD, func(e signal.Event) {
+			if err := config.Load(); err != nil {
+				slog.Error("reload config failed", util.ErrAtrr(err), slog.String("mod", "main"), slog.String("act", "reload"))
+			}
+			service.Load()
+		})
 
 		//-------------------------------------------------
 		//- Application Start                             -
@@ -108,7 +116,7 @@ var StartCMD = &cli.Command{
 		srv.Stop(time.Second * 10)
 		shutSvc := service.Del(time.Second * 10)
 		<-shutSvc.Done()
-		// client.Close()
+		client.Close()
 		if errors.Is(shutSvc.Err(), context.DeadlineExceeded) {
 			return fmt.Errorf("service termination timeout reached! force terminated")
 		}
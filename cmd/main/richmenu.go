@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"app/core/config"
+	"app/core/line"
+	"app/core/property"
+	"app/core/util"
+
+	"github.com/urfave/cli/v2"
+)
+
+// RICHMENU_FILE is the default rich menu definition file in the CUSTOM folder.
+const RICHMENU_FILE = "richmenu.json"
+
+var RichMenuCMD = &cli.Command{
+	Name:  "richmenu",
+	Usage: "manage rich menus declared in " + RICHMENU_FILE + " of the custom folder",
+	Flags: []cli.Flag{configFlag, workingDir},
+	Subcommands: []*cli.Command{
+		{
+			Name:  "sync",
+			Usage: "create, update and delete rich menus to match the definitions, menus not declared will be deleted.",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "file",
+					Usage:   "path of the definition file, defaults to " + RICHMENU_FILE + " in the custom folder.",
+					Aliases: []string{"i"},
+				},
+				&cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "only show the differences without applying them.",
+				},
+			},
+			Action: func(ctx *cli.Context) error {
+				if err := setup_config(); err != nil {
+					return fmt.Errorf("setup error: %s", err)
+				}
+				file := ctx.String("file")
+				if file == "" {
+					file = filepath.Join(config.GetString(property.CUSTOM), RICHMENU_FILE)
+				}
+				def, err := loadRichMenus(file)
+				if err != nil {
+					return err
+				}
+				return syncRichMenus(ctx.Context, def, ctx.Bool("dry-run"))
+			},
+		},
+		{
+			Name:  "list",
+			Usage: "list rich menus of the channel.",
+			Action: func(ctx *cli.Context) error {
+				if err := setup_config(); err != nil {
+					return fmt.Errorf("setup error: %s", err)
+				}
+				menus, err := line.Default().RichMenus(ctx.Context)
+				if err != nil {
+					return err
+				}
+				def, err := line.Default().DefaultRichMenu(ctx.Context)
+				if err != nil {
+					return err
+				}
+				for _, m := range menus {
+					mark := " "
+					if m.RichMenuID == def {
+						mark = "*"
+					}
+					fmt.Printf("%s %s %s\n", mark, m.RichMenuID, m.Name)
+				}
+				return nil
+			},
+		},
+	},
+}
+
+// richMenuDef is the structure of the definition file.
+type richMenuDef struct {
+	// Default is the name of the menu shown to users without a linked menu.
+	Default string `json:"default"`
+	// Menus are the rich menus of the channel.
+	Menus []*richMenu `json:"menus"`
+	// Links maps user IDs to the name of the menu linked to them.
+	Links map[string]string `json:"links"`
+}
+
+type richMenu struct {
+	line.RichMenu
+	// Image is the path of the image, relative to the definition file.
+	Image string `json:"image"`
+
+	key   string
+	image []byte
+}
+
+// loadRichMenus reads the definition file and the images, and keys every menu by "name#hash".
+//
+// The key is used as the name of the menu on LINE, as rich menus could not be updated,
+// a changed definition or image will end up with a new key to be created and the old one to be deleted.
+func loadRichMenus(file string) (*richMenuDef, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	def := &richMenuDef{}
+	if err := json.Unmarshal(content, def); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	names := map[string]bool{}
+	for _, m := range def.Menus {
+		if m.Name == "" || strings.Contains(m.Name, "#") {
+			return nil, fmt.Errorf("%s: menu name should not be empty or contain #: %q", file, m.Name)
+		}
+		if names[m.Name] {
+			return nil, fmt.Errorf("%s: duplicated menu %q", file, m.Name)
+		}
+		names[m.Name] = true
+
+		if m.image, err = os.ReadFile(filepath.Join(filepath.Dir(file), m.Image)); err != nil {
+			return nil, fmt.Errorf("menu %s: %w", m.Name, err)
+		}
+		spec, _ := json.Marshal(&m.RichMenu)
+		sum := sha256.Sum256(append(spec, m.image...))
+		m.key = m.Name + "#" + hex.EncodeToString(sum[:])[:8]
+	}
+	if def.Default != "" && !names[def.Default] {
+		return nil, fmt.Errorf("%s: default menu %q is not defined", file, def.Default)
+	}
+	for user, name := range def.Links {
+		if !names[name] {
+			return nil, fmt.Errorf("%s: menu %q linked to %s is not defined", file, name, user)
+		}
+	}
+	return def, nil
+}
+
+// syncRichMenus makes the rich menus on LINE match @def, changes are printed and only applied if not @dryRun.
+func syncRichMenus(ctx context.Context, def *richMenuDef, dryRun bool) error {
+	client := line.Default()
+	current, err := client.RichMenus(ctx)
+	if err != nil {
+		return err
+	}
+
+	// keys to ids of the menus
+	ids := map[string]string{}
+	differ := util.NewDiffer()
+	for _, m := range current {
+		ids[m.Name] = m.RichMenuID
+		differ.SetCurrent(m.Name)
+	}
+	targets := map[string]*richMenu{}
+	for _, m := range def.Menus {
+		targets[m.key] = m
+		differ.SetTarget(m.key)
+	}
+	add, remove, keep := differ.ToBeAdd(), differ.ToBeRemove(), differ.Unchanged()
+	sort.Strings(add)
+	sort.Strings(remove)
+	sort.Strings(keep)
+	for _, k := range keep {
+		fmt.Printf("  %s\n", k)
+	}
+	for _, k := range add {
+		fmt.Printf("+ %s\n", k)
+	}
+	for _, k := range remove {
+		fmt.Printf("- %s\n", k)
+	}
+
+	for _, k := range add {
+		if dryRun {
+			continue
+		}
+		m := targets[k]
+		menu := m.RichMenu
+		menu.RichMenuID, menu.Name = "", k
+		id, err := client.CreateRichMenu(ctx, &menu)
+		if err != nil {
+			return fmt.Errorf("create %s: %w", k, err)
+		}
+		ids[k] = id
+		if err := client.UploadRichMenuImage(ctx, id, imageType(m.Image), m.image); err != nil {
+			return fmt.Errorf("upload image of %s: %w", k, err)
+		}
+	}
+
+	keyOf := map[string]string{}
+	for _, m := range def.Menus {
+		keyOf[m.Name] = m.key
+	}
+
+	if def.Default != "" {
+		defID, err := client.DefaultRichMenu(ctx)
+		if err != nil {
+			return err
+		}
+		if key := keyOf[def.Default]; defID == "" || defID != ids[key] {
+			fmt.Printf("* default → %s\n", key)
+			if !dryRun {
+				if err := client.SetDefaultRichMenu(ctx, ids[key]); err != nil {
+					return fmt.Errorf("set default %s: %w", key, err)
+				}
+			}
+		}
+	}
+
+	users := make([]string, 0, len(def.Links))
+	for user := range def.Links {
+		users = append(users, user)
+	}
+	sort.Strings(users)
+	for _, user := range users {
+		key := keyOf[def.Links[user]]
+		linked, err := client.UserRichMenu(ctx, user)
+		if err != nil {
+			return err
+		}
+		if linked != "" && linked == ids[key] {
+			continue
+		}
+		fmt.Printf("* %s → %s\n", user, key)
+		if !dryRun {
+			if err := client.LinkRichMenu(ctx, user, ids[key]); err != nil {
+				return fmt.Errorf("link %s to %s: %w", key, user, err)
+			}
+		}
+	}
+
+	for _, k := range remove {
+		if dryRun {
+			continue
+		}
+		if err := client.DeleteRichMenu(ctx, ids[k]); err != nil {
+			return fmt.Errorf("delete %s: %w", k, err)
+		}
+	}
+
+	if dryRun {
+		fmt.Println("dry run, nothing applied.")
+	}
+	return nil
+}
+
+func imageType(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jpg", ".jpeg":
+		return "image/jpeg"
+	default:
+		return "image/png"
+	}
+}
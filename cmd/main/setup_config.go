@@ -3,13 +3,31 @@ package main
 import (
 	"fmt"
 	"path/filepath"
+	"time"
 
+	"app/core/account"
 	"app/core/auth"
 	"app/core/config"
+	"app/core/conversation"
+	"app/core/cron"
+	"app/core/dedup"
+	"app/core/digest"
+	"app/core/line"
 	"app/core/logger"
 	"app/core/msg"
+	"app/core/outbox"
+	"app/core/postback"
 	"app/core/property"
 	"app/core/server"
+	"app/core/storage"
+	"app/core/util"
+	"app/modules/bridge"
+	"app/modules/calendar"
+	"app/modules/expense"
+	"app/modules/media"
+	"app/modules/note"
+	"app/modules/reminder"
+	"app/modules/webhook"
 	"app/src/messages"
 
 	"golang.org/x/exp/slog"
@@ -28,10 +46,6 @@ func setup_config() error {
 		property.RUN_MODE = property.PROD_MODE
 	}
 
-	if config.GetString(property.SECRET) != "" {
-		auth.Secret = []byte(config.GetString(property.SECRET))
-	}
-
 	if property.IsDebug() {
 		for k, v := range config.Dump() {
 			fmt.Printf("%s=%s\n", k, v)
@@ -51,6 +65,178 @@ func setup_config() error {
 	}
 	server.SetLogger(logger.NewLogger("access"))
 
+	//-------------------------------------------------
+	//- Setup signing key                             -
+	//-------------------------------------------------
+
+	// the key signs tokens, postbacks and links, it should not change on restart
+	if config.GetString(property.SECRET) != "" {
+		auth.Secret = []byte(config.GetString(property.SECRET))
+	} else {
+		path := config.GetString(property.SECRET_FILE)
+		created, err := auth.LoadSecret(path)
+		if err != nil {
+			return err
+		}
+		slog.Warn("SECRET is not set, using the key kept in a file which should be backed up with the database",
+			slog.String("mod", "main"), slog.String("act", "setup"), slog.String("path", path), slog.Bool("created", created))
+	}
+
+	//-------------------------------------------------
+	//- Setup LINE client                             -
+	//-------------------------------------------------
+
+	line.Setup(&line.Option{
+		Token:    config.GetString(property.LINE_CHANNEL_TOKEN),
+		BaseURL:  config.GetString(property.LINE_API_URL),
+		DataURL:  config.GetString(property.LINE_DATA_API_URL),
+		MaxRetry: config.GetInt(property.LINE_MAX_RETRY),
+	})
+
+	store, err := dedup.ParseStore(config.GetString(property.LINE_DEDUP_STORE))
+	if err != nil {
+		return err
+	}
+	dedup.Setup(&dedup.Option{Store: store, TTL: config.GetDuration(property.LINE_DEDUP_TTL)})
+
+	//-------------------------------------------------
+	//- Setup accounts, LINE Login and LIFF           -
+	//-------------------------------------------------
+
+	account.Setup(&account.Option{
+		Admins: util.SplitTrim(config.GetString(property.LINE_ADMINS), ","),
+		Group:  account.ParseGroup(config.GetString(property.LINE_USER_GROUP), auth.USER),
+		Dept:   config.GetString(property.LINE_USER_DEPT),
+	})
+	account.SetupLogin(&account.LoginOption{
+		ChannelID:     config.GetString(property.LINE_LOGIN_CHANNEL_ID),
+		ChannelSecret: config.GetString(property.LINE_LOGIN_CHANNEL_SECRET),
+		RedirectURI:   config.GetString(property.LINE_LOGIN_REDIRECT_URI),
+		AuthorizeURL:  config.GetString(property.LINE_LOGIN_AUTHORIZE_URL),
+		TokenURL:      config.GetString(property.LINE_LOGIN_TOKEN_URL),
+		Issuer:        config.GetString(property.LINE_LOGIN_ISSUER),
+	})
+	account.SetupLIFF(&account.LIFFOption{
+		ID:         config.GetString(property.LIFF_ID),
+		ChannelID:  config.GetString(property.LINE_LOGIN_CHANNEL_ID),
+		VerifyURL:  config.GetString(property.LIFF_VERIFY_URL),
+		ProfileURL: config.GetString(property.LIFF_PROFILE_URL),
+	})
+
+	//-------------------------------------------------
+	//- Setup conversations and postbacks             -
+	//-------------------------------------------------
+
+	conversation.Setup(&conversation.Option{
+		CancelWords: util.SplitTrim(config.GetString(property.CONVERSATION_CANCEL), ","),
+		TTL:         config.GetDuration(property.CONVERSATION_TTL),
+	})
+	postback.Setup(&postback.Option{TTL: config.GetDuration(property.POSTBACK_TTL)})
+
+	//-------------------------------------------------
+	//- Setup outbox                                  -
+	//-------------------------------------------------
+
+	outbox.Setup(&outbox.Option{
+		MaxAttempts:  config.GetInt(property.OUTBOX_MAX_ATTEMPTS),
+		Backoff:      config.GetDuration(property.OUTBOX_BACKOFF),
+		FlushTimeout: config.GetDuration(property.OUTBOX_FLUSH_TIMEOUT),
+	})
+
+	//-------------------------------------------------
+	//- Setup outgoing webhooks                       -
+	//-------------------------------------------------
+
+	webhook.Setup(&webhook.Option{
+		MaxAttempts:  config.GetInt(property.WEBHOOK_MAX_ATTEMPTS),
+		Backoff:      config.GetDuration(property.WEBHOOK_BACKOFF),
+		Timeout:      config.GetDuration(property.WEBHOOK_TIMEOUT),
+		Retention:    config.GetDuration(property.WEBHOOK_RETENTION),
+		AllowPrivate: config.GetBool(property.WEBHOOK_ALLOW_PRIVATE),
+	})
+
+	//-------------------------------------------------
+	//- Setup cron jobs                               -
+	//-------------------------------------------------
+
+	cron.Setup(&cron.Option{NoCron: config.GetBool(property.NO_CRON)})
+
+	//-------------------------------------------------
+	//- Setup reminders                               -
+	//-------------------------------------------------
+
+	reminder.Setup(&reminder.Option{
+		NoCron:   config.GetBool(property.NO_CRON),
+		Grace:    config.GetDuration(property.REMINDER_GRACE),
+		Snooze:   config.GetDuration(property.REMINDER_SNOOZE),
+		Interval: config.GetDuration(property.REMINDER_INTERVAL),
+	})
+
+	//-------------------------------------------------
+	//- Setup daily digest                            -
+	//-------------------------------------------------
+
+	digestOpt := &digest.Option{At: config.GetString(property.DIGEST_AT)}
+	if tz := config.GetString(property.DIGEST_TZ); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return err
+		}
+		digestOpt.Location = loc
+	}
+	if err := digest.Setup(digestOpt); err != nil {
+		return err
+	}
+
+	//-------------------------------------------------
+	//- Setup calendar                                -
+	//-------------------------------------------------
+
+	calendar.Setup(&calendar.Option{
+		BaseURL: config.GetString(property.BASE_URL),
+		Refresh: config.GetDuration(property.CALENDAR_REFRESH),
+	})
+
+	//-------------------------------------------------
+	//- Setup notes                                   -
+	//-------------------------------------------------
+
+	note.Setup(&note.Option{AutoSave: config.GetBool(property.NOTE_AUTO_SAVE)})
+
+	//-------------------------------------------------
+	//- Setup expenses                                -
+	//-------------------------------------------------
+
+	expense.Setup(&expense.Option{
+		Currency: config.GetString(property.EXPENSE_CURRENCY),
+		BaseURL:  config.GetString(property.BASE_URL),
+		TTL:      config.GetDuration(property.EXPENSE_REPORT_TTL),
+	})
+
+	//-------------------------------------------------
+	//- Setup storage and media                       -
+	//-------------------------------------------------
+
+	err = storage.Setup(&storage.Option{
+		Backend: config.GetString(property.STORAGE_BACKEND),
+		Dir:     config.GetString(property.STORAGE_DIR),
+		S3: storage.S3Option{
+			Endpoint:  config.GetString(property.S3_ENDPOINT),
+			Region:    config.GetString(property.S3_REGION),
+			Bucket:    config.GetString(property.S3_BUCKET),
+			AccessKey: config.GetString(property.S3_ACCESS_KEY),
+			SecretKey: config.GetString(property.S3_SECRET_KEY),
+			PathStyle: config.GetBool(property.S3_PATH_STYLE),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	media.Setup(&media.Option{
+		BaseURL: config.GetString(property.BASE_URL),
+		TTL:     config.GetDuration(property.MEDIA_URL_TTL),
+	})
+
 	//-------------------------------------------------
 	//- Load language packs                           -
 	//-------------------------------------------------
@@ -67,5 +253,13 @@ func setup_config() error {
 			slog.String("path", cust_msg))
 	}
 
+	//-------------------------------------------------
+	//- Load inbound webhook endpoints                -
+	//-------------------------------------------------
+
+	if err := bridge.LoadFile(filepath.Join(config.GetString(property.CUSTOM), property.CUST_BRIDGE)); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -1 +1,29 @@
 package main
+
+import (
+	"context"
+	"database/sql"
+
+	"app/core/config"
+	"app/core/database"
+	_ "app/core/driver"
+	"app/core/property"
+)
+
+// connect connects to the database and migrates the schema if @migrate is set.
+func connect(migrate bool) (*sql.DB, error) {
+	property.SetState(property.STATE_CONN)
+	client, err := database.Open(config.GetString(property.DB), config.GetString(property.DSN))
+	if err != nil {
+		return nil, err
+	}
+
+	if migrate {
+		property.SetState(property.STATE_DBM)
+		if err := database.Migrate(context.Background()); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+	return client, nil
+}
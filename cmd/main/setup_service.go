@@ -1,16 +1,40 @@
 package main
 
 import (
+	"app/core/account"
+	"app/core/command"
 	"app/core/config"
+	"app/core/conversation"
+	"app/core/cron"
+	"app/core/dedup"
+	"app/core/group"
+	"app/core/line"
+	"app/core/outbox"
+	"app/core/postback"
 	"app/core/property"
 	"app/core/server"
+	"app/core/service"
+	"app/core/util"
+	"app/modules/bridge"
+	"app/modules/calendar"
+	"app/modules/expense"
+	"app/modules/media"
+	"app/modules/note"
+	"app/modules/reminder"
+	"app/modules/todo"
+	"app/modules/webhook"
+	pb "app/service"
 	"app/src"
+	"app/src/web"
 	"context"
 	_ "embed"
+	"io"
+	"io/fs"
 	"net/http"
 	"strings"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"golang.org/x/exp/slog"
 	"google.golang.org/grpc"
 	"kumoly.io/lib/swaggerui"
 )
@@ -25,6 +49,22 @@ func setup_service() (*server.Server, error) {
 	//-------------------------------------------------
 	//- Initiate and Register gRPC Services           -
 	//-------------------------------------------------
+	command.SetUserResolver(account.Resolver)
+	command.SetChatResolver(group.Resolver)
+	outbox.SetHolder(group.Hold)
+	service.Register(conversation.Service)
+	service.Register(postback.Service)
+	service.Register(group.Service)
+	service.Register(command.Router)
+	service.Register(outbox.Service)
+	service.Register(reminder.Service)
+	service.Register(media.Service)
+	service.Register(todo.Service)
+	service.Register(expense.Service)
+	service.Register(note.Service)
+	service.Register(calendar.Service)
+	service.Register(webhook.Service)
+	service.Register(cron.Service)
 
 	return server.NewServer(&server.Option{
 		GrpcAddr: config.GetString(property.GRPC_ADDR), GrpcPort: config.GetString(property.GRPC_PORT),
@@ -35,6 +75,10 @@ func setup_service() (*server.Server, error) {
 		//-------------------------------------------------
 		Service: func(gsrv *grpc.Server) {
 			// service.RegisterCoreServiceServer(gsrv, coreSvc)
+			pb.RegisterOutboxServiceServer(gsrv, outbox.Server)
+			pb.RegisterGroupServiceServer(gsrv, group.Server)
+			pb.RegisterTodoServiceServer(gsrv, todo.Server)
+			pb.RegisterNoteServiceServer(gsrv, note.Server)
 		},
 
 		//-------------------------------------------------
@@ -42,21 +86,56 @@ func setup_service() (*server.Server, error) {
 		//-------------------------------------------------
 		Proxy: func(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) {
 			// service.RegisterCoreServiceHandlerFromEndpoint(ctx, mux, endpoint, opts)
+			pb.RegisterOutboxServiceHandlerFromEndpoint(ctx, mux, endpoint, opts)
+			pb.RegisterGroupServiceHandlerFromEndpoint(ctx, mux, endpoint, opts)
+			pb.RegisterTodoServiceHandlerFromEndpoint(ctx, mux, endpoint, opts)
+			pb.RegisterNoteServiceHandlerFromEndpoint(ctx, mux, endpoint, opts)
 
 			// add http only handlers
 			// mux.HandlePath("POST", "/api/insp_item/import", pmmSvc.ImportInspection)
+			mux.HandlePath("GET", expense.ReportPath, expense.Download)
+			mux.HandlePath("POST", calendar.ImportPath, calendar.Upload)
+			mux.HandlePath("GET", account.LIFFPath, account.LIFFConfig)
+			mux.HandlePath("POST", account.LIFFPath, account.LIFFToken)
+			mux.HandlePath("GET", dedup.StatsPath, dedup.ServeStats)
+			mux.HandlePath("POST", bridge.Path, bridge.Receive)
+			mux.HandlePath("GET", webhook.HooksPath, webhook.List)
+			mux.HandlePath("POST", webhook.HooksPath, webhook.Create)
+			mux.HandlePath("DELETE", webhook.HookPath, webhook.Remove)
+			mux.HandlePath("GET", webhook.DeliveriesPath, webhook.Deliveries)
+			mux.HandlePath("POST", webhook.RedeliverPath, webhook.Redeliver)
 		},
 
 		//-------------------------------------------------
 		//- Setup Router                                  -
 		//-------------------------------------------------
 		Routing: func(sm http.Handler) http.Handler {
+			fileserver := http.FileServer(http.FS(web.FS))
 			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				switch {
 				case strings.HasPrefix(r.URL.Path, "/api"):
 					// gRPC gateway
 					sm.ServeHTTP(w, r)
 
+				case r.URL.Path == "/auth/line/login":
+					// LINE Login
+					account.Login(w, r)
+
+				case r.URL.Path == "/auth/line/callback":
+					account.Callback(w, r)
+
+				case strings.HasPrefix(r.URL.Path, media.Prefix):
+					// signed download links of media
+					media.Download(w, r)
+
+				case strings.HasPrefix(r.URL.Path, calendar.Prefix):
+					// tokenized calendar feeds
+					calendar.Feed(w, r)
+
+				case r.URL.Path == "/webhook/line":
+					// LINE Messaging API webhook
+					line.Webhook(w, r)
+
 				case strings.HasPrefix(r.URL.Path, "/swagger"):
 					switch r.URL.Path {
 					case "/swagger":
@@ -69,17 +148,21 @@ func setup_service() (*server.Server, error) {
 					}
 
 				default:
-					// default spa website
-					// stat, err := fs.Stat(webopi.FS, strings.TrimPrefix(r.URL.Path, "/"))
-					// if err != nil || stat.IsDir() {
-					// 	index, err := webopi.FS.Open("index.html")
-					// 	if err != nil {
-					// 		slog.Error("failed to read index.html", util.ErrAtrr(err))
-					// 	}
-					// 	io.Copy(w, index)
-					// 	return
-					// }
-					// fileserver.ServeHTTP(w, r)
+					// default spa website, opened in LINE with LIFF
+					stat, err := fs.Stat(web.FS, strings.TrimPrefix(r.URL.Path, "/"))
+					if err != nil || stat.IsDir() {
+						index, err := web.FS.Open("index.html")
+						if err != nil {
+							slog.Error("failed to read index.html", util.ErrAtrr(err), slog.String("mod", "main"), slog.String("act", "spa"))
+							w.WriteHeader(http.StatusNotFound)
+							return
+						}
+						defer index.Close()
+						w.Header().Set("Content-Type", "text/html; charset=utf-8")
+						io.Copy(w, index)
+						return
+					}
+					fileserver.ServeHTTP(w, r)
 				}
 			})
 		},
@@ -0,0 +1,161 @@
+/*
+	account.go
+	Purpose: Map LINE users to local users.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Add Name to look up display names
+	2026/10/17  v1.0.2 Evan Chen   Sign in web apps opened with LIFF
+*/
+
+// Package account maps LINE users to local users and signs them in with LINE Login,
+// or with the tokens of the LIFF SDK in web apps opened in LINE.
+//
+// A local user is created on first sight of a LINE user, with the default group and dept,
+// while users in the admin allowlist are always resolved as [auth.ADMIN].
+package account
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"app/core/auth"
+	"app/core/database"
+	"app/core/line"
+	"app/core/util"
+
+	"golang.org/x/exp/slog"
+)
+
+func init() {
+	database.Register(&database.Migration{
+		ID: "20261017_account",
+		Stmts: []string{`CREATE TABLE accounts (
+			line_user_id TEXT PRIMARY KEY,
+			display_name TEXT NOT NULL DEFAULT '',
+			picture_url TEXT NOT NULL DEFAULT '',
+			grp INTEGER NOT NULL,
+			dept TEXT NOT NULL DEFAULT '',
+			created_at BIGINT NOT NULL,
+			updated_at BIGINT NOT NULL
+		)`},
+	})
+}
+
+// Option configures how LINE users are mapped to local users.
+type Option struct {
+	// Admins are the LINE user IDs that are always resolved as [auth.ADMIN].
+	Admins []string
+	// Group is the group of new users.
+	Group auth.Group
+	// Dept is the dept of new users.
+	Dept string
+}
+
+var opt = Option{Group: auth.USER}
+
+// Setup sets the mapping options.
+func Setup(o *Option) { opt = *o }
+
+// ParseGroup parses the name of a group, e.g. "user" or "admin", @def is returned if unknown.
+func ParseGroup(name string, def auth.Group) auth.Group {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "custom":
+		return auth.CUSTOM
+	case "user":
+		return auth.USER
+	case "admin":
+		return auth.ADMIN
+	default:
+		return def
+	}
+}
+
+// Profile is the public profile of a LINE user.
+type Profile struct {
+	DisplayName string
+	PictureURL  string
+}
+
+// Resolve maps @lineUserID to a local user, the user is created if not exist.
+// The stored profile is updated if @p is not nil.
+func Resolve(ctx context.Context, lineUserID string, p *Profile) (*auth.UserInfo, error) {
+	usr := &auth.UserInfo{Username: lineUserID}
+	var grp int
+	now := time.Now().Unix()
+	err := database.DB().QueryRowContext(ctx,
+		"SELECT grp, dept FROM accounts WHERE line_user_id = $1", lineUserID,
+	).Scan(&grp, &usr.Dept)
+	switch {
+	case err == sql.ErrNoRows:
+		if p == nil {
+			p = &Profile{}
+		}
+		usr.Group, usr.Dept = opt.Group, opt.Dept
+		_, err = database.DB().ExecContext(ctx, `INSERT INTO accounts
+			(line_user_id, display_name, picture_url, grp, dept, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $6) ON CONFLICT (line_user_id) DO NOTHING`,
+			lineUserID, p.DisplayName, p.PictureURL, int(usr.Group), usr.Dept, now)
+		if err != nil {
+			return nil, database.Err(err)
+		}
+	case err != nil:
+		return nil, database.Err(err)
+	default:
+		usr.Group = auth.Group(grp)
+		if p != nil {
+			_, err = database.DB().ExecContext(ctx,
+				"UPDATE accounts SET display_name = $1, picture_url = $2, updated_at = $3 WHERE line_user_id = $4",
+				p.DisplayName, p.PictureURL, now, lineUserID)
+			if err != nil {
+				return nil, database.Err(err)
+			}
+		}
+	}
+
+	if IsAdmin(lineUserID) {
+		usr.Group = auth.ADMIN
+	}
+	return usr, nil
+}
+
+// Name returns the display name of @lineUserID, or the ID itself if the name is unknown.
+func Name(ctx context.Context, lineUserID string) string {
+	var name string
+	err := database.DB().QueryRowContext(ctx,
+		"SELECT display_name FROM accounts WHERE line_user_id = $1", lineUserID).Scan(&name)
+	if err != nil || name == "" {
+		return lineUserID
+	}
+	return name
+}
+
+// IsAdmin checks if @lineUserID is in the admin allowlist.
+func IsAdmin(lineUserID string) bool {
+	for _, id := range opt.Admins {
+		if id == lineUserID {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolver resolves the sender of an event to a local user, it could be set to the command router.
+func Resolver(ctx context.Context, src *line.Source) *auth.UserInfo {
+	if src.UserID == "" {
+		return nil
+	}
+	usr, err := Resolve(ctx, src.UserID, nil)
+	if err != nil {
+		slog.Error("resolve user failed", util.ErrAtrr(err),
+			slog.String("mod", "account"), slog.String("act", "resolve"), slog.String("usr", src.UserID))
+		return &auth.UserInfo{Username: src.UserID, Group: auth.USER}
+	}
+	return usr
+}
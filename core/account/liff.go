@@ -0,0 +1,232 @@
+/*
+	liff.go
+	Purpose: Exchange the tokens of LIFF apps for our own tokens.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package account
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"app/core/auth"
+	"app/core/errors"
+	"app/core/line"
+	"app/core/server"
+
+	"golang.org/x/exp/slog"
+)
+
+// Endpoints to verify the tokens of LIFF apps.
+const (
+	DefaultVerifyURL  = "https://api.line.me/oauth2/v2.1/verify"
+	DefaultProfileURL = "https://api.line.me/v2/profile"
+)
+
+// LIFFPath is the path of the gateway to get the LIFF settings and exchange tokens, see [LIFFConfig] and [LIFFToken].
+const LIFFPath = "/api/auth/liff"
+
+// LIFFOption configures the web app opened in LINE with LIFF.
+//
+//   - https://developers.line.biz/en/docs/liff/using-user-profile/#sending-id-token
+type LIFFOption struct {
+	// ID is the LIFF ID of the web app, which the web app initializes the LIFF SDK with.
+	ID string
+	// ChannelID is the LINE Login channel the LIFF app belongs to, which is the audience of the tokens.
+	ChannelID string
+	// VerifyURL overrides [DefaultVerifyURL], it could point to a stub for testing.
+	VerifyURL string
+	// ProfileURL overrides [DefaultProfileURL], which gets the user of an access token.
+	ProfileURL string
+	// HTTPClient is the client to verify tokens with, defaults to a client with 10s timeout.
+	HTTPClient *http.Client
+}
+
+var liff = LIFFOption{
+	VerifyURL:  DefaultVerifyURL,
+	ProfileURL: DefaultProfileURL,
+	HTTPClient: &http.Client{Timeout: 10 * time.Second},
+}
+
+// SetupLIFF sets the LIFF options, empty endpoints are left unchanged.
+func SetupLIFF(o *LIFFOption) {
+	def := liff
+	liff = *o
+	if liff.VerifyURL == "" {
+		liff.VerifyURL = def.VerifyURL
+	}
+	if liff.ProfileURL == "" {
+		liff.ProfileURL = def.ProfileURL
+	}
+	if liff.HTTPClient == nil {
+		liff.HTTPClient = def.HTTPClient
+	}
+}
+
+// LIFFConfig serves the LIFF ID to the web app, e.g. {"liffId": "1234-abcd"}.
+// It is a handler of the gateway mux, see [runtime.ServeMux.HandlePath].
+func LIFFConfig(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	if liff.ID == "" {
+		server.HttpAbort(w, r, errors.ErrServiceUnavailable.SetInfo("LIFF is not configured"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"liffId": liff.ID})
+}
+
+// LIFFToken exchanges the ID token or access token of the LIFF SDK for our token.
+// The body is {"idToken": "..."} or {"accessToken": "..."}, and {"token": "..."} is replied.
+// The ID token is preferred as it carries the profile, while an access token needs another request for it.
+// It is a handler of the gateway mux, see [runtime.ServeMux.HandlePath].
+func LIFFToken(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	if liff.ChannelID == "" {
+		server.HttpAbort(w, r, errors.ErrServiceUnavailable.SetInfo("LIFF is not configured"))
+		return
+	}
+	body := &struct {
+		IDToken     string `json:"idToken"`
+		AccessToken string `json:"accessToken"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		server.HttpAbort(w, r, errors.ErrBadRequest.SetInfo(err.Error()))
+		return
+	}
+
+	var (
+		userID string
+		p      *Profile
+		err    *errors.Error
+	)
+	switch {
+	case body.IDToken != "":
+		userID, p, err = verifyLIFFIDToken(r, body.IDToken)
+	case body.AccessToken != "":
+		userID, p, err = verifyLIFFAccessToken(r, body.AccessToken)
+	default:
+		err = errors.ErrBadRequest.SetInfo("idToken or accessToken is required")
+	}
+	if err != nil {
+		server.HttpAbort(w, r, err)
+		return
+	}
+
+	usr, rerr := Resolve(r.Context(), userID, p)
+	if rerr != nil {
+		server.HttpAbort(w, r, errors.Convert(rerr))
+		return
+	}
+	tok, terr := auth.NewClaim(usr).Token()
+	if terr != nil {
+		server.HttpAbort(w, r, errors.ErrInternal.SetInfo(terr.Error()))
+		return
+	}
+	slog.Info("signed in", slog.String("mod", "account"), slog.String("act", "liff"),
+		slog.String("usr", usr.Username), slog.Int("grp", int(usr.Group)))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"token": tok})
+}
+
+// verifyError is the error replied by the verify endpoint.
+type verifyError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// verifyLIFFIDToken verifies @tok at the verify endpoint, and checks its audience and expiry.
+func verifyLIFFIDToken(r *http.Request, tok string) (string, *Profile, *errors.Error) {
+	form := url.Values{"id_token": {tok}, "client_id": {liff.ChannelID}}
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodPost, liff.VerifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", nil, errors.ErrInternal.SetInfo(err.Error())
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	claims := &struct {
+		verifyError
+		Subject  string `json:"sub"`
+		Audience string `json:"aud"`
+		Expires  int64  `json:"exp"`
+		Name     string `json:"name"`
+		Picture  string `json:"picture"`
+	}{}
+	if err := call(req, claims, &claims.verifyError); err != nil {
+		return "", nil, err
+	}
+	switch {
+	case claims.Audience != liff.ChannelID:
+		return "", nil, errors.ErrUnauthorized.SetInfo("audience mismatch")
+	case time.Now().Unix() >= claims.Expires:
+		return "", nil, errors.ErrUnauthorized.SetInfo("token expired")
+	case claims.Subject == "":
+		return "", nil, errors.ErrUnauthorized.SetInfo("subject missing")
+	}
+	return claims.Subject, &Profile{DisplayName: claims.Name, PictureURL: claims.Picture}, nil
+}
+
+// verifyLIFFAccessToken verifies @tok at the verify endpoint, checks its channel and expiry,
+// and gets the profile of its user.
+func verifyLIFFAccessToken(r *http.Request, tok string) (string, *Profile, *errors.Error) {
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet,
+		liff.VerifyURL+"?"+url.Values{"access_token": {tok}}.Encode(), nil)
+	if err != nil {
+		return "", nil, errors.ErrInternal.SetInfo(err.Error())
+	}
+	info := &struct {
+		verifyError
+		ClientID  string `json:"client_id"`
+		ExpiresIn int64  `json:"expires_in"`
+	}{}
+	if err := call(req, info, &info.verifyError); err != nil {
+		return "", nil, err
+	}
+	switch {
+	case info.ClientID != liff.ChannelID:
+		return "", nil, errors.ErrUnauthorized.SetInfo("audience mismatch")
+	case info.ExpiresIn <= 0:
+		return "", nil, errors.ErrUnauthorized.SetInfo("token expired")
+	}
+
+	req, err = http.NewRequestWithContext(r.Context(), http.MethodGet, liff.ProfileURL, nil)
+	if err != nil {
+		return "", nil, errors.ErrInternal.SetInfo(err.Error())
+	}
+	req.Header.Set("Authorization", "Bearer "+tok)
+	prof := &struct {
+		verifyError
+		line.UserProfile
+	}{}
+	if err := call(req, prof, &prof.verifyError); err != nil {
+		return "", nil, err
+	}
+	if prof.UserID == "" {
+		return "", nil, errors.ErrUnauthorized.SetInfo("user missing")
+	}
+	return prof.UserID, &Profile{DisplayName: prof.DisplayName, PictureURL: prof.PictureURL}, nil
+}
+
+// call sends @req and decodes the reply into @v, a reply other than 200 is an [errors.ErrUnauthorized] with @e.
+func call(req *http.Request, v any, e *verifyError) *errors.Error {
+	res, err := liff.HTTPClient.Do(req)
+	if err != nil {
+		return errors.ErrServiceUnavailable.SetInfo(err.Error())
+	}
+	defer res.Body.Close()
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil && res.StatusCode == http.StatusOK {
+		return errors.ErrServiceUnavailable.SetInfo(err.Error())
+	}
+	if res.StatusCode != http.StatusOK {
+		return errors.ErrUnauthorized.SetInfo(e.Error + ": " + e.ErrorDescription)
+	}
+	return nil
+}
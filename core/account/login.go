@@ -0,0 +1,299 @@
+/*
+	login.go
+	Purpose: Sign in with LINE Login and issue our own tokens.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package account
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"app/core/auth"
+	"app/core/errors"
+	"app/core/server"
+	"app/core/util"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/exp/slog"
+)
+
+// Endpoints of LINE Login v2.1.
+const (
+	DefaultAuthorizeURL = "https://access.line.me/oauth2/v2.1/authorize"
+	DefaultTokenURL     = "https://api.line.me/oauth2/v2.1/token"
+	DefaultIssuer       = "https://access.line.me"
+)
+
+// loginCookie keeps the state of an ongoing login between the authorize and callback requests.
+const loginCookie = "line_login"
+
+// loginTTL is how long a user could take to sign in at LINE.
+const loginTTL = 10 * time.Minute
+
+// LoginOption configures LINE Login.
+//
+//   - https://developers.line.biz/en/docs/line-login/integrate-line-login/
+type LoginOption struct {
+	// ChannelID is the channel ID of the LINE Login channel.
+	ChannelID string
+	// ChannelSecret is the channel secret of the LINE Login channel, which also signs the ID tokens.
+	ChannelSecret string
+	// RedirectURI is the callback url registered in the channel.
+	RedirectURI string
+	// AuthorizeURL overrides [DefaultAuthorizeURL].
+	AuthorizeURL string
+	// TokenURL overrides [DefaultTokenURL], it could point to a mock IdP for testing.
+	TokenURL string
+	// Issuer overrides [DefaultIssuer], which is checked against the ID token.
+	Issuer string
+	// HTTPClient is the client to exchange the code with, defaults to a client with 10s timeout.
+	HTTPClient *http.Client
+}
+
+var login = LoginOption{
+	AuthorizeURL: DefaultAuthorizeURL,
+	TokenURL:     DefaultTokenURL,
+	Issuer:       DefaultIssuer,
+	HTTPClient:   &http.Client{Timeout: 10 * time.Second},
+}
+
+// SetupLogin sets the LINE Login options, empty endpoints are left unchanged.
+func SetupLogin(o *LoginOption) {
+	def := login
+	login = *o
+	if login.AuthorizeURL == "" {
+		login.AuthorizeURL = def.AuthorizeURL
+	}
+	if login.TokenURL == "" {
+		login.TokenURL = def.TokenURL
+	}
+	if login.Issuer == "" {
+		login.Issuer = def.Issuer
+	}
+	if login.HTTPClient == nil {
+		login.HTTPClient = def.HTTPClient
+	}
+}
+
+// loginState is stored in a signed cookie, so no server side storage is needed.
+type loginState struct {
+	State    string `json:"s"`
+	Nonce    string `json:"n"`
+	Verifier string `json:"v"`
+	Redirect string `json:"r,omitempty"`
+	Expires  int64  `json:"e"`
+}
+
+// Login redirects the user to LINE Login with PKCE, state and nonce.
+//
+// An optional "redirect" query sets the path of our web UI to return to after signing in,
+// the token is then passed as the "token" of the url fragment.
+func Login(w http.ResponseWriter, r *http.Request) {
+	if login.ChannelID == "" {
+		server.HttpAbort(w, r, errors.ErrServiceUnavailable.SetInfo("LINE Login is not configured"))
+		return
+	}
+	st := &loginState{
+		State:    util.RandStr(32),
+		Nonce:    util.RandStr(32),
+		Verifier: util.RandStr(64),
+		Redirect: localPath(r.URL.Query().Get("redirect")),
+		Expires:  time.Now().Add(loginTTL).Unix(),
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     loginCookie,
+		Value:    sign(st),
+		Path:     "/",
+		MaxAge:   int(loginTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	challenge := sha256.Sum256([]byte(st.Verifier))
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {login.ChannelID},
+		"redirect_uri":          {login.RedirectURI},
+		"state":                 {st.State},
+		"scope":                 {"openid profile"},
+		"nonce":                 {st.Nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	http.Redirect(w, r, login.AuthorizeURL+"?"+q.Encode(), http.StatusFound)
+}
+
+// Callback exchanges the code for the ID token, maps the LINE user to a local user and issues our token.
+func Callback(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if e := q.Get("error"); e != "" {
+		server.HttpAbort(w, r, errors.ErrUnauthorized.SetInfo(e+": "+q.Get("error_description")))
+		return
+	}
+
+	cookie, err := r.Cookie(loginCookie)
+	if err != nil {
+		server.HttpAbort(w, r, errors.ErrBadRequest.SetInfo("login state not found"))
+		return
+	}
+	http.SetCookie(w, &http.Cookie{Name: loginCookie, Path: "/", MaxAge: -1})
+	st, ok := verify(cookie.Value)
+	if !ok || time.Now().Unix() > st.Expires {
+		server.HttpAbort(w, r, errors.ErrBadRequest.SetInfo("login state expired"))
+		return
+	}
+	if !hmac.Equal([]byte(q.Get("state")), []byte(st.State)) {
+		server.HttpAbort(w, r, errors.ErrUnauthorized.SetInfo("state mismatch"))
+		return
+	}
+
+	idToken, err := exchange(r, q.Get("code"), st.Verifier)
+	if err != nil {
+		server.HttpAbort(w, r, errors.Convert(err))
+		return
+	}
+	claims, err := verifyIDToken(idToken, st.Nonce)
+	if err != nil {
+		server.HttpAbort(w, r, errors.ErrUnauthorized.SetInfo(err.Error()))
+		return
+	}
+
+	usr, err := Resolve(r.Context(), claims.Subject, &Profile{DisplayName: claims.Name, PictureURL: claims.Picture})
+	if err != nil {
+		server.HttpAbort(w, r, errors.Convert(err))
+		return
+	}
+	tok, err := auth.NewClaim(usr).Token()
+	if err != nil {
+		server.HttpAbort(w, r, errors.ErrInternal.SetInfo(err.Error()))
+		return
+	}
+	slog.Info("signed in", slog.String("mod", "account"), slog.String("act", "login"),
+		slog.String("usr", usr.Username), slog.Int("grp", int(usr.Group)))
+
+	if st.Redirect != "" {
+		http.Redirect(w, r, st.Redirect+"#"+url.Values{"token": {tok}}.Encode(), http.StatusFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"token": tok})
+}
+
+// exchange exchanges @code for the ID token at the token endpoint.
+func exchange(r *http.Request, code, verifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {login.RedirectURI},
+		"client_id":     {login.ChannelID},
+		"client_secret": {login.ChannelSecret},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodPost, login.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", errors.ErrInternal.SetInfo(err.Error())
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res, err := login.HTTPClient.Do(req)
+	if err != nil {
+		return "", errors.ErrServiceUnavailable.SetInfo(err.Error())
+	}
+	defer res.Body.Close()
+
+	body := &struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}{}
+	if err := json.NewDecoder(res.Body).Decode(body); err != nil {
+		return "", errors.ErrServiceUnavailable.SetInfo(err.Error())
+	}
+	if res.StatusCode != http.StatusOK || body.IDToken == "" {
+		return "", errors.ErrUnauthorized.SetInfo(body.Error + ": " + body.ErrorDescription)
+	}
+	return body.IDToken, nil
+}
+
+// idClaims are the claims of a LINE ID token.
+type idClaims struct {
+	Name    string `json:"name"`
+	Picture string `json:"picture"`
+	Nonce   string `json:"nonce"`
+	jwt.RegisteredClaims
+}
+
+// verifyIDToken verifies the signature, issuer, audience, expiry and nonce of the ID token,
+// which is signed by the channel secret with HS256.
+func verifyIDToken(tok, nonce string) (*idClaims, error) {
+	claims := &idClaims{}
+	_, err := jwt.ParseWithClaims(tok, claims, func(t *jwt.Token) (interface{}, error) {
+		return []byte(login.ChannelSecret), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(login.Issuer),
+		jwt.WithAudience(login.ChannelID),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if claims.ExpiresAt == nil {
+		return nil, jwt.ErrTokenRequiredClaimMissing
+	}
+	if claims.Subject == "" {
+		return nil, jwt.ErrTokenInvalidSubject
+	}
+	if !hmac.Equal([]byte(claims.Nonce), []byte(nonce)) {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+	return claims, nil
+}
+
+// sign encodes @st and signs it with [auth.Secret].
+func sign(st *loginState) string {
+	payload, _ := json.Marshal(st)
+	enc := base64.RawURLEncoding.EncodeToString(payload)
+	return enc + "." + mac(enc)
+}
+
+// verify decodes the signed value of [sign].
+func verify(value string) (*loginState, bool) {
+	enc, sig, ok := strings.Cut(value, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(mac(enc))) {
+		return nil, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(enc)
+	if err != nil {
+		return nil, false
+	}
+	st := &loginState{}
+	return st, json.Unmarshal(payload, st) == nil
+}
+
+func mac(s string) string {
+	h := hmac.New(sha256.New, auth.Secret)
+	h.Write([]byte(s))
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// localPath only allows paths of our own site to prevent open redirects.
+func localPath(p string) string {
+	if !strings.HasPrefix(p, "/") || strings.HasPrefix(p, "//") || strings.HasPrefix(p, "/\\") {
+		return ""
+	}
+	return p
+}
@@ -0,0 +1,160 @@
+package account
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"app/core/database"
+	_ "app/core/driver"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	channelID     = "1650000000"
+	channelSecret = "channel-secret"
+)
+
+// idp is a fake token endpoint of LINE Login, which checks the PKCE verifier against the challenge
+// and issues an ID token of the claims changed by @claims and signed by @secret.
+type idp struct {
+	challenge string
+	nonce     string
+	claims    func(c jwt.MapClaims)
+	secret    string
+}
+
+func (p *idp) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+	if r.Form.Get("code") != "code" || r.Form.Get("client_secret") != channelSecret ||
+		base64.RawURLEncoding.EncodeToString(sum[:]) != p.challenge {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant", "error_description": "invalid code or verifier"})
+		return
+	}
+	c := jwt.MapClaims{
+		"iss": DefaultIssuer, "sub": "U1", "aud": channelID, "name": "Amy", "nonce": p.nonce,
+		"iat": time.Now().Unix(), "exp": time.Now().Add(time.Hour).Unix(),
+	}
+	if p.claims != nil {
+		p.claims(c)
+	}
+	secret := channelSecret
+	if p.secret != "" {
+		secret = p.secret
+	}
+	tok, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString([]byte(secret))
+	json.NewEncoder(w).Encode(map[string]string{"id_token": tok})
+}
+
+func TestCallback(t *testing.T) {
+	database.Open(database.SQLITE, ":memory:")
+	if err := database.Migrate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	p := &idp{}
+	srv := httptest.NewServer(p)
+	defer srv.Close()
+	SetupLogin(&LoginOption{ChannelID: channelID, ChannelSecret: channelSecret, RedirectURI: "https://bot/callback", TokenURL: srv.URL})
+
+	tests := []struct {
+		name   string
+		state  func(st *loginState) // changes the state kept in the cookie
+		cookie func(v string) string
+		query  func(q url.Values)
+		claims func(c jwt.MapClaims)
+		secret string
+		status int
+	}{
+		{name: "signed in", status: http.StatusOK},
+		{name: "state mismatch", query: func(q url.Values) { q.Set("state", "forged") }, status: http.StatusUnauthorized},
+		{name: "no state", query: func(q url.Values) { q.Del("state") }, status: http.StatusUnauthorized},
+		{name: "no cookie", cookie: func(string) string { return "" }, status: http.StatusBadRequest},
+		{name: "cookie tampered", cookie: func(v string) string { return "x" + v }, status: http.StatusBadRequest},
+		{name: "cookie signed by another key", cookie: func(v string) string {
+			enc, _, _ := strings.Cut(v, ".")
+			return enc + "." + base64.RawURLEncoding.EncodeToString(make([]byte, 32))
+		}, status: http.StatusBadRequest},
+		{name: "login expired", state: func(st *loginState) { st.Expires = time.Now().Add(-time.Second).Unix() }, status: http.StatusBadRequest},
+		{name: "verifier of another login", state: func(st *loginState) { st.Verifier = "other" }, status: http.StatusUnauthorized},
+		{name: "denied at LINE", query: func(q url.Values) { q.Set("error", "access_denied") }, status: http.StatusUnauthorized},
+		{name: "wrong nonce", claims: func(c jwt.MapClaims) { c["nonce"] = "replayed" }, status: http.StatusUnauthorized},
+		{name: "no nonce", claims: func(c jwt.MapClaims) { delete(c, "nonce") }, status: http.StatusUnauthorized},
+		{name: "wrong aud", claims: func(c jwt.MapClaims) { c["aud"] = "1650000001" }, status: http.StatusUnauthorized},
+		{name: "wrong iss", claims: func(c jwt.MapClaims) { c["iss"] = "https://evil.example" }, status: http.StatusUnauthorized},
+		{name: "expired ID token", claims: func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() }, status: http.StatusUnauthorized},
+		{name: "no exp", claims: func(c jwt.MapClaims) { delete(c, "exp") }, status: http.StatusUnauthorized},
+		{name: "no sub", claims: func(c jwt.MapClaims) { delete(c, "sub") }, status: http.StatusUnauthorized},
+		{name: "ID token signed by another secret", secret: "other", status: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		// start a login as the browser would
+		rec := httptest.NewRecorder()
+		Login(rec, httptest.NewRequest(http.MethodGet, "/login", nil))
+		loc, err := url.Parse(rec.Header().Get("Location"))
+		if err != nil || rec.Code != http.StatusFound {
+			t.Fatalf("%s: login = %d %v", tt.name, rec.Code, err)
+		}
+		auth := loc.Query()
+		if auth.Get("code_challenge_method") != "S256" || auth.Get("client_id") != channelID {
+			t.Fatalf("%s: authorize url = %s", tt.name, loc)
+		}
+		cookie := rec.Result().Cookies()[0].Value
+		if tt.state != nil {
+			st, _ := verify(cookie)
+			tt.state(st)
+			cookie = sign(st)
+		}
+		if tt.cookie != nil {
+			cookie = tt.cookie(cookie)
+		}
+		p.challenge, p.nonce, p.claims, p.secret = auth.Get("code_challenge"), auth.Get("nonce"), tt.claims, tt.secret
+
+		q := url.Values{"code": {"code"}, "state": {auth.Get("state")}}
+		if tt.query != nil {
+			tt.query(q)
+		}
+		req := httptest.NewRequest(http.MethodGet, "/callback?"+q.Encode(), nil)
+		if cookie != "" {
+			req.AddCookie(&http.Cookie{Name: loginCookie, Value: cookie})
+		}
+		rec = httptest.NewRecorder()
+		Callback(rec, req)
+		if rec.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d: %s", tt.name, rec.Code, tt.status, rec.Body)
+			continue
+		}
+		if tt.status == http.StatusOK {
+			res := map[string]string{}
+			if json.Unmarshal(rec.Body.Bytes(), &res); res["token"] == "" {
+				t.Errorf("%s: no token in %s", tt.name, rec.Body)
+			}
+		}
+	}
+}
+
+func TestLocalPath(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"/todos", "/todos"},
+		{"/todos?x=1", "/todos?x=1"},
+		{"", ""},
+		{"https://evil.example", ""},
+		{"//evil.example", ""},
+		{"/\\evil.example", ""},
+		{"todos", ""},
+	}
+	for _, tt := range tests {
+		if got := localPath(tt.in); got != tt.want {
+			t.Errorf("localPath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
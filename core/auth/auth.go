@@ -118,6 +118,12 @@ func SetUser(ctx context.Context, tok string) context.Context {
 	}
 }
 
+// WithUser sets @usr to the context,
+// it is used when the user is not identified by a token, e.g. the sender of a chat message.
+func WithUser(ctx context.Context, usr *UserInfo) context.Context {
+	return context.WithValue(ctx, ctx_user_key, usr)
+}
+
 // GetUser gets the userinfo fron the context.
 func GetUser(ctx context.Context) (*UserInfo, bool) {
 	u, ok := ctx.Value(ctx_user_key).(*UserInfo)
@@ -8,11 +8,17 @@
 	   Date        Ver    Name     Description
 	---------- ------- ----------- -------------------------------------------
 	2023/03/02  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Load the secret from a file to keep it across restarts
 */
 
 package auth
 
 import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"app/core/util"
@@ -21,8 +27,46 @@ import (
 	"github.com/rs/xid"
 )
 
+// Secret signs the jwt tokens and the links made by other packages,
+// it is random per process unless set by config or loaded by [LoadSecret].
 var Secret = []byte(util.RandStr(32))
 
+// LoadSecret sets [Secret] to the key kept in the file @path, a random key is generated and written to it
+// if the file does not exist, so tokens and signed links stay valid after a restart.
+func LoadSecret(path string) (created bool, err error) {
+	b, err := os.ReadFile(path)
+	if err == nil {
+		if key := strings.TrimSpace(string(b)); key != "" {
+			Secret = []byte(key)
+			return false, nil
+		}
+		return false, errors.New("secret file is empty: " + path)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return false, err
+		}
+	}
+	key := util.RandStr(64)
+	// O_EXCL so a key written by another process at the same time is not replaced
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return false, err
+	}
+	if _, err := f.WriteString(key + "\n"); err != nil {
+		f.Close()
+		return false, err
+	}
+	if err := f.Close(); err != nil {
+		return false, err
+	}
+	Secret = []byte(key)
+	return true, nil
+}
+
 /*
 
 	type RegisteredClaims struct {
@@ -0,0 +1,308 @@
+/*
+	command.go
+	Purpose: A registry of chat commands that skills could plug into.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Add skills and the chat of groups and rooms
+	2026/10/17  v1.0.2 Evan Chen   Quote only by " and keep the original text of rest arguments
+*/
+
+// Package command dispatches LINE text messages like "/todo add milk" to registered handlers.
+package command
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	"app/core/auth"
+	"app/core/errors"
+	"app/core/line"
+	"app/core/msg"
+)
+
+// Prefix is what a text message should start with to be treated as a command.
+const Prefix = "/"
+
+// Arg describes an argument of a command.
+type Arg struct {
+	// Name is the key to get the argument from [Args].
+	Name string
+	// Required arguments must be given, or the usage of the command will be replied.
+	Required bool
+	// Rest makes the argument capture the remaining text, it should be the last argument.
+	Rest bool
+}
+
+// Command is a chat command.
+type Command struct {
+	// Name is the command without prefix, it could contain spaces for sub commands, e.g. "todo add".
+	Name string
+	// Aliases are alternative names of the command.
+	Aliases []string
+	// Args are positional arguments of the command.
+	Args []Arg
+	// Desc is the message key of the description shown in help.
+	Desc string
+	// Hidden commands are not listed in help.
+	Hidden bool
+	// Skill is the skill the command belongs to, e.g. "reminder", which could be disabled per group.
+	// Commands without a skill are always enabled.
+	Skill string
+	// Handler handles the command.
+	Handler Handler
+}
+
+// Usage returns how the command should be used, e.g. "/todo add <title...>".
+func (cmd *Command) Usage() string {
+	b := &strings.Builder{}
+	b.WriteString(Prefix + cmd.Name)
+	for _, arg := range cmd.Args {
+		name := arg.Name
+		if arg.Rest {
+			name += "..."
+		}
+		if arg.Required {
+			b.WriteString(" <" + name + ">")
+		} else {
+			b.WriteString(" [" + name + "]")
+		}
+	}
+	return b.String()
+}
+
+// Handler handles a command, a returned error will be replied to the sender.
+type Handler func(c *Context) error
+
+// Args are the parsed arguments of a command.
+type Args map[string]string
+
+// Get gets the argument by @name, empty string is returned if not given.
+func (a Args) Get(name string) string {
+	return a[name]
+}
+
+// Context is passed to the [Handler], it carries the event and the parsed command.
+type Context struct {
+	context.Context
+	// Event is the text message event which triggered the command.
+	Event *line.Event
+	// Command is the matched command, it is nil when the message is not a command.
+	Command *Command
+	// Args are the parsed arguments of the command.
+	Args Args
+	// Text is the text of the message.
+	Text string
+	// User is the sender of the message.
+	User *auth.UserInfo
+	// Chat is the group or room the message is sent in, it is nil in one-on-one chats.
+	Chat *Chat
+	// Locale is the locale to reply in.
+	Locale string
+}
+
+// Chat is a group or room chat with its settings.
+type Chat struct {
+	// ID is the group or room ID.
+	ID string
+	// Type is either [line.SourceGroup] or [line.SourceRoom].
+	Type string
+	// Locale is the locale of the chat, the default locale is used if empty.
+	Locale string
+	// Admin tells if the sender could change the settings of the chat.
+	Admin bool
+	// Disabled are the skills disabled in the chat.
+	Disabled []string
+}
+
+// Enabled checks if @skill is enabled in the chat.
+func (c *Chat) Enabled(skill string) bool {
+	for _, s := range c.Disabled {
+		if s == skill {
+			return false
+		}
+	}
+	return true
+}
+
+// InGroup checks if the message is sent in a group or room, so handlers could tell "our" data from "my" data.
+func (c *Context) InGroup() bool {
+	return c.Chat != nil
+}
+
+// ChatID returns the ID of the chat the message is sent in, which is the user ID in one-on-one chats.
+func (c *Context) ChatID() string {
+	if c.Event == nil || c.Event.Source == nil {
+		return ""
+	}
+	return c.Event.Source.ID()
+}
+
+// T translates the message @key with the locale of the context.
+func (c *Context) T(key string, data any) string {
+	return msg.T(key, c.Locale, data)
+}
+
+// Reply replies @msgs to the event.
+func (c *Context) Reply(msgs ...line.Message) error {
+	return line.Reply(c, c.Event.ReplyToken, msgs...)
+}
+
+// ReplyText replies the translated message @key.
+func (c *Context) ReplyText(key string, data any) error {
+	return c.Reply(line.NewText(c.T(key, data)))
+}
+
+// ReplyFlex replies the flex message rendered from the template @key.
+func (c *Context) ReplyFlex(key string, data any) error {
+	m, err := msg.Flex(key, c.Locale, data)
+	if err != nil {
+		return errors.ErrInternal.SetInfo(err.Error())
+	}
+	return c.Reply(m)
+}
+
+var commands = map[string]*Command{}
+
+// Register registers commands that would be dispatched by the router,
+// commands with the same name or alias will be overwritten.
+//
+// It is usually called in the init function of each feature package.
+func Register(cmds ...*Command) {
+	for _, cmd := range cmds {
+		commands[strings.ToLower(cmd.Name)] = cmd
+		for _, alias := range cmd.Aliases {
+			commands[strings.ToLower(alias)] = cmd
+		}
+	}
+}
+
+// Skills returns the skills of the registered commands sorted by name.
+func Skills() []string {
+	seen := map[string]bool{}
+	list := []string{}
+	for _, cmd := range commands {
+		if cmd.Skill != "" && !seen[cmd.Skill] {
+			seen[cmd.Skill] = true
+			list = append(list, cmd.Skill)
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+// List returns the registered commands sorted by name.
+func List() []*Command {
+	seen := map[*Command]bool{}
+	list := []*Command{}
+	for _, cmd := range commands {
+		if !seen[cmd] {
+			seen[cmd] = true
+			list = append(list, cmd)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// IsCommand checks if @text should be treated as a command.
+func IsCommand(text string) bool {
+	return strings.HasPrefix(strings.TrimSpace(text), Prefix)
+}
+
+// Match finds the command of @text, which is the longest registered name matching the leading words.
+// The remaining words are returned as args.
+func Match(text string) (cmd *Command, args []string, ok bool) {
+	cmd, _, words, ok := match(text)
+	args = make([]string, len(words))
+	for i, w := range words {
+		args[i] = w.text
+	}
+	return cmd, args, ok
+}
+
+// match is [Match] which also returns the text without the prefix, where the words are split from.
+func match(text string) (cmd *Command, trimmed string, args []word, ok bool) {
+	text = strings.TrimPrefix(strings.TrimSpace(text), Prefix)
+	words := split(text)
+	for i := len(words); i > 0; i-- {
+		names := make([]string, i)
+		for j, w := range words[:i] {
+			names[j] = w.text
+		}
+		if cmd, ok := commands[strings.ToLower(strings.Join(names, " "))]; ok {
+			return cmd, text, words[i:], true
+		}
+	}
+	return nil, text, words, false
+}
+
+// bind binds @words split from @text to the arguments of @cmd, ok is false if a required argument is missing.
+// A rest argument takes the text from its first word as is, so apostrophes, quotes and line breaks are kept.
+func bind(cmd *Command, text string, words []word) (Args, bool) {
+	args := Args{}
+	for i, arg := range cmd.Args {
+		if i >= len(words) {
+			if arg.Required {
+				return args, false
+			}
+			continue
+		}
+		if arg.Rest {
+			if i == len(words)-1 {
+				// a single word, which could be quoted
+				args[arg.Name] = words[i].text
+			} else {
+				args[arg.Name] = strings.TrimSpace(text[words[i].start:])
+			}
+			break
+		}
+		args[arg.Name] = words[i].text
+	}
+	return args, true
+}
+
+// word is a word of a command and where it starts in the text.
+type word struct {
+	text  string
+	start int
+}
+
+// split splits @text by white spaces, including the full-width one.
+// A word starting with " is kept as one until the next ", a quote without its closing one is a letter.
+func split(text string) []word {
+	words := []word{}
+	b := &strings.Builder{}
+	start, skip := -1, 0
+	for i, r := range text {
+		switch {
+		case i < skip:
+			// quoted
+		case unicode.IsSpace(r):
+			if start >= 0 {
+				words = append(words, word{b.String(), start})
+				b.Reset()
+				start = -1
+			}
+		case r == '"' && start < 0 && strings.IndexByte(text[i+1:], '"') >= 0:
+			end := i + 1 + strings.IndexByte(text[i+1:], '"')
+			b.WriteString(text[i+1 : end])
+			start, skip = i, end+1
+		default:
+			if start < 0 {
+				start = i
+			}
+			b.WriteRune(r)
+		}
+	}
+	if start >= 0 {
+		words = append(words, word{b.String(), start})
+	}
+	return words
+}
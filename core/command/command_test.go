@@ -0,0 +1,83 @@
+package command
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"todo add milk", []string{"todo", "add", "milk"}},
+		{"  todo\tadd  milk ", []string{"todo", "add", "milk"}},
+		{"todo　add　牛奶", []string{"todo", "add", "牛奶"}},
+		{"note a\nb\r\nc", []string{"note", "a", "b", "c"}},
+		{`todo add "buy milk" now`, []string{"todo", "add", "buy milk", "now"}},
+		{`todo add ""`, []string{"todo", "add", ""}},
+		{`todo "add"x`, []string{"todo", "addx"}},
+		// only " at the start of a word quotes
+		{"remind call mom's dentist", []string{"remind", "call", "mom's", "dentist"}},
+		{"remind it's 'fine' now", []string{"remind", "it's", "'fine'", "now"}},
+		{`remind 5" screen`, []string{"remind", `5"`, "screen"}},
+		// a quote without its closing one is a letter
+		{`remind "call mom`, []string{"remind", `"call`, "mom"}},
+		{"", []string{}},
+	}
+	for _, tt := range tests {
+		words := split(tt.in)
+		got := make([]string, len(words))
+		for i, w := range words {
+			got[i] = w.text
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("split(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestBind(t *testing.T) {
+	defer func(old map[string]*Command) { commands = old }(commands)
+	commands = map[string]*Command{}
+	Register(
+		&Command{Name: "remind", Args: []Arg{{Name: "text", Required: true, Rest: true}}},
+		&Command{Name: "note add", Args: []Arg{{Name: "text", Rest: true}}},
+		&Command{Name: "group member", Args: []Arg{{Name: "op", Required: true}, {Name: "members", Required: true, Rest: true}}},
+	)
+
+	tests := []struct {
+		in   string
+		name string
+		want Args
+		ok   bool
+	}{
+		{"/remind me at 9am to call mom's dentist", "remind", Args{"text": "me at 9am to call mom's dentist"}, true},
+		{"/remind me at 9am to call mom's dentist's office", "remind", Args{"text": "me at 9am to call mom's dentist's office"}, true},
+		{"/remind me   tomorrow  to  stretch ", "remind", Args{"text": "me   tomorrow  to  stretch"}, true},
+		{"/remind", "remind", Args{}, false},
+		{"/note add line one\nline two\n\n  indented", "note add", Args{"text": "line one\nline two\n\n  indented"}, true},
+		{"/note add\nfirst\nsecond", "note add", Args{"text": "first\nsecond"}, true},
+		{"/note　add　全形　空白", "note add", Args{"text": "全形　空白"}, true},
+		{`/note add "quoted"`, "note add", Args{"text": "quoted"}, true},
+		{`/note add "a b" c`, "note add", Args{"text": `"a b" c`}, true},
+		{"/note add", "note add", Args{}, true},
+		{"/group member add @Amy @Bob", "group member", Args{"op": "add", "members": "@Amy @Bob"}, true},
+		{"/GROUP MEMBER del @Amy", "group member", Args{"op": "del", "members": "@Amy"}, true},
+	}
+	for _, tt := range tests {
+		cmd, text, words, ok := match(tt.in)
+		if !ok || cmd.Name != tt.name {
+			t.Errorf("match(%q) = %v, %v, want %s", tt.in, cmd, ok, tt.name)
+			continue
+		}
+		args, ok := bind(cmd, text, words)
+		if ok != tt.ok || (ok && !reflect.DeepEqual(args, tt.want)) {
+			t.Errorf("bind(%q) = %q, %v, want %q, %v", tt.in, args, ok, tt.want, tt.ok)
+		}
+	}
+
+	if _, _, ok := Match("/unknown thing"); ok {
+		t.Error(`Match("/unknown thing") found a command`)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+	help.go
+	Purpose: The /help command generated from the registry.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Hide skills disabled in the chat
+*/
+
+package command
+
+import (
+	"strings"
+)
+
+func init() {
+	Register(&Command{
+		Name:    "help",
+		Aliases: []string{"h", "?"},
+		Args:    []Arg{{Name: "command", Rest: true}},
+		Desc:    "command.help.desc",
+		Handler: help,
+	})
+}
+
+type helpItem struct {
+	Usage   string
+	Aliases string
+	Desc    string
+}
+
+// help lists the registered commands except the ones disabled in the chat,
+// the list could be filtered by the leading words of a command.
+func help(c *Context) error {
+	filter := strings.ToLower(strings.TrimPrefix(c.Args.Get("command"), Prefix))
+	items := []helpItem{}
+	for _, cmd := range List() {
+		if cmd.Hidden || !strings.HasPrefix(cmd.Name, filter) {
+			continue
+		}
+		if cmd.Skill != "" && c.Chat != nil && !c.Chat.Enabled(cmd.Skill) {
+			continue
+		}
+		item := helpItem{Usage: cmd.Usage()}
+		if cmd.Desc != "" {
+			item.Desc = c.T(cmd.Desc, nil)
+		}
+		if len(cmd.Aliases) > 0 {
+			item.Aliases = Prefix + strings.Join(cmd.Aliases, ", "+Prefix)
+		}
+		items = append(items, item)
+	}
+	return c.ReplyText("command.help", map[string]any{"Commands": items})
+}
@@ -0,0 +1,214 @@
+/*
+	router.go
+	Purpose: Dispatch text message events to the registered commands.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Skip redelivered messages
+	2026/10/17  v1.0.2 Evan Chen   Resolve the chat of groups and rooms
+	2026/10/17  v1.0.3 Evan Chen   Remove interceptors with the returned closer
+	2026/10/17  v1.0.4 Evan Chen   Bind the rest arguments from the original text
+*/
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"app/core/auth"
+	"app/core/dedup"
+	"app/core/errors"
+	"app/core/line"
+	"app/core/msg"
+	"app/core/property"
+	"app/core/util"
+
+	"golang.org/x/exp/slog"
+)
+
+// Router is the [service.Service] which listens to text messages and dispatches them.
+var Router = &router{}
+
+type router struct {
+	close func()
+}
+
+func (r *router) Init() error {
+	r.close = dedup.Handle("command", line.TopicText, dedup.ExactlyOnce, func(ctx context.Context, evt *line.Event) error {
+		Dispatch(ctx, evt)
+		return nil
+	})
+	return nil
+}
+
+func (r *router) Load() {}
+
+func (r *router) Del() {
+	if r.close != nil {
+		r.close()
+	}
+}
+
+// UserResolver resolves the sender of an event to a user.
+type UserResolver func(ctx context.Context, src *line.Source) *auth.UserInfo
+
+var resolveUser UserResolver = func(ctx context.Context, src *line.Source) *auth.UserInfo {
+	return &auth.UserInfo{Username: src.UserID, Group: auth.USER}
+}
+
+// SetUserResolver sets how the sender of an event is resolved to [auth.UserInfo].
+func SetUserResolver(f UserResolver) {
+	resolveUser = f
+}
+
+// ChatResolver resolves the group or room an event is sent in, nil should be returned for one-on-one chats.
+type ChatResolver func(ctx context.Context, src *line.Source) *Chat
+
+var resolveChat ChatResolver
+
+// SetChatResolver sets how the group or room of an event is resolved to [Chat].
+// Without it, [Context.Chat] is always nil.
+func SetChatResolver(f ChatResolver) {
+	resolveChat = f
+}
+
+// NewContext builds the [Context] of a text message event.
+func NewContext(ctx context.Context, evt *line.Event) *Context {
+	c := &Context{
+		Event:  evt,
+		Args:   Args{},
+		Locale: property.DefaultLocale,
+	}
+	if evt.Message != nil {
+		c.Text = evt.Message.Text
+	}
+	if evt.Source != nil {
+		c.User = resolveUser(ctx, evt.Source)
+		if resolveChat != nil && evt.Source.Type != line.SourceUser {
+			c.Chat = resolveChat(ctx, evt.Source)
+		}
+	}
+	if c.Chat != nil && c.Chat.Locale != "" {
+		c.Locale = c.Chat.Locale
+	}
+	if c.User != nil {
+		ctx = auth.WithUser(ctx, c.User)
+	}
+	c.Context = ctx
+	return c
+}
+
+// Interceptor is called before a text message is dispatched to commands,
+// it returns true if the message has been handled and should not be dispatched further.
+type Interceptor func(c *Context) (handled bool, err error)
+
+var interceptors struct {
+	sync.RWMutex
+	list []*Interceptor
+}
+
+// Intercept adds an [Interceptor], which is called in the order added.
+// It is usually called in the Init of a service, and the returned close removes it in the Del.
+func Intercept(f Interceptor) (close func()) {
+	p := &f
+	interceptors.Lock()
+	interceptors.list = append(interceptors.list, p)
+	interceptors.Unlock()
+	return func() {
+		interceptors.Lock()
+		defer interceptors.Unlock()
+		for i, q := range interceptors.list {
+			if q == p {
+				interceptors.list = append(interceptors.list[:i:i], interceptors.list[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Dispatch dispatches a text message event to the interceptors and then its command.
+//
+// Texts that are not commands are ignored,
+// while unknown commands and missing arguments are replied with localized messages.
+func Dispatch(ctx context.Context, evt *line.Event) {
+	c := NewContext(ctx, evt)
+
+	start := time.Now()
+	var err error
+	handled := false
+	defer func() {
+		pan := recover()
+		if !handled && err == nil && pan == nil {
+			return
+		}
+		args := []any{
+			slog.String("mod", "command"), slog.String("act", "dispatch"),
+			slog.Duration("duration", time.Since(start)),
+		}
+		if c.Command != nil {
+			args = append(args, slog.String("cmd", c.Command.Name))
+		}
+		if c.User != nil {
+			args = append(args, slog.String("usr", c.User.Username))
+		}
+		if pan != nil {
+			err = errors.ErrInternal.SetInfo(fmt.Sprint(pan))
+			if property.IsDebug() {
+				args = append(args, slog.String("stack", util.Stack()))
+			}
+		}
+		if err != nil {
+			ce := errors.Convert(err).Exec(c.Locale, nil)
+			slog.Error(ce.Error(), append(args, ce.Attr())...)
+			if rerr := c.Reply(line.NewText(ce.Error())); rerr != nil {
+				slog.Error("reply failed", util.ErrAtrr(rerr), slog.String("mod", "command"))
+			}
+			return
+		}
+		slog.Info("ok", args...)
+	}()
+
+	interceptors.RLock()
+	list := interceptors.list
+	interceptors.RUnlock()
+	for _, intercept := range list {
+		if handled, err = (*intercept)(c); handled || err != nil {
+			return
+		}
+	}
+
+	if !IsCommand(c.Text) {
+		return
+	}
+	handled = true
+
+	cmd, text, words, ok := match(c.Text)
+	if !ok {
+		name := ""
+		if len(words) > 0 {
+			name = Prefix + words[0].text
+		}
+		err = c.ReplyText("command.unknown", msg.Plain(name))
+		return
+	}
+	c.Command = cmd
+
+	if cmd.Skill != "" && c.Chat != nil && !c.Chat.Enabled(cmd.Skill) {
+		err = c.ReplyText("command.disabled", msg.Plain(cmd.Skill))
+		return
+	}
+
+	if c.Args, ok = bind(cmd, text, words); !ok {
+		err = c.ReplyText("command.usage", msg.Plain(cmd.Usage()))
+		return
+	}
+
+	err = cmd.Handler(c)
+}
@@ -0,0 +1,221 @@
+/*
+	conversation.go
+	Purpose: Capture replies of active sessions in front of the command router.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Purge expired sessions hourly with cron
+	2026/10/17  v1.0.2 Evan Chen   Remove the interceptor on Del
+*/
+
+// Package conversation runs multi-turn dialogs whose sessions are persisted in the database.
+//
+// A dialog is started by a command handler with [Start],
+// afterwards free-text replies of the user are captured until the dialog ends,
+// expires or is cancelled with a cancel keyword. Commands are still dispatched during a dialog.
+package conversation
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"app/core/command"
+	"app/core/cron"
+	"app/core/errors"
+
+	"golang.org/x/exp/slog"
+)
+
+// Option configures the conversation layer.
+type Option struct {
+	// CancelWords are the keywords to cancel the active dialog, compared case-insensitively.
+	CancelWords []string
+	// TTL is how long a session could wait for a reply.
+	TTL time.Duration
+}
+
+var opt = Option{
+	CancelWords: []string{"取消", "cancel"},
+	TTL:         10 * time.Minute,
+}
+
+// Setup sets the options, empty fields are left unchanged.
+func Setup(o *Option) {
+	if len(o.CancelWords) > 0 {
+		opt.CancelWords = o.CancelWords
+	}
+	if o.TTL > 0 {
+		opt.TTL = o.TTL
+	}
+}
+
+func init() {
+	cron.Register(&cron.Job{
+		Name: "conversation.purge",
+		Spec: "@hourly",
+		Run: func(ctx context.Context) error {
+			n, err := purge(ctx)
+			if n > 0 {
+				slog.Info("sessions purged", slog.Int64("count", n),
+					slog.String("mod", "conversation"), slog.String("act", "purge"))
+			}
+			return err
+		},
+	})
+}
+
+// Service is the [service.Service] which puts the conversation layer in front of the command router.
+var Service = &conversation{}
+
+type conversation struct {
+	close func()
+}
+
+func (s *conversation) Init() error {
+	s.close = command.Intercept(intercept)
+	return nil
+}
+
+// Load purges expired sessions.
+func (*conversation) Load() {
+	n, err := purge(context.Background())
+	if err != nil {
+		slog.Error("purge sessions failed", slog.String("err", err.Error()),
+			slog.String("mod", "conversation"), slog.String("act", "purge"))
+		return
+	}
+	if n > 0 {
+		slog.Info("sessions purged", slog.Int64("count", n),
+			slog.String("mod", "conversation"), slog.String("act", "purge"))
+	}
+}
+
+func (s *conversation) Del() {
+	if s.close != nil {
+		s.close()
+	}
+}
+
+// Start starts dialog @name for the sender of @c, replacing any active session.
+// @slots are the pre-filled values, the steps of which are skipped.
+func Start(c *command.Context, name string, slots map[string]string) error {
+	d, ok := dialogs[name]
+	if !ok || len(d.Steps) == 0 {
+		return errors.ErrNotFound.SetInfo("dialog " + name)
+	}
+	chatID, userID := ids(c)
+	s := &Session{ChatID: chatID, UserID: userID, Dialog: name, Slots: map[string]string{}}
+	for k, v := range slots {
+		if v != "" {
+			s.Slots[k] = v
+		}
+	}
+	return d.enter(&Context{Context: c, Session: s}, d.Steps[0].Name)
+}
+
+// Active returns the active session of the sender of @c, nil is returned if there is none.
+func Active(c *command.Context) (*Session, error) {
+	chatID, userID := ids(c)
+	s, err := load(c, chatID, userID)
+	if err != nil || s == nil || s.Expired() {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Cancel discards the active session of the sender of @c.
+func Cancel(c *command.Context) error {
+	chatID, userID := ids(c)
+	return remove(c, chatID, userID)
+}
+
+// ids returns the chat and user of the event.
+func ids(c *command.Context) (chatID, userID string) {
+	src := c.Event.Source
+	if src == nil {
+		return "", ""
+	}
+	chatID, userID = src.ID(), src.UserID
+	if userID == "" {
+		userID = chatID
+	}
+	return
+}
+
+// intercept captures the replies of an active session.
+func intercept(c *command.Context) (bool, error) {
+	if c.Event.Source == nil {
+		return false, nil
+	}
+	s, err := Active(c)
+	if err != nil || s == nil {
+		return false, err
+	}
+	d, ok := dialogs[s.Dialog]
+	if !ok {
+		// the dialog has been removed since the session was saved
+		return false, Cancel(c)
+	}
+
+	text := strings.TrimSpace(c.Text)
+	for _, w := range opt.CancelWords {
+		if strings.EqualFold(text, w) {
+			if err := Cancel(c); err != nil {
+				return true, err
+			}
+			return true, c.ReplyText("conversation.cancelled", nil)
+		}
+	}
+	if command.IsCommand(text) {
+		return false, nil
+	}
+
+	cc := &Context{Context: c, Session: s}
+	i, step := d.step(s.Step)
+	if step == nil {
+		return true, d.enter(cc, d.Steps[0].Name)
+	}
+	if step.Parse != nil {
+		if text, err = step.Parse(cc, text); err != nil {
+			return true, err
+		}
+	}
+	cc.SetSlot(step.slot(), text)
+	return true, d.enter(cc, d.next(cc, i))
+}
+
+// enter moves the session to step @name, skipping steps with filled slots.
+// The prompt of the step is replied, or [Dialog.Done] is called if the dialog has ended.
+func (d *Dialog) enter(c *Context, name string) error {
+	for name != End {
+		i, step := d.step(name)
+		if step == nil {
+			return errors.ErrInternal.SetInfo("dialog " + d.Name + " has no step " + name)
+		}
+		if c.Slot(step.slot()) == "" {
+			c.Session.Step = step.Name
+			ttl := d.TTL
+			if ttl <= 0 {
+				ttl = opt.TTL
+			}
+			c.Session.ExpiresAt = time.Now().Add(ttl)
+			if err := save(c, c.Session); err != nil {
+				return err
+			}
+			return c.ReplyText(step.Prompt, c.Session.Slots)
+		}
+		name = d.next(c, i)
+	}
+
+	if d.Done != nil {
+		if err := d.Done(c); err != nil {
+			return err
+		}
+	}
+	return remove(c, c.Session.ChatID, c.Session.UserID)
+}
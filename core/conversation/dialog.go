@@ -0,0 +1,108 @@
+/*
+	dialog.go
+	Purpose: Dialogs declared as state machines of steps.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package conversation
+
+import (
+	"time"
+
+	"app/core/command"
+)
+
+// End is the step name to finish a dialog, it could be returned by [Step.Next].
+const End = "$end"
+
+// Dialog is a multi-turn conversation declared as a state machine,
+// e.g. "add expense" → "amount?" → "category?".
+type Dialog struct {
+	// Name identifies the dialog, it is persisted in sessions so it should not be changed once released.
+	Name string
+	// Steps are the states of the dialog, the dialog starts at the first step.
+	Steps []*Step
+	// TTL is how long a session could wait for a reply, defaults to the TTL of [Option].
+	TTL time.Duration
+	// Done is called with the collected slots when the dialog reaches [End].
+	// The session is kept if an error is returned, so the user could reply the last step again.
+	Done func(c *Context) error
+}
+
+// Step is a state of a [Dialog] which prompts the user and collects the reply into a slot.
+type Step struct {
+	// Name identifies the step within the dialog.
+	Name string
+	// Prompt is the message key replied when the step is entered, the slots are passed as data.
+	Prompt string
+	// Slot is the slot to store the reply, defaults to Name.
+	// The step is skipped if the slot has already been filled, e.g. by the arguments of a command.
+	Slot string
+	// Parse validates and normalizes the reply,
+	// the returned error is replied and the step stays the same.
+	Parse func(c *Context, text string) (string, error)
+	// Next returns the name of the next step, defaults to the following step or [End] for the last one.
+	Next func(c *Context) string
+}
+
+func (s *Step) slot() string {
+	if s.Slot != "" {
+		return s.Slot
+	}
+	return s.Name
+}
+
+// Context is passed to the functions of a [Dialog].
+type Context struct {
+	*command.Context
+	// Session is the session of the dialog.
+	Session *Session
+}
+
+// Slot gets the value of slot @name, empty string is returned if not filled.
+func (c *Context) Slot(name string) string {
+	return c.Session.Slots[name]
+}
+
+// SetSlot sets the value of slot @name.
+func (c *Context) SetSlot(name, value string) {
+	c.Session.Slots[name] = value
+}
+
+// step finds the step @name and its index.
+func (d *Dialog) step(name string) (int, *Step) {
+	for i, s := range d.Steps {
+		if s.Name == name {
+			return i, s
+		}
+	}
+	return -1, nil
+}
+
+// next returns the name of the step after @i.
+func (d *Dialog) next(c *Context, i int) string {
+	if next := d.Steps[i].Next; next != nil {
+		return next(c)
+	}
+	if i+1 < len(d.Steps) {
+		return d.Steps[i+1].Name
+	}
+	return End
+}
+
+var dialogs = map[string]*Dialog{}
+
+// Register registers dialogs so they could be started and resumed after restarts.
+//
+// It is usually called in the init function of each feature package.
+func Register(d ...*Dialog) {
+	for _, dialog := range d {
+		dialogs[dialog.Name] = dialog
+	}
+}
@@ -0,0 +1,112 @@
+/*
+	session.go
+	Purpose: Persist conversation sessions so they survive restarts.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package conversation
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"app/core/database"
+)
+
+func init() {
+	database.Register(&database.Migration{
+		ID: "20261017_conversation",
+		Stmts: []string{`CREATE TABLE conversation_sessions (
+			chat_id TEXT NOT NULL,
+			user_id TEXT NOT NULL,
+			dialog TEXT NOT NULL,
+			step TEXT NOT NULL,
+			slots TEXT NOT NULL,
+			expires_at BIGINT NOT NULL,
+			updated_at BIGINT NOT NULL,
+			PRIMARY KEY (chat_id, user_id)
+		)`},
+	})
+}
+
+// Session is the state of a dialog between the bot and a user.
+type Session struct {
+	// ChatID is the user, group or room where the dialog happens.
+	ChatID string
+	// UserID is the user who is talking, it equals ChatID in one-on-one chats.
+	UserID string
+	// Dialog is the name of the running dialog.
+	Dialog string
+	// Step is the name of the current step.
+	Step string
+	// Slots are the values collected so far.
+	Slots map[string]string
+	// ExpiresAt is when the session will be discarded if no reply is received.
+	ExpiresAt time.Time
+}
+
+// Expired checks if the session has expired.
+func (s *Session) Expired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
+
+// load loads the session of @userID in @chatID, nil is returned if there is none.
+func load(ctx context.Context, chatID, userID string) (*Session, error) {
+	s := &Session{ChatID: chatID, UserID: userID}
+	var slots string
+	var expires int64
+	err := database.DB().QueryRowContext(ctx,
+		"SELECT dialog, step, slots, expires_at FROM conversation_sessions WHERE chat_id = $1 AND user_id = $2",
+		chatID, userID,
+	).Scan(&s.Dialog, &s.Step, &slots, &expires)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, database.Err(err)
+	}
+	s.ExpiresAt = time.Unix(expires, 0)
+	if err := json.Unmarshal([]byte(slots), &s.Slots); err != nil || s.Slots == nil {
+		s.Slots = map[string]string{}
+	}
+	return s, nil
+}
+
+// save upserts the session.
+func save(ctx context.Context, s *Session) error {
+	slots, err := json.Marshal(s.Slots)
+	if err != nil {
+		return database.Err(err)
+	}
+	_, err = database.DB().ExecContext(ctx, `INSERT INTO conversation_sessions
+		(chat_id, user_id, dialog, step, slots, expires_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (chat_id, user_id) DO UPDATE SET
+		dialog = excluded.dialog, step = excluded.step, slots = excluded.slots,
+		expires_at = excluded.expires_at, updated_at = excluded.updated_at`,
+		s.ChatID, s.UserID, s.Dialog, s.Step, string(slots), s.ExpiresAt.Unix(), time.Now().Unix())
+	return database.Err(err)
+}
+
+// remove deletes the session of @userID in @chatID.
+func remove(ctx context.Context, chatID, userID string) error {
+	_, err := database.DB().ExecContext(ctx,
+		"DELETE FROM conversation_sessions WHERE chat_id = $1 AND user_id = $2", chatID, userID)
+	return database.Err(err)
+}
+
+// purge deletes expired sessions.
+func purge(ctx context.Context) (int64, error) {
+	res, err := database.DB().ExecContext(ctx,
+		"DELETE FROM conversation_sessions WHERE expires_at < $1", time.Now().Unix())
+	if err != nil {
+		return 0, database.Err(err)
+	}
+	return res.RowsAffected()
+}
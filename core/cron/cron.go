@@ -0,0 +1,254 @@
+/*
+	cron.go
+	Purpose: Run registered jobs on schedules.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Log jobs done at debug level
+*/
+
+// Package cron runs named jobs on cron expressions or intervals.
+//
+// Modules register jobs in their init functions, and the schedule of a job could be
+// overridden by the config CRON_<NAME>, e.g. CRON_REMINDER_PURGE="0 3 * * *" for job "reminder.purge",
+// or "off" to disable it. Schedules are re-read from the config whenever the services reload.
+package cron
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"app/core/config"
+	"app/core/property"
+	"app/core/util"
+
+	"golang.org/x/exp/slog"
+)
+
+// Off disables a job when set as its schedule.
+const Off = "off"
+
+// Job is a task to be run on a schedule.
+type Job struct {
+	// Name identifies the job, e.g. "reminder.purge".
+	Name string
+	// Spec is the default schedule, see [Parse] for the formats.
+	Spec string
+	// Location is the time zone of the schedule, defaults to the local time zone.
+	Location *time.Location
+	// Jitter delays every run by a random duration up to it, to spread jobs on the same schedule.
+	Jitter time.Duration
+	// Overlap allows a run to start while the previous one is still running,
+	// by default such run is skipped.
+	Overlap bool
+	// Run runs the job, the context is cancelled when the application shuts down.
+	Run func(ctx context.Context) error
+}
+
+// Key returns the config key to override the schedule of job @name.
+func Key(name string) config.Key {
+	return config.Key(string(property.CRON) + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_", " ", "_").Replace(name)))
+}
+
+// Option configures the scheduler.
+type Option struct {
+	// NoCron disables the scheduler, no jobs will be run.
+	NoCron bool
+}
+
+var opt Option
+
+// Setup sets the options.
+func Setup(o *Option) { opt = *o }
+
+type entry struct {
+	*Job
+	lock    sync.Mutex
+	running bool
+}
+
+var (
+	jobs     = map[string]*entry{}
+	jobsLock sync.RWMutex
+)
+
+// Register registers jobs to be scheduled, jobs with the same name will be overwritten.
+//
+// It is usually called in the init function of each feature package,
+// jobs registered after the scheduler started are scheduled on the next reload.
+func Register(js ...*Job) {
+	jobsLock.Lock()
+	defer jobsLock.Unlock()
+	for _, j := range js {
+		jobs[j.Name] = &entry{Job: j}
+	}
+}
+
+// Service is the [service.Service] which runs the registered jobs.
+var Service = &scheduler{}
+
+type scheduler struct {
+	lock   sync.Mutex
+	ctx    context.Context
+	cancel func()
+	stop   chan struct{}
+	loops  sync.WaitGroup
+	runs   sync.WaitGroup
+}
+
+// Init starts the scheduler unless disabled by [Option.NoCron].
+func (s *scheduler) Init() error {
+	if opt.NoCron {
+		slog.Info("cron disabled", slog.String("mod", "cron"), slog.String("act", "init"))
+		return nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.start()
+	return nil
+}
+
+// Load reschedules the jobs with the schedules from the config.
+func (s *scheduler) Load() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.stop == nil {
+		return
+	}
+	s.halt()
+	s.start()
+}
+
+// Del stops scheduling and waits for the running jobs, which are notified by their context.
+func (s *scheduler) Del() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.stop == nil {
+		return
+	}
+	s.halt()
+	s.stop = nil
+	s.cancel()
+	s.runs.Wait()
+}
+
+// start starts a loop for every job, it should be called with the lock held.
+func (s *scheduler) start() {
+	s.stop = make(chan struct{})
+	jobsLock.RLock()
+	defer jobsLock.RUnlock()
+
+	names := make([]string, 0, len(jobs))
+	for name := range jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		e := jobs[name]
+		spec := e.Spec
+		if v := config.GetString(Key(name)); v != "" {
+			spec = v
+		}
+		if strings.EqualFold(strings.TrimSpace(spec), Off) || spec == "" {
+			slog.Info("job disabled", slog.String("mod", "cron"), slog.String("act", "schedule"), slog.String("job", name))
+			continue
+		}
+		sched, err := Parse(spec, e.Location)
+		if err != nil {
+			slog.Error("invalid schedule", util.ErrAtrr(err),
+				slog.String("mod", "cron"), slog.String("act", "schedule"), slog.String("job", name))
+			continue
+		}
+		slog.Debug("job scheduled", slog.String("mod", "cron"), slog.String("act", "schedule"),
+			slog.String("job", name), slog.String("spec", spec))
+		s.loops.Add(1)
+		go s.loop(e, sched, s.stop)
+	}
+}
+
+// halt stops the loops, running jobs are not affected.
+func (s *scheduler) halt() {
+	close(s.stop)
+	s.loops.Wait()
+}
+
+func (s *scheduler) loop(e *entry, sched Schedule, stop chan struct{}) {
+	defer s.loops.Done()
+	for {
+		at := sched.Next(time.Now())
+		if at.IsZero() {
+			slog.Warn("job will not run again", slog.String("mod", "cron"), slog.String("act", "schedule"), slog.String("job", e.Name))
+			return
+		}
+		if e.Jitter > 0 {
+			at = at.Add(time.Duration(rand.Int63n(int64(e.Jitter))))
+		}
+		timer := time.NewTimer(time.Until(at))
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.fire(e)
+		}
+	}
+}
+
+// fire runs the job in background, unless the previous run is still running and overlap is not allowed.
+func (s *scheduler) fire(e *entry) {
+	e.lock.Lock()
+	if e.running && !e.Overlap {
+		e.lock.Unlock()
+		slog.Warn("job skipped", slog.String("mod", "cron"), slog.String("act", "run"),
+			slog.String("job", e.Name), slog.String("outcome", "skipped"))
+		return
+	}
+	e.running = true
+	e.lock.Unlock()
+
+	s.runs.Add(1)
+	go func() {
+		defer s.runs.Done()
+		s.run(e)
+		e.lock.Lock()
+		e.running = false
+		e.lock.Unlock()
+	}()
+}
+
+// run runs the job with panic recovery, and logs its duration and outcome.
+// A job done is logged at debug level, since jobs like the digest run every minute.
+func (s *scheduler) run(e *entry) {
+	start := time.Now()
+	var err error
+	defer func() {
+		args := []any{
+			slog.String("mod", "cron"), slog.String("act", "run"), slog.String("job", e.Name),
+			slog.Duration("duration", time.Since(start)),
+		}
+		if pan := recover(); pan != nil {
+			args = append(args, slog.String("outcome", "panic"), slog.String("err", fmt.Sprint(pan)))
+			if property.IsDebug() {
+				args = append(args, slog.String("stack", util.Stack()))
+			}
+			slog.Error("job panicked", args...)
+			return
+		}
+		if err != nil {
+			slog.Error("job failed", append(args, slog.String("outcome", "error"), util.ErrAtrr(err))...)
+			return
+		}
+		slog.Debug("job done", append(args, slog.String("outcome", "ok"))...)
+	}()
+	err = e.Run(s.ctx)
+}
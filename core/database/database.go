@@ -0,0 +1,106 @@
+/*
+	database.go
+	Purpose: A shared database handle for modules to store their data.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+// Package database holds the connection of the application and the schema migrations of each module.
+//
+// Queries should be written in the common subset of sqlite and postgres,
+// placeholders are written as $1, $2... which both drivers understand.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"app/core/errors"
+)
+
+// Dialects of the supported databases, which is the value of the DB config.
+const (
+	SQLITE   = "sqlite"
+	POSTGRES = "postgres"
+)
+
+// Executor runs queries, it is implemented by both *sql.DB and *sql.Tx
+// so that functions could take part in a transaction of the caller.
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+var std *sql.DB
+var dialect = SQLITE
+
+// Open connects to the database of @db dialect with @dsn and sets it as the default handle.
+func Open(db, dsn string) (*sql.DB, error) {
+	var driver string
+	switch db {
+	case SQLITE:
+		driver = "sqlite3"
+	case POSTGRES:
+		driver = "postgres"
+	default:
+		return nil, fmt.Errorf("unsupported database: %s", db)
+	}
+
+	conn, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if db == SQLITE {
+		// sqlite only allows a single writer, serialize the access instead of failing with SQLITE_BUSY.
+		conn.SetMaxOpenConns(1)
+	}
+	if err := conn.Ping(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	std, dialect = conn, db
+	return conn, nil
+}
+
+// DB returns the default handle, it is nil before [Open].
+func DB() *sql.DB { return std }
+
+// Dialect returns the dialect of the default handle.
+func Dialect() string { return dialect }
+
+// Tx runs @fn in a transaction, which is committed if @fn returns nil or rolled back otherwise.
+//
+// Note that sqlite holds a single connection,
+// so @fn must only query through @tx or it would wait forever.
+func Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := std.BeginTx(ctx, nil)
+	if err != nil {
+		return Err(err)
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return Err(tx.Commit())
+}
+
+// Err converts errors of database/sql to errors of app/core/errors.
+func Err(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, sql.ErrNoRows):
+		return errors.ErrNotFound
+	}
+	if e, ok := err.(*errors.Error); ok {
+		return e
+	}
+	return errors.ErrInternal.SetInfo(err.Error())
+}
@@ -0,0 +1,86 @@
+/*
+	migrate.go
+	Purpose: Schema migrations registered by modules.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+// Migration is a set of statements to change the schema.
+type Migration struct {
+	// ID identifies the migration and decides the order to apply, e.g. "20261017_conversation".
+	// It should never be changed once released.
+	ID string
+	// Dialect limits the migration to a database, empty for all.
+	Dialect string
+	// Stmts are executed in order within a transaction.
+	Stmts []string
+}
+
+var migrations []*Migration
+
+// Register registers migrations which will be applied by [Migrate].
+//
+// It is usually called in the init function of each module.
+func Register(m ...*Migration) {
+	migrations = append(migrations, m...)
+}
+
+// Migrate applies the registered migrations that have not been applied yet.
+func Migrate(ctx context.Context) error {
+	if _, err := std.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		id TEXT PRIMARY KEY,
+		applied_at BIGINT NOT NULL
+	)`); err != nil {
+		return err
+	}
+
+	list := []*Migration{}
+	for _, m := range migrations {
+		if m.Dialect == "" || m.Dialect == dialect {
+			list = append(list, m)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+
+	for _, m := range list {
+		err := Tx(ctx, func(tx *sql.Tx) error {
+			var id string
+			err := tx.QueryRowContext(ctx, "SELECT id FROM schema_migrations WHERE id = $1", m.ID).Scan(&id)
+			if err == nil {
+				return nil
+			} else if err != sql.ErrNoRows {
+				return err
+			}
+
+			slog.Info("migrating", slog.String("mod", "database"), slog.String("act", "migrate"), slog.String("id", m.ID))
+			for _, stmt := range m.Stmts {
+				if _, err := tx.ExecContext(ctx, stmt); err != nil {
+					return err
+				}
+			}
+			_, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (id, applied_at) VALUES ($1, $2)", m.ID, time.Now().Unix())
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("migration %s failed: %w", m.ID, err)
+		}
+	}
+	return nil
+}
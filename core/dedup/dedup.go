@@ -0,0 +1,231 @@
+/*
+	dedup.go
+	Purpose: Detect redelivered webhook events and run handlers once per event.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Serve the counters to admins and log them hourly
+*/
+
+// Package dedup is the idempotency layer of the LINE webhook.
+//
+// LINE could redeliver a webhook event with the same webhookEventId,
+// the webhook marks such events with [line.Event.Duplicate] once [Setup] is called.
+// Handlers registered with [Handle] choose how to deal with them:
+//
+//   - [AtLeastOnce] handlers receive every delivery, they should be idempotent themselves.
+//   - [ExactlyOnce] handlers claim the event before handling it and skip events already claimed,
+//     a claim is released if the handler fails, so a redelivery could be handled again.
+package dedup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"app/core/auth"
+	"app/core/cron"
+	"app/core/errors"
+	"app/core/line"
+	"app/core/server"
+	"app/core/signal"
+	"app/core/util"
+
+	"golang.org/x/exp/slog"
+)
+
+// Stores that could be set by name with [ParseStore].
+const (
+	MEMORY   = "memory"
+	DATABASE = "database"
+)
+
+// Mode is the delivery semantics of a handler.
+type Mode int
+
+const (
+	// AtLeastOnce handles every delivery, including duplicates.
+	AtLeastOnce Mode = iota
+	// ExactlyOnce handles an event only once per handler name within the TTL.
+	ExactlyOnce
+)
+
+// Option configures the idempotency layer.
+type Option struct {
+	// Store records the event IDs, defaults to an in-memory store.
+	Store Store
+	// TTL is how long an event ID is remembered.
+	TTL time.Duration
+}
+
+var opt = Option{Store: NewMemory(), TTL: 24 * time.Hour}
+
+// Setup sets the options and starts marking duplicated events in the webhook, empty fields are left unchanged.
+func Setup(o *Option) {
+	if o.Store != nil {
+		opt.Store = o.Store
+	}
+	if o.TTL > 0 {
+		opt.TTL = o.TTL
+	}
+	line.SetDeduplicator(received)
+}
+
+// ParseStore returns the [Store] of @name, either [MEMORY] or [DATABASE].
+func ParseStore(name string) (Store, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", MEMORY:
+		return NewMemory(), nil
+	case DATABASE:
+		return NewDatabase(), nil
+	default:
+		return nil, fmt.Errorf("unknown dedup store %q", name)
+	}
+}
+
+func init() {
+	cron.Register(&cron.Job{
+		Name: "dedup.purge",
+		Spec: "@hourly",
+		Run: func(ctx context.Context) error {
+			st := Counters()
+			slog.Info("event counters", slog.String("mod", "dedup"), slog.String("act", "stats"),
+				slog.Int64("received", st.Received), slog.Int64("redeliveries", st.Redeliveries),
+				slog.Int64("duplicates", st.Duplicates), slog.Int64("skipped", st.Skipped))
+			_, err := opt.Store.Purge(ctx)
+			return err
+		},
+	})
+}
+
+// Stats are the counters of the idempotency layer since start.
+type Stats struct {
+	// Received is the number of events with an ID received by the webhook.
+	Received int64 `json:"received"`
+	// Redeliveries is the number of events LINE flagged as redelivered.
+	Redeliveries int64 `json:"redeliveries"`
+	// Duplicates is the number of events received before.
+	Duplicates int64 `json:"duplicates"`
+	// Skipped is the number of times an [ExactlyOnce] handler skipped an event.
+	Skipped int64 `json:"skipped"`
+}
+
+var stats struct {
+	received, redeliveries, duplicates, skipped atomic.Int64
+}
+
+// Counters returns the current [Stats].
+func Counters() Stats {
+	return Stats{
+		Received:     stats.received.Load(),
+		Redeliveries: stats.redeliveries.Load(),
+		Duplicates:   stats.duplicates.Load(),
+		Skipped:      stats.skipped.Load(),
+	}
+}
+
+// StatsPath is the path of the gateway to get the counters, see [ServeStats].
+const StatsPath = "/api/admin/dedup/stats"
+
+// ServeStats serves the current [Stats] to admins, e.g. {"received": 120, "redeliveries": 3, "duplicates": 2, "skipped": 2}.
+// It is a handler of the gateway mux, see [runtime.ServeMux.HandlePath].
+func ServeStats(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	u, ok := auth.GetUserFromToken(server.GetHttpAuthToken(r))
+	if !ok {
+		server.HttpAbort(w, r, errors.ErrUnauthorized)
+		return
+	}
+	if u.Group < auth.ADMIN {
+		server.HttpAbort(w, r, errors.ErrForbidden)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(Counters())
+}
+
+// received is the [line.Deduplicator] of the webhook.
+func received(ctx context.Context, evt *line.Event) bool {
+	stats.received.Add(1)
+	if evt.DeliveryContext.IsRedelivery {
+		stats.redeliveries.Add(1)
+	}
+	first, err := opt.Store.Claim(ctx, "webhook:"+evt.WebhookEventID, opt.TTL)
+	if err != nil {
+		slog.Error("record event failed", util.ErrAtrr(err),
+			slog.String("mod", "dedup"), slog.String("act", "webhook"), slog.String("id", evt.WebhookEventID))
+		return false
+	}
+	if !first {
+		stats.duplicates.Add(1)
+		slog.Info("duplicated event", slog.String("mod", "dedup"), slog.String("act", "webhook"),
+			slog.String("id", evt.WebhookEventID), slog.Bool("redelivery", evt.DeliveryContext.IsRedelivery))
+	}
+	return !first
+}
+
+// Handler handles a webhook event.
+type Handler func(ctx context.Context, evt *line.Event) error
+
+// Handle handles the events of @topic in background with the semantics of @mode,
+// @name identifies the handler to claim events for [ExactlyOnce].
+func Handle(name, topic string, mode Mode, h Handler) (close func()) {
+	return signal.Handle(topic, func(e signal.Event) {
+		evt, ok := line.EventOf(e)
+		if !ok {
+			return
+		}
+		go func() {
+			ctx := context.Background()
+			var err error
+			if mode == ExactlyOnce {
+				_, err = Once(ctx, name, evt, func() error { return h(ctx, evt) })
+			} else {
+				err = h(ctx, evt)
+			}
+			if err != nil {
+				slog.Error("handle event failed", util.ErrAtrr(err),
+					slog.String("mod", "dedup"), slog.String("act", name), slog.String("id", evt.WebhookEventID))
+			}
+		}()
+	})
+}
+
+// Once runs @fn unless @evt has been claimed by @name, ran is false if it is skipped.
+// The claim is released if @fn returns an error.
+//
+// Events without an ID, e.g. from tests, are always run.
+func Once(ctx context.Context, name string, evt *line.Event, fn func() error) (ran bool, err error) {
+	if evt.WebhookEventID == "" {
+		return true, fn()
+	}
+	key := name + ":" + evt.WebhookEventID
+	first, err := opt.Store.Claim(ctx, key, opt.TTL)
+	if err != nil {
+		// better to handle it twice than to lose it
+		slog.Warn("claim event failed", util.ErrAtrr(err),
+			slog.String("mod", "dedup"), slog.String("act", name), slog.String("id", evt.WebhookEventID))
+		return true, fn()
+	}
+	if !first {
+		stats.skipped.Add(1)
+		slog.Info("event skipped", slog.String("mod", "dedup"), slog.String("act", name),
+			slog.String("id", evt.WebhookEventID))
+		return false, nil
+	}
+	if err := fn(); err != nil {
+		if rerr := opt.Store.Release(ctx, key); rerr != nil {
+			slog.Error("release event failed", util.ErrAtrr(rerr),
+				slog.String("mod", "dedup"), slog.String("act", name), slog.String("id", evt.WebhookEventID))
+		}
+		return true, err
+	}
+	return true, nil
+}
@@ -0,0 +1,154 @@
+package dedup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"app/core/auth"
+	"app/core/database"
+	_ "app/core/driver"
+	"app/core/line"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	database.Open(database.SQLITE, ":memory:")
+	if err := database.Migrate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for name, s := range map[string]Store{MEMORY: NewMemory(), DATABASE: NewDatabase()} {
+		steps := []struct {
+			op    string
+			key   string
+			ttl   time.Duration
+			first bool
+		}{
+			{"claim", "a", time.Hour, true},
+			{"claim", "a", time.Hour, false},
+			{"claim", "b", time.Hour, true},
+			{"release", "a", 0, false},
+			{"claim", "a", time.Hour, true},
+			{"claim", "a", time.Hour, false},
+			// an expired key could be claimed again
+			{"claim", "c", -time.Hour, true},
+			{"claim", "c", time.Hour, true},
+			{"claim", "c", time.Hour, false},
+		}
+		for i, st := range steps {
+			switch st.op {
+			case "claim":
+				first, err := s.Claim(ctx, st.key, st.ttl)
+				if err != nil || first != st.first {
+					t.Errorf("%s step %d: Claim(%s) = %v, %v, want %v", name, i, st.key, first, err, st.first)
+				}
+			case "release":
+				if err := s.Release(ctx, st.key); err != nil {
+					t.Errorf("%s step %d: Release(%s) = %v", name, i, st.key, err)
+				}
+			}
+		}
+		s.Claim(ctx, "d", -time.Hour)
+		if n, err := s.Purge(ctx); n != 1 || err != nil {
+			t.Errorf("%s: Purge = %d, %v, want 1", name, n, err)
+		}
+		if first, _ := s.Claim(ctx, "b", time.Hour); first {
+			t.Errorf("%s: unexpired key purged", name)
+		}
+	}
+}
+
+func TestReceived(t *testing.T) {
+	opt.Store = NewMemory()
+	ctx := context.Background()
+	before := Counters()
+	tests := []struct {
+		id         string
+		redelivery bool
+		dup        bool
+	}{
+		{"E1", false, false},
+		{"E2", false, false},
+		{"E1", true, true},
+		{"E1", true, true},
+		{"E3", true, false},
+	}
+	for i, tt := range tests {
+		evt := &line.Event{WebhookEventID: tt.id, DeliveryContext: line.DeliveryContext{IsRedelivery: tt.redelivery}}
+		if dup := received(ctx, evt); dup != tt.dup {
+			t.Errorf("event %d %s: duplicate = %v, want %v", i, tt.id, dup, tt.dup)
+		}
+	}
+	after := Counters()
+	if got := (Stats{after.Received - before.Received, after.Redeliveries - before.Redeliveries,
+		after.Duplicates - before.Duplicates, after.Skipped - before.Skipped}); got != (Stats{5, 3, 2, 0}) {
+		t.Errorf("counted %+v", got)
+	}
+}
+
+func TestOnce(t *testing.T) {
+	opt.Store = NewMemory()
+	ctx := context.Background()
+	fail := errors.New("failed")
+	tests := []struct {
+		name string
+		id   string
+		err  error // returned by the handler
+		ran  bool
+	}{
+		{"a", "E1", nil, true},
+		{"a", "E1", nil, false},
+		// handlers claim for themselves
+		{"b", "E1", nil, true},
+		// a failed handler releases the claim for a redelivery
+		{"a", "E2", fail, true},
+		{"a", "E2", nil, true},
+		{"a", "E2", nil, false},
+		// events without an ID are always run
+		{"a", "", nil, true},
+		{"a", "", nil, true},
+	}
+	for i, tt := range tests {
+		called := false
+		ran, err := Once(ctx, tt.name, &line.Event{WebhookEventID: tt.id}, func() error {
+			called = true
+			return tt.err
+		})
+		if ran != tt.ran || called != tt.ran || err != tt.err {
+			t.Errorf("step %d %s %s: ran %v, called %v, err %v, want ran %v, err %v", i, tt.name, tt.id, ran, called, err, tt.ran, tt.err)
+		}
+	}
+}
+
+func TestServeStats(t *testing.T) {
+	user, _ := auth.NewClaim(&auth.UserInfo{Username: "U1", Group: auth.USER}).Token()
+	admin, _ := auth.NewClaim(&auth.UserInfo{Username: "A1", Group: auth.ADMIN}).Token()
+	tests := []struct {
+		token  string
+		status int
+	}{
+		{"", http.StatusUnauthorized},
+		{"Bearer forged", http.StatusUnauthorized},
+		{"Bearer " + user, http.StatusForbidden},
+		{"Bearer " + admin, http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, StatsPath, nil)
+		req.Header.Set("Authorization", tt.token)
+		rec := httptest.NewRecorder()
+		ServeStats(rec, req, nil)
+		if rec.Code != tt.status {
+			t.Errorf("token %.20q: status = %d, want %d", tt.token, rec.Code, tt.status)
+		}
+		if rec.Code == http.StatusOK {
+			st := Stats{}
+			if err := json.NewDecoder(rec.Body).Decode(&st); err != nil || st != Counters() {
+				t.Errorf("served %+v, %v, want %+v", st, err, Counters())
+			}
+		}
+	}
+}
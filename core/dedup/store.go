@@ -0,0 +1,115 @@
+/*
+	store.go
+	Purpose: Stores to record processed keys with expiry.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package dedup
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"app/core/database"
+)
+
+func init() {
+	database.Register(&database.Migration{
+		ID: "20261017_dedup",
+		Stmts: []string{`CREATE TABLE processed_events (
+			id TEXT PRIMARY KEY,
+			expires_at BIGINT NOT NULL
+		)`},
+	})
+}
+
+// Store records keys until they expire.
+type Store interface {
+	// Claim records @key for @ttl, first is false if @key is already recorded and not expired.
+	Claim(ctx context.Context, key string, ttl time.Duration) (first bool, err error)
+	// Release removes @key, so it could be claimed again.
+	Release(ctx context.Context, key string) error
+	// Purge removes expired keys.
+	Purge(ctx context.Context) (int64, error)
+}
+
+// NewMemory returns a [Store] in memory, which is lost on restarts.
+func NewMemory() Store {
+	return &memory{keys: map[string]int64{}}
+}
+
+type memory struct {
+	lock sync.Mutex
+	keys map[string]int64
+}
+
+func (m *memory) Claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	now := time.Now()
+	if exp, ok := m.keys[key]; ok && exp > now.UnixNano() {
+		return false, nil
+	}
+	m.keys[key] = now.Add(ttl).UnixNano()
+	return true, nil
+}
+
+func (m *memory) Release(ctx context.Context, key string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.keys, key)
+	return nil
+}
+
+func (m *memory) Purge(ctx context.Context) (int64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	now := time.Now().UnixNano()
+	var n int64
+	for k, exp := range m.keys {
+		if exp <= now {
+			delete(m.keys, k)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// NewDatabase returns a [Store] in the database, which survives restarts
+// and could be shared by multiple instances.
+func NewDatabase() Store { return dbStore{} }
+
+type dbStore struct{}
+
+// Claim inserts the key, or takes over an expired one.
+func (dbStore) Claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	res, err := database.DB().ExecContext(ctx, `INSERT INTO processed_events (id, expires_at) VALUES ($1, $2)
+		ON CONFLICT (id) DO UPDATE SET expires_at = excluded.expires_at WHERE processed_events.expires_at <= $3`,
+		key, now.Add(ttl).Unix(), now.Unix())
+	if err != nil {
+		return false, database.Err(err)
+	}
+	n, err := res.RowsAffected()
+	return n > 0, database.Err(err)
+}
+
+func (dbStore) Release(ctx context.Context, key string) error {
+	_, err := database.DB().ExecContext(ctx, "DELETE FROM processed_events WHERE id = $1", key)
+	return database.Err(err)
+}
+
+func (dbStore) Purge(ctx context.Context) (int64, error) {
+	res, err := database.DB().ExecContext(ctx, "DELETE FROM processed_events WHERE expires_at <= $1", time.Now().Unix())
+	if err != nil {
+		return 0, database.Err(err)
+	}
+	return res.RowsAffected()
+}
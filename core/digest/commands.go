@@ -0,0 +1,134 @@
+/*
+	commands.go
+	Purpose: Chat commands to preview the digest and change its settings.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package digest
+
+import (
+	"fmt"
+	"time"
+
+	"app/core/command"
+	"app/core/msg"
+)
+
+func init() {
+	command.Register(
+		&command.Command{
+			Name:    "digest",
+			Aliases: []string{"摘要"},
+			Desc:    "digest.desc",
+			Handler: info,
+		},
+		&command.Command{
+			Name:    "digest now",
+			Desc:    "digest.now.desc",
+			Handler: now,
+		},
+		&command.Command{
+			Name:    "digest at",
+			Args:    []command.Arg{{Name: "HH:MM", Required: true}, {Name: "timezone"}},
+			Desc:    "digest.at.desc",
+			Handler: setAt,
+		},
+		&command.Command{
+			Name:    "digest on",
+			Desc:    "digest.on.desc",
+			Handler: func(c *command.Context) error { return toggle(c, false) },
+		},
+		&command.Command{
+			Name:    "digest off",
+			Desc:    "digest.off.desc",
+			Handler: func(c *command.Context) error { return toggle(c, true) },
+		},
+	)
+}
+
+// settings gets the digest settings of the sender, ok is false if the chat is not one-on-one,
+// since the digest is personal.
+func settings(c *command.Context) (*Settings, bool, error) {
+	if c.InGroup() || c.Event.Source == nil || c.Event.Source.UserID == "" {
+		return nil, false, c.ReplyText("digest.personal", nil)
+	}
+	s, err := Get(c, c.Event.Source.UserID)
+	return s, err == nil, err
+}
+
+func info(c *command.Context) error {
+	s, ok, err := settings(c)
+	if !ok {
+		return err
+	}
+	return c.ReplyText("digest.settings", view(s))
+}
+
+// now replies the digest of today as a preview.
+func now(c *command.Context) error {
+	s, ok, err := settings(c)
+	if !ok {
+		return err
+	}
+	day, _ := s.schedule(time.Now())
+	data := Build(c, s.UserID, day, c.Locale)
+	if data == nil {
+		return c.ReplyText("digest.empty", nil)
+	}
+	return c.ReplyFlex("digest.push", data)
+}
+
+func setAt(c *command.Context) error {
+	s, ok, err := settings(c)
+	if !ok {
+		return err
+	}
+	mins, err := clock(c.Args.Get("HH:MM"))
+	if err != nil {
+		return c.ReplyText("digest.bad_time", msg.Plain(c.Args.Get("HH:MM")))
+	}
+	s.At = fmt.Sprintf("%02d:%02d", mins/60, mins%60)
+	if tz := c.Args.Get("timezone"); tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil || tz == "Local" {
+			return c.ReplyText("digest.bad_tz", msg.Plain(tz))
+		}
+		s.TZ = tz
+	}
+	s.Off = false
+	if err := Save(c, s); err != nil {
+		return err
+	}
+	return c.ReplyText("digest.settings", view(s))
+}
+
+func toggle(c *command.Context, off bool) error {
+	s, ok, err := settings(c)
+	if !ok {
+		return err
+	}
+	s.Off = off
+	if err := Save(c, s); err != nil {
+		return err
+	}
+	return c.ReplyText("digest.settings", view(s))
+}
+
+// view is the template data of the settings, with the defaults filled.
+func view(s *Settings) map[string]any {
+	at, tz := s.At, s.TZ
+	if at == "" {
+		at = opt.At
+	}
+	if tz == "" {
+		if tz = opt.Location.String(); tz == "Local" {
+			tz, _ = time.Now().Zone()
+		}
+	}
+	return map[string]any{"At": at, "TZ": tz, "Off": s.Off}
+}
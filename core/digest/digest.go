@@ -0,0 +1,254 @@
+/*
+	digest.go
+	Purpose: Build and push the daily digest of users from the sections of skills.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Add Location of users
+	2026/10/17  v1.0.2 Evan Chen   Note why calendar events have no section of their own
+*/
+
+// Package digest pushes every user one message each morning, combining the sections
+// provided by the skills, e.g. today's reminders and overdue todos.
+//
+// Skills register a [Provider] in their init functions, the section of which is rendered
+// with its message template, and the digest itself is rendered with the "digest.push" template,
+// so the layout is localizable with message packs. Users pick the delivery time and
+// time zone with "/digest at 07:30 Asia/Taipei", a digest without any section is not pushed.
+//
+// Calendar events have no section of their own: the events of .ics files are imported as reminders,
+// so today's events are in the section of reminders, and the feeds of package calendar are served
+// to calendar apps rather than subscribed to. A skill subscribing to remote feeds would register its own.
+package digest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"app/core/cron"
+	"app/core/database"
+	"app/core/flex"
+	"app/core/group"
+	"app/core/msg"
+	"app/core/outbox"
+	"app/core/util"
+
+	"golang.org/x/exp/slog"
+)
+
+// Option configures the digest.
+type Option struct {
+	// At is the default delivery time, e.g. 07:00.
+	At string
+	// Location is the default time zone of users.
+	Location *time.Location
+}
+
+var opt = Option{At: "07:00", Location: time.Local}
+
+// Setup sets the options, empty fields are left unchanged.
+func Setup(o *Option) error {
+	if o.At != "" {
+		if _, err := clock(o.At); err != nil {
+			return err
+		}
+		opt.At = o.At
+	}
+	if o.Location != nil {
+		opt.Location = o.Location
+	}
+	return nil
+}
+
+// grace is how late a digest could still be pushed, e.g. after the server was down in the morning.
+const grace = 2 * time.Hour
+
+// Provider provides a section of the digest.
+type Provider struct {
+	// Name identifies the provider, e.g. "reminder".
+	Name string
+	// Order sorts the sections, the smaller the earlier.
+	Order int
+	// Title is the message key of the title of the section.
+	Title string
+	// Key is the message key of the section, which is rendered with the data collected.
+	Key string
+	// Collect collects the data of the section for @userID on @day, which is the start of the day
+	// in the time zone of the user. The section is left out if the data is nil.
+	Collect func(ctx context.Context, userID string, day time.Time) (any, error)
+}
+
+var (
+	providers     = map[string]*Provider{}
+	providersLock sync.RWMutex
+)
+
+// Register registers providers of sections, providers with the same name will be overwritten.
+//
+// It is usually called in the init function of each skill.
+func Register(ps ...*Provider) {
+	providersLock.Lock()
+	defer providersLock.Unlock()
+	for _, p := range ps {
+		providers[p.Name] = p
+	}
+}
+
+// sorted returns the providers by order.
+func sorted() []*Provider {
+	providersLock.RLock()
+	defer providersLock.RUnlock()
+	list := make([]*Provider, 0, len(providers))
+	for _, p := range providers {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Order != list[j].Order {
+			return list[i].Order < list[j].Order
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// Build builds the template data of the digest of @userID on @day in @locale, nil if no section has content.
+//
+// A failing provider is logged and left out, so that the other sections are still delivered.
+func Build(ctx context.Context, userID string, day time.Time, locale string) map[string]any {
+	sections := []map[string]any{}
+	for _, p := range sorted() {
+		data, err := p.Collect(ctx, userID, day)
+		if err != nil {
+			slog.Error("collect digest section failed", util.ErrAtrr(err), slog.String("mod", "digest"), slog.String("act", "build"),
+				slog.String("provider", p.Name), slog.String("usr", userID))
+			continue
+		}
+		if data == nil {
+			continue
+		}
+		sections = append(sections, map[string]any{
+			"Name":  p.Name,
+			"Title": msg.T(p.Title, locale, data),
+			"Text":  msg.T(p.Key, locale, data),
+		})
+	}
+	if len(sections) == 0 {
+		return nil
+	}
+	return map[string]any{
+		"Date":     day.Format("2006/01/02"),
+		"Weekday":  msg.T("digest.weekday."+strconv.Itoa(int(day.Weekday())), locale, nil),
+		"Sections": sections,
+	}
+}
+
+// clock parses a delivery time like "7:30" or "07:30" into minutes of the day.
+func clock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, should be like 07:30", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Location returns the time zone of @userID picked for the digest, or the default time zone if not picked.
+func Location(ctx context.Context, userID string) *time.Location {
+	s, err := Get(ctx, userID)
+	if err != nil {
+		return opt.Location
+	}
+	return s.location()
+}
+
+// location returns the time zone of @s.
+func (s *Settings) location() *time.Location {
+	if s.TZ != "" {
+		if l, err := time.LoadLocation(s.TZ); err == nil {
+			return l
+		}
+	}
+	return opt.Location
+}
+
+// schedule returns the start of the day of @now and the delivery time on it, in the time zone of @s.
+func (s *Settings) schedule(now time.Time) (day, at time.Time) {
+	loc := s.location()
+	now = now.In(loc)
+	day = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	mins, err := clock(s.At)
+	if err != nil {
+		mins, _ = clock(opt.At)
+	}
+	return day, day.Add(time.Duration(mins) * time.Minute)
+}
+
+func init() {
+	cron.Register(&cron.Job{
+		Name: "digest.push",
+		Spec: "* * * * *",
+		Run: func(ctx context.Context) error {
+			return push(ctx, time.Now())
+		},
+	})
+}
+
+// push pushes the digests due at @now through the outbox, each user gets at most one a day.
+func push(ctx context.Context, now time.Time) error {
+	list, err := users(ctx)
+	if err != nil {
+		return err
+	}
+	pushed := 0
+	for _, s := range list {
+		day, at := s.schedule(now)
+		date := day.Format("2006-01-02")
+		if s.Off || s.SentOn == date || now.Before(at) || now.Sub(at) > grace {
+			continue
+		}
+		locale := group.Locale(ctx, s.UserID)
+		data := Build(ctx, s.UserID, day, locale)
+		var m *flex.Message
+		if data != nil {
+			if m, err = msg.Flex("digest.push", locale, data); err != nil {
+				slog.Error("render digest failed", util.ErrAtrr(err), slog.String("mod", "digest"), slog.String("act", "push"),
+					slog.String("usr", s.UserID))
+				continue
+			}
+		}
+		// the digest is marked sent together with its message put into the outbox,
+		// an empty digest is marked sent too so it is not built again today
+		err = database.Tx(ctx, func(tx *sql.Tx) error {
+			if err := markSent(ctx, tx, s.UserID, date); err != nil {
+				return err
+			}
+			if m == nil {
+				return nil
+			}
+			_, err := outbox.Enqueue(ctx, tx, s.UserID, m)
+			return err
+		})
+		if err != nil {
+			slog.Error("push digest failed", util.ErrAtrr(err), slog.String("mod", "digest"), slog.String("act", "push"),
+				slog.String("usr", s.UserID))
+			continue
+		}
+		if m != nil {
+			pushed++
+			slog.Info("digest pushed", slog.String("mod", "digest"), slog.String("act", "push"), slog.String("usr", s.UserID),
+				slog.Int("sections", len(data["Sections"].([]map[string]any))))
+		}
+	}
+	if pushed > 0 {
+		outbox.Notify()
+	}
+	return nil
+}
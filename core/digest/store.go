@@ -0,0 +1,102 @@
+/*
+	store.go
+	Purpose: Persist the digest settings of users in the database.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package digest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"app/core/database"
+)
+
+func init() {
+	database.Register(&database.Migration{
+		ID: "20261017_digest",
+		Stmts: []string{
+			`CREATE TABLE digests (
+				user_id TEXT PRIMARY KEY,
+				deliver_at TEXT NOT NULL,
+				timezone TEXT NOT NULL,
+				off INTEGER NOT NULL,
+				sent_on TEXT NOT NULL,
+				updated_at BIGINT NOT NULL
+			)`,
+		},
+	})
+}
+
+// Settings are the digest settings of a user, empty fields follow the [Option].
+type Settings struct {
+	UserID string
+	At     string // delivery time, e.g. 07:30
+	TZ     string // IANA time zone, e.g. Asia/Taipei
+	Off    bool
+	SentOn string // the date in the time zone of the user the digest was last sent, e.g. 2026-10-17
+}
+
+// Get gets the settings of @userID, the defaults are returned if never set.
+func Get(ctx context.Context, userID string) (*Settings, error) {
+	s := &Settings{UserID: userID}
+	var off int
+	err := database.DB().QueryRowContext(ctx,
+		"SELECT deliver_at, timezone, off, sent_on FROM digests WHERE user_id = $1", userID,
+	).Scan(&s.At, &s.TZ, &off, &s.SentOn)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, database.Err(err)
+	}
+	s.Off = off == 1
+	return s, nil
+}
+
+// Save saves the delivery time, time zone and whether the digest is off of @s.
+func Save(ctx context.Context, s *Settings) error {
+	off := 0
+	if s.Off {
+		off = 1
+	}
+	_, err := database.DB().ExecContext(ctx, `INSERT INTO digests (user_id, deliver_at, timezone, off, sent_on, updated_at)
+		VALUES ($1, $2, $3, $4, '', $5) ON CONFLICT (user_id) DO UPDATE SET deliver_at = $2, timezone = $3, off = $4, updated_at = $5`,
+		s.UserID, s.At, s.TZ, off, time.Now().Unix())
+	return database.Err(err)
+}
+
+// users lists the settings of every known user, including those who never set them.
+func users(ctx context.Context) ([]*Settings, error) {
+	rows, err := database.DB().QueryContext(ctx, `SELECT a.line_user_id, COALESCE(d.deliver_at, ''), COALESCE(d.timezone, ''),
+		COALESCE(d.off, 0), COALESCE(d.sent_on, '') FROM accounts a LEFT JOIN digests d ON d.user_id = a.line_user_id
+		ORDER BY a.line_user_id`)
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	defer rows.Close()
+	list := []*Settings{}
+	for rows.Next() {
+		s := &Settings{}
+		var off int
+		if err := rows.Scan(&s.UserID, &s.At, &s.TZ, &off, &s.SentOn); err != nil {
+			return nil, database.Err(err)
+		}
+		s.Off = off == 1
+		list = append(list, s)
+	}
+	return list, database.Err(rows.Err())
+}
+
+// markSent records the digest of @userID sent on @date within the transaction of @ex.
+func markSent(ctx context.Context, ex database.Executor, userID, date string) error {
+	_, err := ex.ExecContext(ctx, `INSERT INTO digests (user_id, deliver_at, timezone, off, sent_on, updated_at)
+		VALUES ($1, '', '', 0, $2, $3) ON CONFLICT (user_id) DO UPDATE SET sent_on = $2`,
+		userID, date, time.Now().Unix())
+	return database.Err(err)
+}
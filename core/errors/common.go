@@ -30,6 +30,9 @@ var ErrNotFound = New("ECMN-05-0", codes.NotFound, nil)
 // ErrConflict 409
 var ErrConflict = New("ECMN-06-0", codes.AlreadyExists, nil)
 
+// ErrResourceExhausted 429
+var ErrResourceExhausted = New("ECMN-08-0", codes.ResourceExhausted, nil)
+
 // ErrInternal 500
 var ErrInternal = New("ECMN-02-0", codes.Internal, nil)
 
@@ -56,6 +59,8 @@ func ECodeFromGCode(c codes.Code) string {
 		return ErrConflict.Code
 	case codes.Unavailable:
 		return ErrServiceUnavailable.Code
+	case codes.ResourceExhausted:
+		return ErrResourceExhausted.Code
 
 	default:
 		return ErrInternal.Code
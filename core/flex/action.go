@@ -0,0 +1,110 @@
+/*
+	action.go
+	Purpose: Actions performed when a component is tapped.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Limit the data of postbacks
+*/
+
+package flex
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Types of an [Action].
+const (
+	ActionMessage  = "message"
+	ActionURI      = "uri"
+	ActionPostback = "postback"
+	ActionDatetime = "datetimepicker"
+)
+
+// Modes of a datetime picker action.
+const (
+	ModeDate     = "date"
+	ModeTime     = "time"
+	ModeDatetime = "datetime"
+)
+
+// MaxActionLabel is the max characters of the label of an action.
+const MaxActionLabel = 40
+
+// MaxActionData is the max characters of the data of a postback or datetime picker action.
+const MaxActionData = 300
+
+// Action is performed when a component is tapped.
+//
+//   - https://developers.line.biz/en/reference/messaging-api/#action-objects
+type Action struct {
+	Type        string `json:"type"`
+	Label       string `json:"label,omitempty"`
+	Text        string `json:"text,omitempty"`
+	URI         string `json:"uri,omitempty"`
+	Data        string `json:"data,omitempty"`
+	DisplayText string `json:"displayText,omitempty"`
+	Mode        string `json:"mode,omitempty"`
+	Initial     string `json:"initial,omitempty"`
+	Max         string `json:"max,omitempty"`
+	Min         string `json:"min,omitempty"`
+}
+
+// NewMessageAction sends @text as the user when tapped.
+func NewMessageAction(label, text string) *Action {
+	return &Action{Type: ActionMessage, Label: label, Text: text}
+}
+
+// NewURIAction opens @uri when tapped.
+func NewURIAction(label, uri string) *Action {
+	return &Action{Type: ActionURI, Label: label, URI: uri}
+}
+
+// NewPostbackAction returns a postback event with @data when tapped,
+// @displayText is shown in the chat as the user if not empty.
+func NewPostbackAction(label, data, displayText string) *Action {
+	return &Action{Type: ActionPostback, Label: label, Data: data, DisplayText: displayText}
+}
+
+// NewDatetimeAction opens a datetime picker of @mode and returns a postback event with @data.
+func NewDatetimeAction(label, data, mode string) *Action {
+	return &Action{Type: ActionDatetime, Label: label, Data: data, Mode: mode}
+}
+
+func (a *Action) validate(path string) error {
+	if a == nil {
+		return nil
+	}
+	if utf8.RuneCountInString(a.Label) > MaxActionLabel {
+		return fmt.Errorf("flex: %s: label exceeds %d characters", path, MaxActionLabel)
+	}
+	if utf8.RuneCountInString(a.Data) > MaxActionData {
+		return fmt.Errorf("flex: %s: data exceeds %d characters", path, MaxActionData)
+	}
+	switch a.Type {
+	case ActionMessage:
+		if a.Text == "" {
+			return fmt.Errorf("flex: %s: message action requires text", path)
+		}
+	case ActionURI:
+		if a.URI == "" {
+			return fmt.Errorf("flex: %s: uri action requires uri", path)
+		}
+	case ActionPostback:
+		if a.Data == "" {
+			return fmt.Errorf("flex: %s: postback action requires data", path)
+		}
+	case ActionDatetime:
+		if a.Data == "" || (a.Mode != ModeDate && a.Mode != ModeTime && a.Mode != ModeDatetime) {
+			return fmt.Errorf("flex: %s: datetime picker action requires data and mode", path)
+		}
+	default:
+		return fmt.Errorf("flex: %s: unknown action type %q", path, a.Type)
+	}
+	return nil
+}
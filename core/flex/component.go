@@ -0,0 +1,396 @@
+/*
+	component.go
+	Purpose: Components that could be put in a flex bubble.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package flex
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Component is a building block of a bubble.
+type Component interface {
+	ComponentType() string
+	validate(path string) error
+}
+
+// Layouts of a [Box].
+const (
+	Vertical   = "vertical"
+	Horizontal = "horizontal"
+	Baseline   = "baseline"
+)
+
+// Box lays out its contents vertically, horizontally or by baseline.
+type Box struct {
+	Layout          string      `json:"layout"`
+	Contents        []Component `json:"contents"`
+	Flex            *int        `json:"flex,omitempty"`
+	Spacing         string      `json:"spacing,omitempty"`
+	Margin          string      `json:"margin,omitempty"`
+	PaddingAll      string      `json:"paddingAll,omitempty"`
+	Width           string      `json:"width,omitempty"`
+	Height          string      `json:"height,omitempty"`
+	BackgroundColor string      `json:"backgroundColor,omitempty"`
+	BorderColor     string      `json:"borderColor,omitempty"`
+	BorderWidth     string      `json:"borderWidth,omitempty"`
+	CornerRadius    string      `json:"cornerRadius,omitempty"`
+	JustifyContent  string      `json:"justifyContent,omitempty"`
+	AlignItems      string      `json:"alignItems,omitempty"`
+	Action          *Action     `json:"action,omitempty"`
+}
+
+// VBox creates a vertical box.
+func VBox(contents ...Component) *Box {
+	return &Box{Layout: Vertical, Contents: contents}
+}
+
+// HBox creates a horizontal box.
+func HBox(contents ...Component) *Box {
+	return &Box{Layout: Horizontal, Contents: contents}
+}
+
+// BaselineBox creates a box which aligns texts and icons by their baseline.
+func BaselineBox(contents ...Component) *Box {
+	return &Box{Layout: Baseline, Contents: contents}
+}
+
+// Add appends @contents to the box.
+func (b *Box) Add(contents ...Component) *Box {
+	b.Contents = append(b.Contents, contents...)
+	return b
+}
+
+// WithSpacing sets the spacing between the contents, e.g. "md".
+func (b *Box) WithSpacing(spacing string) *Box {
+	b.Spacing = spacing
+	return b
+}
+
+// WithAction sets the action when the box is tapped.
+func (b *Box) WithAction(a *Action) *Box {
+	b.Action = a
+	return b
+}
+
+func (*Box) ComponentType() string { return "box" }
+
+func (b *Box) MarshalJSON() ([]byte, error) {
+	type alias Box
+	v := alias(*b)
+	if v.Contents == nil {
+		v.Contents = []Component{} // contents is required even if empty
+	}
+	return json.Marshal(&struct {
+		Type string `json:"type"`
+		*alias
+	}{"box", &v})
+}
+
+func (b *Box) UnmarshalJSON(data []byte) error {
+	type alias Box
+	v := &struct {
+		Contents []json.RawMessage `json:"contents"`
+		*alias
+	}{alias: (*alias)(b)}
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	b.Contents = make([]Component, 0, len(v.Contents))
+	for _, raw := range v.Contents {
+		c, err := unmarshalComponent(raw)
+		if err != nil {
+			return err
+		}
+		b.Contents = append(b.Contents, c)
+	}
+	return nil
+}
+
+func (b *Box) validate(path string) error {
+	switch b.Layout {
+	case Vertical, Horizontal, Baseline:
+	default:
+		return fmt.Errorf("flex: %s: unknown box layout %q", path, b.Layout)
+	}
+	for i, c := range b.Contents {
+		if c == nil {
+			return fmt.Errorf("flex: %s.contents[%d] is nil", path, i)
+		}
+		if err := c.validate(fmt.Sprintf("%s.contents[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	return b.Action.validate(path + ".action")
+}
+
+// Text is a text component, styled spans could be set in Contents instead of Text.
+type Text struct {
+	Text       string  `json:"text,omitempty"`
+	Contents   []*Span `json:"contents,omitempty"`
+	Flex       *int    `json:"flex,omitempty"`
+	Size       string  `json:"size,omitempty"`
+	Weight     string  `json:"weight,omitempty"`
+	Color      string  `json:"color,omitempty"`
+	Align      string  `json:"align,omitempty"`
+	Gravity    string  `json:"gravity,omitempty"`
+	Margin     string  `json:"margin,omitempty"`
+	Wrap       bool    `json:"wrap,omitempty"`
+	MaxLines   int     `json:"maxLines,omitempty"`
+	Decoration string  `json:"decoration,omitempty"`
+	Action     *Action `json:"action,omitempty"`
+}
+
+// NewText creates a text component.
+func NewText(text string) *Text {
+	return &Text{Text: text}
+}
+
+// Bold makes the text bold.
+func (t *Text) Bold() *Text {
+	t.Weight = "bold"
+	return t
+}
+
+// Wrapped wraps the text instead of truncating it.
+func (t *Text) Wrapped() *Text {
+	t.Wrap = true
+	return t
+}
+
+// WithSize sets the font size, e.g. "sm" or "xl".
+func (t *Text) WithSize(size string) *Text {
+	t.Size = size
+	return t
+}
+
+// WithColor sets the font color, e.g. "#aaaaaa".
+func (t *Text) WithColor(color string) *Text {
+	t.Color = color
+	return t
+}
+
+// WithFlex sets the ratio of the width or height in its box.
+func (t *Text) WithFlex(flex int) *Text {
+	t.Flex = &flex
+	return t
+}
+
+// WithAction sets the action when the text is tapped.
+func (t *Text) WithAction(a *Action) *Text {
+	t.Action = a
+	return t
+}
+
+func (*Text) ComponentType() string { return "text" }
+
+func (t *Text) MarshalJSON() ([]byte, error) {
+	type alias Text
+	return json.Marshal(&struct {
+		Type string `json:"type"`
+		*alias
+	}{"text", (*alias)(t)})
+}
+
+func (t *Text) validate(path string) error {
+	if t.Text == "" && len(t.Contents) == 0 {
+		return fmt.Errorf("flex: %s: text is required", path)
+	}
+	return t.Action.validate(path + ".action")
+}
+
+// Span is a styled part of a [Text].
+type Span struct {
+	Text       string `json:"text"`
+	Size       string `json:"size,omitempty"`
+	Weight     string `json:"weight,omitempty"`
+	Color      string `json:"color,omitempty"`
+	Decoration string `json:"decoration,omitempty"`
+}
+
+func (s *Span) MarshalJSON() ([]byte, error) {
+	type alias Span
+	return json.Marshal(&struct {
+		Type string `json:"type"`
+		*alias
+	}{"span", (*alias)(s)})
+}
+
+// Image is an image component, the url must be https.
+type Image struct {
+	URL             string  `json:"url"`
+	Flex            *int    `json:"flex,omitempty"`
+	Size            string  `json:"size,omitempty"`
+	AspectRatio     string  `json:"aspectRatio,omitempty"`
+	AspectMode      string  `json:"aspectMode,omitempty"`
+	Align           string  `json:"align,omitempty"`
+	Gravity         string  `json:"gravity,omitempty"`
+	Margin          string  `json:"margin,omitempty"`
+	BackgroundColor string  `json:"backgroundColor,omitempty"`
+	Action          *Action `json:"action,omitempty"`
+}
+
+// NewImage creates an image component.
+func NewImage(url string) *Image {
+	return &Image{URL: url}
+}
+
+// Cover makes the image fill its area, e.g. as the hero of a bubble with @ratio "20:13".
+func (i *Image) Cover(ratio string) *Image {
+	i.Size, i.AspectRatio, i.AspectMode = "full", ratio, "cover"
+	return i
+}
+
+func (*Image) ComponentType() string { return "image" }
+
+func (i *Image) MarshalJSON() ([]byte, error) {
+	type alias Image
+	return json.Marshal(&struct {
+		Type string `json:"type"`
+		*alias
+	}{"image", (*alias)(i)})
+}
+
+func (i *Image) validate(path string) error {
+	if !strings.HasPrefix(i.URL, "https://") {
+		return fmt.Errorf("flex: %s: image url should be https, got %q", path, i.URL)
+	}
+	return i.Action.validate(path + ".action")
+}
+
+// Icon is an icon component which could only be put in a baseline box.
+type Icon struct {
+	URL         string `json:"url"`
+	Size        string `json:"size,omitempty"`
+	AspectRatio string `json:"aspectRatio,omitempty"`
+	Margin      string `json:"margin,omitempty"`
+}
+
+func (*Icon) ComponentType() string { return "icon" }
+
+func (i *Icon) MarshalJSON() ([]byte, error) {
+	type alias Icon
+	return json.Marshal(&struct {
+		Type string `json:"type"`
+		*alias
+	}{"icon", (*alias)(i)})
+}
+
+func (i *Icon) validate(path string) error {
+	if !strings.HasPrefix(i.URL, "https://") {
+		return fmt.Errorf("flex: %s: icon url should be https, got %q", path, i.URL)
+	}
+	return nil
+}
+
+// Button is a button component which triggers its action.
+type Button struct {
+	Action  *Action `json:"action"`
+	Flex    *int    `json:"flex,omitempty"`
+	Style   string  `json:"style,omitempty"`
+	Color   string  `json:"color,omitempty"`
+	Height  string  `json:"height,omitempty"`
+	Gravity string  `json:"gravity,omitempty"`
+	Margin  string  `json:"margin,omitempty"`
+}
+
+// NewButton creates a button with @action.
+func NewButton(action *Action) *Button {
+	return &Button{Action: action}
+}
+
+// Primary styles the button as primary.
+func (b *Button) Primary() *Button {
+	b.Style = "primary"
+	return b
+}
+
+// Secondary styles the button as secondary.
+func (b *Button) Secondary() *Button {
+	b.Style = "secondary"
+	return b
+}
+
+// Link styles the button as a link.
+func (b *Button) Link() *Button {
+	b.Style = "link"
+	return b
+}
+
+func (*Button) ComponentType() string { return "button" }
+
+func (b *Button) MarshalJSON() ([]byte, error) {
+	type alias Button
+	return json.Marshal(&struct {
+		Type string `json:"type"`
+		*alias
+	}{"button", (*alias)(b)})
+}
+
+func (b *Button) validate(path string) error {
+	if b.Action == nil {
+		return fmt.Errorf("flex: %s: button requires an action", path)
+	}
+	return b.Action.validate(path + ".action")
+}
+
+// Separator draws a line between components.
+type Separator struct {
+	Margin string `json:"margin,omitempty"`
+	Color  string `json:"color,omitempty"`
+}
+
+// NewSeparator creates a separator.
+func NewSeparator() *Separator {
+	return &Separator{}
+}
+
+func (*Separator) ComponentType() string { return "separator" }
+
+func (s *Separator) MarshalJSON() ([]byte, error) {
+	type alias Separator
+	return json.Marshal(&struct {
+		Type string `json:"type"`
+		*alias
+	}{"separator", (*alias)(s)})
+}
+
+func (*Separator) validate(string) error { return nil }
+
+// unmarshalComponent decodes a component by its type.
+func unmarshalComponent(data []byte) (Component, error) {
+	head := &struct {
+		Type string `json:"type"`
+	}{}
+	if err := json.Unmarshal(data, head); err != nil {
+		return nil, err
+	}
+	var c Component
+	switch head.Type {
+	case "box":
+		c = &Box{}
+	case "text":
+		c = &Text{}
+	case "image":
+		c = &Image{}
+	case "icon":
+		c = &Icon{}
+	case "button":
+		c = &Button{}
+	case "separator":
+		c = &Separator{}
+	default:
+		return nil, fmt.Errorf("flex: unknown component type %q", head.Type)
+	}
+	return c, json.Unmarshal(data, c)
+}
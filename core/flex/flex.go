@@ -0,0 +1,229 @@
+/*
+	flex.go
+	Purpose: Flex Message containers and their size limits.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+// Package flex builds LINE Flex Messages with typed components.
+//
+//   - https://developers.line.biz/en/reference/messaging-api/#flex-message
+//
+// It only depends on the standard library, so that message packs could render flex templates with it.
+package flex
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Limits of a flex message.
+const (
+	MaxAltText         = 400       // max characters of the alt text
+	MaxBubbleSize      = 30 * 1024 // max bytes of a bubble in json
+	MaxCarouselSize    = 50 * 1024 // max bytes of a carousel in json
+	MaxCarouselBubbles = 12        // max bubbles in a carousel
+)
+
+// Message is a flex message, it implements the message interface of app/core/line.
+type Message struct {
+	AltText  string
+	Contents Container
+}
+
+// NewMessage creates a flex message with @altText shown in notifications and chat lists.
+func NewMessage(altText string, contents Container) *Message {
+	return &Message{AltText: altText, Contents: contents}
+}
+
+func (m *Message) MessageType() string { return "flex" }
+
+func (m *Message) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Type     string    `json:"type"`
+		AltText  string    `json:"altText"`
+		Contents Container `json:"contents"`
+	}{"flex", m.AltText, m.Contents})
+}
+
+// Validate checks the message against the required fields and the size limits.
+func (m *Message) Validate() error {
+	n := utf8.RuneCountInString(m.AltText)
+	if n == 0 || n > MaxAltText {
+		return fmt.Errorf("flex: altText should have 1 to %d characters, got %d", MaxAltText, n)
+	}
+	return Validate(m.Contents)
+}
+
+// Container is either a [Bubble] or a [Carousel].
+type Container interface {
+	ContainerType() string
+}
+
+// Bubble is a single message bubble.
+type Bubble struct {
+	Size      string        `json:"size,omitempty"`
+	Direction string        `json:"direction,omitempty"`
+	Header    *Box          `json:"header,omitempty"`
+	Hero      Component     `json:"hero,omitempty"`
+	Body      *Box          `json:"body,omitempty"`
+	Footer    *Box          `json:"footer,omitempty"`
+	Styles    *BubbleStyles `json:"styles,omitempty"`
+	Action    *Action       `json:"action,omitempty"`
+}
+
+// NewBubble creates a bubble with @body.
+func NewBubble(body *Box) *Bubble {
+	return &Bubble{Body: body}
+}
+
+func (*Bubble) ContainerType() string { return "bubble" }
+
+func (b *Bubble) MarshalJSON() ([]byte, error) {
+	type alias Bubble
+	return json.Marshal(&struct {
+		Type string `json:"type"`
+		*alias
+	}{"bubble", (*alias)(b)})
+}
+
+func (b *Bubble) UnmarshalJSON(data []byte) error {
+	type alias Bubble
+	v := &struct {
+		Hero json.RawMessage `json:"hero"`
+		*alias
+	}{alias: (*alias)(b)}
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	if len(v.Hero) > 0 && string(v.Hero) != "null" {
+		hero, err := unmarshalComponent(v.Hero)
+		if err != nil {
+			return err
+		}
+		b.Hero = hero
+	}
+	return nil
+}
+
+// BubbleStyles styles the blocks of a bubble.
+type BubbleStyles struct {
+	Header *BlockStyle `json:"header,omitempty"`
+	Hero   *BlockStyle `json:"hero,omitempty"`
+	Body   *BlockStyle `json:"body,omitempty"`
+	Footer *BlockStyle `json:"footer,omitempty"`
+}
+
+// BlockStyle styles a block of a bubble.
+type BlockStyle struct {
+	BackgroundColor string `json:"backgroundColor,omitempty"`
+	Separator       bool   `json:"separator,omitempty"`
+	SeparatorColor  string `json:"separatorColor,omitempty"`
+}
+
+// Carousel is a horizontally scrollable list of bubbles.
+type Carousel struct {
+	Contents []*Bubble `json:"contents"`
+}
+
+// NewCarousel creates a carousel of @bubbles.
+func NewCarousel(bubbles ...*Bubble) *Carousel {
+	return &Carousel{Contents: bubbles}
+}
+
+func (*Carousel) ContainerType() string { return "carousel" }
+
+func (c *Carousel) MarshalJSON() ([]byte, error) {
+	type alias Carousel
+	return json.Marshal(&struct {
+		Type string `json:"type"`
+		*alias
+	}{"carousel", (*alias)(c)})
+}
+
+// Unmarshal decodes a container from json, which is usually rendered from a template.
+func Unmarshal(data []byte) (Container, error) {
+	head := &struct {
+		Type string `json:"type"`
+	}{}
+	if err := json.Unmarshal(data, head); err != nil {
+		return nil, err
+	}
+	var c Container
+	switch head.Type {
+	case "bubble":
+		c = &Bubble{}
+	case "carousel":
+		c = &Carousel{}
+	default:
+		return nil, fmt.Errorf("flex: unknown container type %q", head.Type)
+	}
+	return c, json.Unmarshal(data, c)
+}
+
+// Validate checks @c against the required fields and the size limits.
+func Validate(c Container) error {
+	switch c := c.(type) {
+	case *Bubble:
+		return validateBubble(c, "bubble")
+	case *Carousel:
+		if c == nil || len(c.Contents) == 0 || len(c.Contents) > MaxCarouselBubbles {
+			n := 0
+			if c != nil {
+				n = len(c.Contents)
+			}
+			return fmt.Errorf("flex: carousel should have 1 to %d bubbles, got %d", MaxCarouselBubbles, n)
+		}
+		for i, b := range c.Contents {
+			if err := validateBubble(b, fmt.Sprintf("carousel.contents[%d]", i)); err != nil {
+				return err
+			}
+		}
+		return checkSize(c, MaxCarouselSize, "carousel")
+	default:
+		return fmt.Errorf("flex: contents is required")
+	}
+}
+
+func validateBubble(b *Bubble, path string) error {
+	if b == nil {
+		return fmt.Errorf("flex: %s is nil", path)
+	}
+	blocks := []struct {
+		name string
+		box  *Box
+	}{{"header", b.Header}, {"body", b.Body}, {"footer", b.Footer}}
+	for _, block := range blocks {
+		if block.box != nil {
+			if err := block.box.validate(path + "." + block.name); err != nil {
+				return err
+			}
+		}
+	}
+	if b.Hero != nil {
+		if err := b.Hero.validate(path + ".hero"); err != nil {
+			return err
+		}
+	}
+	if err := b.Action.validate(path + ".action"); err != nil {
+		return err
+	}
+	return checkSize(b, MaxBubbleSize, path)
+}
+
+func checkSize(v any, limit int, path string) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("flex: %s: %w", path, err)
+	}
+	if len(data) > limit {
+		return fmt.Errorf("flex: %s is %d bytes, exceeds the limit of %d bytes", path, len(data), limit)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+/*
+	commands.go
+	Purpose: Chat commands to view and change the settings of a group.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package group
+
+import (
+	"strings"
+
+	"app/core/auth"
+	"app/core/command"
+	"app/core/msg"
+)
+
+func init() {
+	command.Register(
+		&command.Command{
+			Name:    "group",
+			Aliases: []string{"群組"},
+			Desc:    "group.desc",
+			Handler: info,
+		},
+		&command.Command{
+			Name:    "group locale",
+			Args:    []command.Arg{{Name: "locale", Required: true}},
+			Desc:    "group.locale.desc",
+			Handler: setLocale,
+		},
+		&command.Command{
+			Name:    "group skill",
+			Args:    []command.Arg{{Name: "skill", Required: true}, {Name: "on|off", Required: true}},
+			Desc:    "group.skill.desc",
+			Handler: setSkill,
+		},
+		&command.Command{
+			Name:    "group quiet",
+			Args:    []command.Arg{{Name: "HH:MM-HH:MM|off", Required: true}},
+			Desc:    "group.quiet.desc",
+			Handler: setQuiet,
+		},
+		&command.Command{
+			Name:    "group admin",
+			Args:    []command.Arg{{Name: "add|del", Required: true}, {Name: "@members", Required: true, Rest: true}},
+			Desc:    "group.admin.desc",
+			Handler: setAdmin,
+		},
+	)
+}
+
+func info(c *command.Context) error {
+	if !c.InGroup() {
+		return c.ReplyText("group.only", nil)
+	}
+	g, err := Get(c, c.Chat.ID)
+	if err != nil {
+		return err
+	}
+	skills := []map[string]any{}
+	for _, s := range command.Skills() {
+		skills = append(skills, map[string]any{"Name": s, "Enabled": c.Chat.Enabled(s)})
+	}
+	locale := g.Locale
+	if locale == "" {
+		locale = c.Locale
+	}
+	return c.ReplyText("group.info", map[string]any{
+		"Name":    g.Name,
+		"Locale":  locale,
+		"Quiet":   g.Quiet.String(),
+		"Skills":  skills,
+		"Admins":  len(g.Admins),
+		"Members": g.MemberCount,
+	})
+}
+
+// allowed checks the message is sent in a group by someone who could change its settings,
+// the reason is replied if not.
+func allowed(c *command.Context) (bool, error) {
+	if !c.InGroup() {
+		return false, c.ReplyText("group.only", nil)
+	}
+	if !c.Chat.Admin && (c.User == nil || c.User.Group != auth.ADMIN) {
+		return false, c.ReplyText("group.forbidden", nil)
+	}
+	return true, nil
+}
+
+// apply saves the settings changed by @change and replies the result.
+func apply(c *command.Context, change func(s *Settings)) error {
+	if ok, err := allowed(c); !ok {
+		return err
+	}
+	g, err := Get(c, c.Chat.ID)
+	if err != nil {
+		return err
+	}
+	change(&g.Settings)
+	if err := Update(c, g.ID, &g.Settings, nil); err != nil {
+		return err
+	}
+	return c.ReplyText("group.updated", nil)
+}
+
+func setLocale(c *command.Context) error {
+	locale := strings.ToLower(c.Args.Get("locale"))
+	if !msg.HasLocale(locale) {
+		return c.ReplyText("group.locale.unknown", msg.Plain(locale))
+	}
+	return apply(c, func(s *Settings) {
+		s.Locale = locale
+		// replied in the new locale
+		c.Locale = locale
+	})
+}
+
+func setSkill(c *command.Context) error {
+	skill, state := strings.ToLower(c.Args.Get("skill")), strings.ToLower(c.Args.Get("on|off"))
+	if state != "on" && state != "off" {
+		return c.ReplyText("command.usage", msg.Plain(c.Command.Usage()))
+	}
+	known := false
+	for _, s := range command.Skills() {
+		known = known || s == skill
+	}
+	if !known {
+		return c.ReplyText("group.skill.unknown", msg.Plain(skill))
+	}
+	return apply(c, func(s *Settings) {
+		disabled := []string{}
+		for _, d := range s.Disabled {
+			if d != skill {
+				disabled = append(disabled, d)
+			}
+		}
+		if state == "off" {
+			disabled = append(disabled, skill)
+		}
+		s.Disabled = disabled
+	})
+}
+
+func setQuiet(c *command.Context) error {
+	q, err := ParseQuietHours(c.Args.Get("HH:MM-HH:MM|off"))
+	if err != nil {
+		return c.ReplyText("command.usage", msg.Plain(c.Command.Usage()))
+	}
+	return apply(c, func(s *Settings) {
+		s.Quiet = q
+	})
+}
+
+func setAdmin(c *command.Context) error {
+	action := strings.ToLower(c.Args.Get("add|del"))
+	var mentioned []string
+	if m := c.Event.Message; m != nil && m.Mention != nil {
+		for _, u := range m.Mention.Mentionees {
+			if u.UserID != "" {
+				mentioned = append(mentioned, u.UserID)
+			}
+		}
+	}
+	if (action != "add" && action != "del") || len(mentioned) == 0 {
+		return c.ReplyText("command.usage", msg.Plain(c.Command.Usage()))
+	}
+	if ok, err := allowed(c); !ok {
+		return err
+	}
+	for _, user := range mentioned {
+		if err := SetAdmin(c, c.Chat.ID, user, action == "add"); err != nil {
+			return err
+		}
+	}
+	return c.ReplyText("group.updated", nil)
+}
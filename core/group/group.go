@@ -0,0 +1,188 @@
+/*
+	group.go
+	Purpose: Track the groups and rooms the bot is in, and apply their settings.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Leave the admins of a new chat to the global admins
+*/
+
+// Package group tracks the group and room chats the bot is in with their members and settings.
+//
+// Chats are recorded from join and leave events, and from messages of chats joined before they were tracked.
+// Each chat has its own locale, disabled skills, admin members and quiet hours,
+// which are applied to commands through [Resolver] and to pushes through [Hold].
+package group
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"app/core/command"
+	"app/core/dedup"
+	"app/core/line"
+	"app/core/msg"
+	"app/core/property"
+	"app/core/util"
+
+	"golang.org/x/exp/slog"
+)
+
+// Service is the [service.Service] which records membership from the webhook events.
+var Service = &tracker{}
+
+type tracker struct {
+	closers []func()
+}
+
+func (t *tracker) Init() error {
+	t.closers = []func(){
+		dedup.Handle("group.join", line.TopicJoin, dedup.AtLeastOnce, onJoin),
+		dedup.Handle("group.leave", line.TopicLeave, dedup.AtLeastOnce, onLeave),
+		dedup.Handle("group.memberJoined", line.TopicMemberJoined, dedup.AtLeastOnce, onMemberJoined),
+		dedup.Handle("group.memberLeft", line.TopicMemberLeft, dedup.AtLeastOnce, onMemberLeft),
+	}
+	return nil
+}
+
+func (t *tracker) Load() {}
+
+func (t *tracker) Del() {
+	for _, close := range t.closers {
+		close()
+	}
+	t.closers = nil
+}
+
+// chat returns the group or room ID and type of @src, ok is false for one-on-one chats.
+func chat(src *line.Source) (id, typ string, ok bool) {
+	if src == nil || src.Type == line.SourceUser {
+		return "", "", false
+	}
+	return src.ID(), src.Type, true
+}
+
+func onJoin(ctx context.Context, evt *line.Event) error {
+	id, typ, ok := chat(evt.Source)
+	if !ok {
+		return nil
+	}
+	name := ""
+	if typ == line.SourceGroup {
+		if p, err := line.GroupSummary(ctx, id); err != nil {
+			slog.Warn("get group summary failed", util.ErrAtrr(err), slog.String("mod", "group"), slog.String("act", "join"), slog.String("group", id))
+		} else {
+			name = p.GroupName
+		}
+	}
+	if err := join(ctx, id, typ, name); err != nil {
+		return err
+	}
+	slog.Info("group joined", slog.String("mod", "group"), slog.String("act", "join"), slog.String("group", id), slog.String("type", typ))
+
+	if evt.ReplyToken != "" {
+		text := msg.T("group.welcome", Locale(ctx, id), nil)
+		if err := line.Reply(ctx, evt.ReplyToken, line.NewText(text)); err != nil {
+			slog.Warn("reply welcome failed", util.ErrAtrr(err), slog.String("mod", "group"), slog.String("act", "join"), slog.String("group", id))
+		}
+	}
+	return nil
+}
+
+func onLeave(ctx context.Context, evt *line.Event) error {
+	id, _, ok := chat(evt.Source)
+	if !ok {
+		return nil
+	}
+	if err := leave(ctx, id); err != nil {
+		return err
+	}
+	slog.Info("group left", slog.String("mod", "group"), slog.String("act", "leave"), slog.String("group", id))
+	return nil
+}
+
+func onMemberJoined(ctx context.Context, evt *line.Event) error {
+	id, typ, ok := chat(evt.Source)
+	if !ok || evt.Joined == nil {
+		return nil
+	}
+	if err := ensure(ctx, id, typ, ""); err != nil {
+		return err
+	}
+	return addMembers(ctx, id, users(evt.Joined.Members)...)
+}
+
+func onMemberLeft(ctx context.Context, evt *line.Event) error {
+	id, _, ok := chat(evt.Source)
+	if !ok || evt.Left == nil {
+		return nil
+	}
+	return removeMembers(ctx, id, users(evt.Left.Members)...)
+}
+
+func users(members []*line.Source) []string {
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		if m.UserID != "" {
+			ids = append(ids, m.UserID)
+		}
+	}
+	return ids
+}
+
+// Resolver resolves the group or room of an event to a [command.Chat], it could be set to the command router.
+//
+// The chat and the sender are recorded if not yet, and the sender is an admin of the chat only if marked as admin.
+// A chat with no admins yet is set up by the global admins, as the join event does not tell who invited the bot.
+func Resolver(ctx context.Context, src *line.Source) *command.Chat {
+	id, typ, ok := chat(src)
+	if !ok {
+		return nil
+	}
+	c := &command.Chat{ID: id, Type: typ}
+	fail := func(err error) *command.Chat {
+		slog.Error("resolve group failed", util.ErrAtrr(err),
+			slog.String("mod", "group"), slog.String("act", "resolve"), slog.String("group", id))
+		return c
+	}
+	if err := ensure(ctx, id, typ, src.UserID); err != nil {
+		return fail(err)
+	}
+	g, err := Get(ctx, id)
+	if err != nil {
+		return fail(err)
+	}
+	c.Locale, c.Disabled = g.Locale, g.Disabled
+	for _, admin := range g.Admins {
+		if admin == src.UserID {
+			c.Admin = true
+		}
+	}
+	return c
+}
+
+// Hold holds pushes to groups in their quiet hours, it could be set to the outbox.
+func Hold(ctx context.Context, to string, now time.Time) time.Time {
+	// group IDs start with C and room IDs start with R, while user IDs start with U
+	if !strings.HasPrefix(to, "C") && !strings.HasPrefix(to, "R") {
+		return time.Time{}
+	}
+	g, err := Get(ctx, to)
+	if err != nil {
+		return time.Time{}
+	}
+	return g.Quiet.Until(now)
+}
+
+// Locale returns the locale of the chat @id, or the default locale if not set or not a group.
+func Locale(ctx context.Context, id string) string {
+	if g, err := Get(ctx, id); err == nil && g.Locale != "" {
+		return g.Locale
+	}
+	return property.DefaultLocale
+}
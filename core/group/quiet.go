@@ -0,0 +1,87 @@
+/*
+	quiet.go
+	Purpose: Daily quiet hours of a group, in which pushes are held.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package group
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"app/core/errors"
+)
+
+// QuietHours is a daily range in minutes of the day in local time, it could cross midnight, e.g. 22:00-07:00.
+// The zero value means no quiet hours.
+type QuietHours struct {
+	Start, End int
+	Set        bool
+}
+
+// ParseQuietHours parses "HH:MM-HH:MM", empty string or "off" means no quiet hours.
+func ParseQuietHours(s string) (QuietHours, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.EqualFold(s, "off") {
+		return QuietHours{}, nil
+	}
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		return QuietHours{}, errors.ErrBadRequest.SetInfo(s)
+	}
+	start, err := clock(from)
+	if err != nil {
+		return QuietHours{}, errors.ErrBadRequest.SetInfo(s)
+	}
+	end, err := clock(to)
+	if err != nil || start == end {
+		return QuietHours{}, errors.ErrBadRequest.SetInfo(s)
+	}
+	return QuietHours{Start: start, End: end, Set: true}, nil
+}
+
+// clock parses "HH:MM" into minutes of the day.
+func clock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func (q QuietHours) String() string {
+	if !q.Set {
+		return ""
+	}
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", q.Start/60, q.Start%60, q.End/60, q.End%60)
+}
+
+// Until returns when the quiet hours containing @now end, zero time if @now is not in quiet hours.
+func (q QuietHours) Until(now time.Time) time.Time {
+	if !q.Set {
+		return time.Time{}
+	}
+	min := now.Hour()*60 + now.Minute()
+	in := false
+	if q.Start < q.End {
+		in = min >= q.Start && min < q.End
+	} else {
+		in = min >= q.Start || min < q.End
+	}
+	if !in {
+		return time.Time{}
+	}
+	end := time.Date(now.Year(), now.Month(), now.Day(), q.End/60, q.End%60, 0, 0, now.Location())
+	if !end.After(now) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
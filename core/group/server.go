@@ -0,0 +1,146 @@
+/*
+	server.go
+	Purpose: gRPC service for admins to list and edit groups.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package group
+
+import (
+	"context"
+
+	"app/core/auth"
+	"app/core/command"
+	"app/core/errors"
+	"app/core/msg"
+	pb "app/service"
+
+	"golang.org/x/exp/slog"
+)
+
+func init() {
+	auth.Guard(auth.ADMIN,
+		pb.GroupService_ListGroups_FullMethodName,
+		pb.GroupService_GetGroup_FullMethodName,
+		pb.GroupService_UpdateGroup_FullMethodName,
+	)
+}
+
+// defaultPageSize is the page size if not given by the pager.
+const defaultPageSize = 20
+
+// Server is the implementation of [pb.GroupServiceServer].
+var Server = &server{}
+
+type server struct {
+	pb.UnimplementedGroupServiceServer
+}
+
+func (*server) ListGroups(ctx context.Context, req *pb.ListGroupsRequest) (*pb.ListGroupsResponse, error) {
+	size, page := int32(defaultPageSize), int32(1)
+	if p := req.GetPager(); p != nil {
+		if p.Size > 0 {
+			size = p.Size
+		}
+		if p.Page > 0 {
+			page = p.Page
+		}
+	}
+	list, total, err := List(ctx, req.GetStatus(), int((page-1)*size), int(size))
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.ListGroupsResponse{
+		Groups: make([]*pb.Group, len(list)),
+		Pager:  &pb.PagerResult{Size: size, Page: page, Total: int32(total)},
+	}
+	for i, g := range list {
+		res.Groups[i] = toPB(g)
+	}
+	return res, nil
+}
+
+func (*server) GetGroup(ctx context.Context, req *pb.GetGroupRequest) (*pb.Group, error) {
+	return get(ctx, req.GetId())
+}
+
+func (*server) UpdateGroup(ctx context.Context, req *pb.UpdateGroupRequest) (*pb.Group, error) {
+	in := req.GetSettings()
+	if in == nil {
+		return nil, errors.ErrBadRequest.SetInfo("settings")
+	}
+	if in.Locale != "" && !msg.HasLocale(in.Locale) {
+		return nil, errors.ErrBadRequest.SetInfo("locale: " + in.Locale)
+	}
+	skills := map[string]bool{}
+	for _, s := range command.Skills() {
+		skills[s] = true
+	}
+	for _, s := range in.DisabledSkills {
+		if !skills[s] {
+			return nil, errors.ErrBadRequest.SetInfo("skill: " + s)
+		}
+	}
+	quiet, err := ParseQuietHours(in.QuietHours)
+	if err != nil {
+		return nil, err
+	}
+	admins := in.Admins
+	if admins == nil {
+		admins = []string{}
+	}
+	s := &Settings{Locale: in.Locale, Disabled: in.DisabledSkills, Quiet: quiet}
+	if err := Update(ctx, req.GetId(), s, admins); err != nil {
+		return nil, err
+	}
+
+	usr, _ := auth.GetUser(ctx)
+	args := []any{slog.String("mod", "group"), slog.String("act", "update"), slog.String("group", req.GetId())}
+	if usr != nil {
+		args = append(args, slog.String("usr", usr.Username))
+	}
+	slog.Info("group updated", args...)
+	return get(ctx, req.GetId())
+}
+
+// get gets the group @id with its members.
+func get(ctx context.Context, id string) (*pb.Group, error) {
+	g, err := Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	members, err := Members(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	res := toPB(g)
+	res.Members = make([]*pb.GroupMember, len(members))
+	for i, m := range members {
+		res.Members[i] = &pb.GroupMember{UserId: m.UserID, Admin: m.Admin, JoinedAt: m.JoinedAt.Unix()}
+	}
+	return res, nil
+}
+
+func toPB(g *Group) *pb.Group {
+	return &pb.Group{
+		Id:     g.ID,
+		Type:   g.Type,
+		Name:   g.Name,
+		Status: g.Status,
+		Settings: &pb.GroupSettings{
+			Locale:         g.Locale,
+			DisabledSkills: g.Disabled,
+			QuietHours:     g.Quiet.String(),
+			Admins:         g.Admins,
+		},
+		MemberCount: int32(g.MemberCount),
+		JoinedAt:    g.JoinedAt.Unix(),
+		UpdatedAt:   g.UpdatedAt.Unix(),
+	}
+}
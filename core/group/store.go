@@ -0,0 +1,334 @@
+/*
+	store.go
+	Purpose: Persist groups, rooms and their members in the database.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Add IsMember
+*/
+
+package group
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"app/core/database"
+	"app/core/errors"
+)
+
+func init() {
+	database.Register(&database.Migration{
+		ID: "20261017_group",
+		Stmts: []string{
+			`CREATE TABLE line_groups (
+				id TEXT PRIMARY KEY,
+				type TEXT NOT NULL,
+				name TEXT NOT NULL DEFAULT '',
+				status TEXT NOT NULL,
+				locale TEXT NOT NULL DEFAULT '',
+				disabled_skills TEXT NOT NULL DEFAULT '',
+				quiet_hours TEXT NOT NULL DEFAULT '',
+				joined_at BIGINT NOT NULL,
+				updated_at BIGINT NOT NULL
+			)`,
+			`CREATE TABLE line_group_members (
+				group_id TEXT NOT NULL,
+				user_id TEXT NOT NULL,
+				admin INTEGER NOT NULL DEFAULT 0,
+				joined_at BIGINT NOT NULL,
+				PRIMARY KEY (group_id, user_id)
+			)`,
+		},
+	})
+}
+
+// Status of a group.
+const (
+	ACTIVE = "active" // the bot is in the chat
+	LEFT   = "left"   // the bot has left or been removed, the settings are kept in case it joins again
+)
+
+// Settings are the per chat settings.
+type Settings struct {
+	// Locale is the locale to reply in, the default locale is used if empty.
+	Locale string
+	// Disabled are the skills whose commands are disabled in the chat.
+	Disabled []string
+	// Quiet is the daily range pushes are held in.
+	Quiet QuietHours
+}
+
+// Group is a group or room chat.
+type Group struct {
+	ID string
+	// Type is either [line.SourceGroup] or [line.SourceRoom].
+	Type   string
+	Name   string
+	Status string
+	Settings
+	// Admins are the user IDs of the admin members.
+	Admins      []string
+	MemberCount int
+	JoinedAt    time.Time
+	UpdatedAt   time.Time
+}
+
+// Member is a member of a group known to the bot,
+// members are learned from member events and messages as LINE does not list members of unverified bots.
+type Member struct {
+	UserID   string
+	Admin    bool
+	JoinedAt time.Time
+}
+
+const columns = "id, type, name, status, locale, disabled_skills, quiet_hours, joined_at, updated_at"
+
+func scan(row interface{ Scan(...any) error }) (*Group, error) {
+	g := &Group{}
+	var disabled, quiet string
+	var joined, updated int64
+	if err := row.Scan(&g.ID, &g.Type, &g.Name, &g.Status, &g.Locale, &disabled, &quiet, &joined, &updated); err != nil {
+		return nil, err
+	}
+	if disabled != "" {
+		g.Disabled = strings.Split(disabled, ",")
+	}
+	g.Quiet, _ = ParseQuietHours(quiet)
+	g.JoinedAt, g.UpdatedAt = time.Unix(joined, 0), time.Unix(updated, 0)
+	return g, nil
+}
+
+// Get gets the group or room @id with its admins and member count.
+func Get(ctx context.Context, id string) (*Group, error) {
+	g, err := scan(database.DB().QueryRowContext(ctx, "SELECT "+columns+" FROM line_groups WHERE id = $1", id))
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	if err := fill(ctx, g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// List lists the groups and rooms with @status, every group if empty, latest joined first, with the total count.
+func List(ctx context.Context, status string, offset, limit int) ([]*Group, int, error) {
+	where, args := "", []any{}
+	if status != "" {
+		where, args = " WHERE status = $1", append(args, status)
+	}
+	var total int
+	if err := database.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM line_groups"+where, args...).Scan(&total); err != nil {
+		return nil, 0, database.Err(err)
+	}
+	n := len(args)
+	rows, err := database.DB().QueryContext(ctx, "SELECT "+columns+" FROM line_groups"+where+
+		" ORDER BY joined_at DESC, id LIMIT $"+strconv.Itoa(n+1)+" OFFSET $"+strconv.Itoa(n+2), append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, database.Err(err)
+	}
+	list := []*Group{}
+	for rows.Next() {
+		g, err := scan(rows)
+		if err != nil {
+			rows.Close()
+			return nil, 0, database.Err(err)
+		}
+		list = append(list, g)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, 0, database.Err(err)
+	}
+	// filled after the rows are closed, as sqlite has a single connection
+	for _, g := range list {
+		if err := fill(ctx, g); err != nil {
+			return nil, 0, err
+		}
+	}
+	return list, total, nil
+}
+
+// fill loads the admins and member count of @g.
+func fill(ctx context.Context, g *Group) error {
+	if err := database.DB().QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM line_group_members WHERE group_id = $1", g.ID).Scan(&g.MemberCount); err != nil {
+		return database.Err(err)
+	}
+	rows, err := database.DB().QueryContext(ctx,
+		"SELECT user_id FROM line_group_members WHERE group_id = $1 AND admin = 1 ORDER BY user_id", g.ID)
+	if err != nil {
+		return database.Err(err)
+	}
+	defer rows.Close()
+	g.Admins = []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return database.Err(err)
+		}
+		g.Admins = append(g.Admins, id)
+	}
+	return database.Err(rows.Err())
+}
+
+// Members lists the known members of the group @id.
+func Members(ctx context.Context, id string) ([]*Member, error) {
+	rows, err := database.DB().QueryContext(ctx,
+		"SELECT user_id, admin, joined_at FROM line_group_members WHERE group_id = $1 ORDER BY joined_at, user_id", id)
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	defer rows.Close()
+	list := []*Member{}
+	for rows.Next() {
+		m := &Member{}
+		var admin int
+		var joined int64
+		if err := rows.Scan(&m.UserID, &admin, &joined); err != nil {
+			return nil, database.Err(err)
+		}
+		m.Admin, m.JoinedAt = admin == 1, time.Unix(joined, 0)
+		list = append(list, m)
+	}
+	return list, database.Err(rows.Err())
+}
+
+// IsMember reports whether @userID is a known member of the group @id.
+func IsMember(ctx context.Context, id, userID string) (bool, error) {
+	var n int
+	err := database.DB().QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM line_group_members WHERE group_id = $1 AND user_id = $2", id, userID).Scan(&n)
+	return n > 0, database.Err(err)
+}
+
+// Update replaces the settings of the group @id, and the admins if @admins is not nil.
+// Admins not known as members yet are added as members.
+func Update(ctx context.Context, id string, s *Settings, admins []string) error {
+	return database.Err(database.Tx(ctx, func(tx *sql.Tx) error {
+		now := time.Now().Unix()
+		res, err := tx.ExecContext(ctx,
+			"UPDATE line_groups SET locale = $1, disabled_skills = $2, quiet_hours = $3, updated_at = $4 WHERE id = $5",
+			s.Locale, strings.Join(s.Disabled, ","), s.Quiet.String(), now, id)
+		if err := affected(res, err); err != nil {
+			return err
+		}
+		if admins == nil {
+			return nil
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE line_group_members SET admin = 0 WHERE group_id = $1", id); err != nil {
+			return err
+		}
+		for _, user := range admins {
+			if err := addMember(ctx, tx, id, user, now); err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx,
+				"UPDATE line_group_members SET admin = 1 WHERE group_id = $1 AND user_id = $2", id, user); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+// SetAdmin sets whether @userID is an admin of the group @id, the user is added as a member if not known yet.
+func SetAdmin(ctx context.Context, id, userID string, admin bool) error {
+	return database.Err(database.Tx(ctx, func(tx *sql.Tx) error {
+		if err := addMember(ctx, tx, id, userID, time.Now().Unix()); err != nil {
+			return err
+		}
+		flag := 0
+		if admin {
+			flag = 1
+		}
+		_, err := tx.ExecContext(ctx,
+			"UPDATE line_group_members SET admin = $1 WHERE group_id = $2 AND user_id = $3", flag, id, userID)
+		return err
+	}))
+}
+
+// join records the bot is in the group @id, the settings of a group joined before are kept.
+func join(ctx context.Context, id, typ, name string) error {
+	now := time.Now().Unix()
+	_, err := database.DB().ExecContext(ctx, `INSERT INTO line_groups (id, type, name, status, joined_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT (id) DO UPDATE SET status = excluded.status, name = CASE WHEN excluded.name = '' THEN line_groups.name ELSE excluded.name END,
+		joined_at = CASE WHEN line_groups.status = $4 THEN line_groups.joined_at ELSE excluded.joined_at END, updated_at = excluded.updated_at`,
+		id, typ, name, ACTIVE, now)
+	return database.Err(err)
+}
+
+// leave records the bot has left the group @id, the members are forgotten as they are not tracked anymore.
+func leave(ctx context.Context, id string) error {
+	return database.Err(database.Tx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "UPDATE line_groups SET status = $1, updated_at = $2 WHERE id = $3",
+			LEFT, time.Now().Unix(), id); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, "DELETE FROM line_group_members WHERE group_id = $1", id)
+		return err
+	}))
+}
+
+// ensure makes sure the group @id and its member @userID are recorded, for chats joined before the bot tracked them.
+func ensure(ctx context.Context, id, typ, userID string) error {
+	now := time.Now().Unix()
+	_, err := database.DB().ExecContext(ctx, `INSERT INTO line_groups (id, type, status, joined_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4) ON CONFLICT (id) DO NOTHING`, id, typ, ACTIVE, now)
+	if err != nil || userID == "" {
+		return database.Err(err)
+	}
+	return database.Err(addMember(ctx, database.DB(), id, userID, now))
+}
+
+// addMembers records @users joined the group @id.
+func addMembers(ctx context.Context, id string, users ...string) error {
+	return database.Err(database.Tx(ctx, func(tx *sql.Tx) error {
+		now := time.Now().Unix()
+		for _, user := range users {
+			if err := addMember(ctx, tx, id, user, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+// removeMembers records @users left the group @id.
+func removeMembers(ctx context.Context, id string, users ...string) error {
+	return database.Err(database.Tx(ctx, func(tx *sql.Tx) error {
+		for _, user := range users {
+			if _, err := tx.ExecContext(ctx,
+				"DELETE FROM line_group_members WHERE group_id = $1 AND user_id = $2", id, user); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+func addMember(ctx context.Context, ex database.Executor, id, userID string, now int64) error {
+	_, err := ex.ExecContext(ctx, `INSERT INTO line_group_members (group_id, user_id, joined_at)
+		VALUES ($1, $2, $3) ON CONFLICT (group_id, user_id) DO NOTHING`, id, userID, now)
+	return err
+}
+
+func affected(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
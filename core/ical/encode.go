@@ -0,0 +1,286 @@
+/*
+	encode.go
+	Purpose: Write calendars as iCalendar files.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// maxLine is the longest content line in octets, longer ones are folded.
+	maxLine = 75
+
+	dateFormat     = "20060102"
+	localFormat    = "20060102T150405"
+	utcFormat      = "20060102T150405Z"
+	defaultProdID  = "-//lineasst//iCalendar//EN"
+	weekdayAbbrevs = "SUMOTUWETHFRSA"
+)
+
+// WriteTo writes the calendar to @w, it implements [io.WriterTo].
+//
+// Times are written in UTC, except for recurring events which are written in the time zone of their start
+// with a VTIMEZONE, so that the occurrences keep their wall clock time across daylight saving changes.
+func (c *Calendar) WriteTo(w io.Writer) (int64, error) {
+	b := &builder{}
+	b.line("BEGIN:VCALENDAR")
+	b.line("VERSION:2.0")
+	prodID := c.ProdID
+	if prodID == "" {
+		prodID = defaultProdID
+	}
+	b.line("PRODID:" + prodID)
+	b.line("CALSCALE:GREGORIAN")
+	b.line("METHOD:PUBLISH")
+	if c.Name != "" {
+		b.line("X-WR-CALNAME:" + escape(c.Name))
+	}
+	if c.Refresh > 0 {
+		b.line("REFRESH-INTERVAL;VALUE=DURATION:" + formatDuration(c.Refresh))
+		b.line("X-PUBLISHED-TTL:" + formatDuration(c.Refresh))
+	}
+
+	// the time zones of recurring events, with the earliest year they are used in
+	years, order := map[string]int{}, []*time.Location{}
+	for _, e := range c.Events {
+		if !e.Recurring() || e.AllDay || e.Start.Location() == time.UTC {
+			continue
+		}
+		loc := e.Start.Location()
+		y, ok := years[loc.String()]
+		if !ok {
+			order = append(order, loc)
+		}
+		if !ok || e.Start.Year() < y {
+			years[loc.String()] = e.Start.Year()
+		}
+	}
+	for _, loc := range order {
+		b.timezone(loc, years[loc.String()])
+	}
+
+	now := time.Now()
+	for _, e := range c.Events {
+		b.event(e, now)
+	}
+	b.line("END:VCALENDAR")
+	n, err := w.Write(b.Bytes())
+	return int64(n), err
+}
+
+// builder builds the content lines of a calendar.
+type builder struct {
+	bytes.Buffer
+}
+
+// line writes a content line, folded at [maxLine] octets without breaking UTF-8 characters.
+func (b *builder) line(s string) {
+	limit := maxLine
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		// the leading space of a continuation line counts
+		limit = maxLine - 1
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+}
+
+// text writes a TEXT property if @v is not empty.
+func (b *builder) text(name, v string) {
+	if v != "" {
+		b.line(name + ":" + escape(v))
+	}
+}
+
+// date writes a DATE or DATE-TIME property, in the time zone of @t if @zoned.
+func (b *builder) date(name string, t time.Time, allDay, zoned bool) {
+	switch {
+	case allDay:
+		b.line(name + ";VALUE=DATE:" + t.Format(dateFormat))
+	case zoned:
+		b.line(name + ";TZID=" + paramValue(t.Location().String()) + ":" + t.Format(localFormat))
+	default:
+		b.line(name + ":" + t.UTC().Format(utcFormat))
+	}
+}
+
+func (b *builder) event(e *Event, now time.Time) {
+	zoned := e.Recurring() && !e.AllDay && e.Start.Location() != time.UTC
+	b.line("BEGIN:VEVENT")
+	b.text("UID", e.UID)
+	stamp := e.Stamp
+	if stamp.IsZero() {
+		stamp = now
+	}
+	b.line("DTSTAMP:" + stamp.UTC().Format(utcFormat))
+	b.date("DTSTART", e.Start, e.AllDay, zoned)
+	switch {
+	case e.AllDay && !e.End.After(e.Start):
+		b.date("DTEND", e.Start.AddDate(0, 0, 1), true, false)
+	case e.End.After(e.Start):
+		b.date("DTEND", e.End.In(e.Start.Location()), e.AllDay, zoned)
+	}
+	b.text("SUMMARY", e.Summary)
+	b.text("DESCRIPTION", e.Description)
+	b.text("LOCATION", e.Location)
+	if e.URL != "" {
+		b.line("URL:" + e.URL)
+	}
+	if e.Status != "" {
+		b.line("STATUS:" + e.Status)
+	}
+	if e.Recurring() {
+		b.line("RRULE:" + e.RRule)
+		for _, t := range e.ExDates {
+			b.date("EXDATE", t.In(e.Start.Location()), e.AllDay, zoned)
+		}
+	}
+	if e.Alarm >= 0 {
+		b.line("BEGIN:VALARM")
+		b.line("ACTION:DISPLAY")
+		b.text("DESCRIPTION", e.Summary)
+		b.line("TRIGGER:" + formatDuration(-e.Alarm))
+		b.line("END:VALARM")
+	}
+	b.line("END:VEVENT")
+}
+
+// timezone writes the VTIMEZONE of @loc with the offset changes of @year as yearly rules.
+func (b *builder) timezone(loc *time.Location, year int) {
+	b.line("BEGIN:VTIMEZONE")
+	b.line("TZID:" + loc.String())
+	start := time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+	end := start.AddDate(1, 0, 0)
+	_, off := start.Zone()
+	changed := false
+	for t := start; t.Before(end); t = t.Add(24 * time.Hour) {
+		next := t.Add(24 * time.Hour)
+		if _, o := next.Zone(); o == off {
+			continue
+		}
+		// the first second of the new offset
+		lo, hi := t, next
+		for hi.Sub(lo) > time.Second {
+			mid := lo.Add(hi.Sub(lo) / 2)
+			if _, o := mid.Zone(); o == off {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		_, to := hi.Zone()
+		b.observance(hi, off, to, true)
+		off, changed = to, true
+	}
+	if !changed {
+		b.observance(start, off, off, false)
+	}
+	b.line("END:VTIMEZONE")
+}
+
+// observance writes a STANDARD or DAYLIGHT block starting at @at changing the offset from @from to @to,
+// which repeats yearly on the same weekday of the month if @yearly.
+func (b *builder) observance(at time.Time, from, to int, yearly bool) {
+	kind := "STANDARD"
+	if at.IsDST() {
+		kind = "DAYLIGHT"
+	}
+	name, _ := at.Zone()
+	// the start is in the wall clock time before the change
+	local := at.In(time.FixedZone("", from))
+	b.line("BEGIN:" + kind)
+	b.line("DTSTART:" + local.Format(localFormat))
+	b.line("TZOFFSETFROM:" + formatOffset(from))
+	b.line("TZOFFSETTO:" + formatOffset(to))
+	if name != "" && !strings.HasPrefix(name, "+") && !strings.HasPrefix(name, "-") {
+		b.line("TZNAME:" + escape(name))
+	}
+	if yearly {
+		nth := (local.Day()-1)/7 + 1
+		if local.AddDate(0, 0, 7).Month() != local.Month() {
+			nth = -1
+		}
+		wd := int(local.Weekday())
+		b.line(fmt.Sprintf("RRULE:FREQ=YEARLY;BYMONTH=%d;BYDAY=%d%s", local.Month(), nth, weekdayAbbrevs[wd*2:wd*2+2]))
+	}
+	b.line("END:" + kind)
+}
+
+// escape escapes a TEXT value.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", "").Replace(s)
+}
+
+// paramValue quotes a parameter value if it has characters not allowed unquoted.
+func paramValue(s string) string {
+	if strings.ContainsAny(s, ";:,") {
+		return `"` + strings.ReplaceAll(s, `"`, "") + `"`
+	}
+	return s
+}
+
+// formatOffset formats a UTC offset in seconds, e.g. "+0800".
+func formatOffset(sec int) string {
+	sign := '+'
+	if sec < 0 {
+		sign, sec = '-', -sec
+	}
+	if s := sec % 60; s != 0 {
+		return fmt.Sprintf("%c%02d%02d%02d", sign, sec/3600, sec/60%60, s)
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, sec/3600, sec/60%60)
+}
+
+// formatDuration formats a DURATION value, e.g. "PT1H30M" or "-PT15M".
+func formatDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	var b strings.Builder
+	b.WriteString(sign + "P")
+	if days := d / (24 * time.Hour); days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+		d -= days * 24 * time.Hour
+	}
+	if d == 0 {
+		if b.Len() == len(sign)+1 {
+			b.WriteString("T0S")
+		}
+		return b.String()
+	}
+	b.WriteString("T")
+	if h := d / time.Hour; h > 0 {
+		fmt.Fprintf(&b, "%dH", h)
+		d -= h * time.Hour
+	}
+	if m := d / time.Minute; m > 0 {
+		fmt.Fprintf(&b, "%dM", m)
+		d -= m * time.Minute
+	}
+	if s := d / time.Second; s > 0 {
+		fmt.Fprintf(&b, "%dS", s)
+	}
+	return b.String()
+}
@@ -0,0 +1,71 @@
+/*
+	ical.go
+	Purpose: Types of iCalendar (RFC 5545) calendars and events.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+// Package ical parses and writes iCalendar (.ics) files, as exported by Google Calendar, Apple Calendar and Outlook.
+//
+// Only VEVENTs are read, with their time zones resolved from the TZID parameter, either an IANA name
+// or the standard offset of the VTIMEZONE of the file. All-day events start at midnight of their
+// location, and floating times are in the default location given to [Parse].
+// Recurrence rules are kept as they are in [Event.RRule] for the caller to expand.
+package ical
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrFormat is returned when the input is not an iCalendar file.
+var ErrFormat = errors.New("ical: not a VCALENDAR")
+
+// Calendar is a VCALENDAR.
+type Calendar struct {
+	// ProdID identifies the product which made the calendar, e.g. "-//lineasst//EN".
+	ProdID string
+	// Name is the display name of the calendar (X-WR-CALNAME).
+	Name string
+	// Refresh is how often subscribers should refresh the calendar, zero if not set.
+	Refresh time.Duration
+	Events  []*Event
+}
+
+// Event is a VEVENT.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Status      string // TENTATIVE, CONFIRMED or CANCELLED
+	// Start is the start of the event, at midnight of its location if AllDay.
+	Start time.Time
+	// End is the exclusive end of the event, equal to Start if the event has no duration.
+	End    time.Time
+	AllDay bool
+	// RRule is the recurrence rule without the "RRULE:" prefix, e.g. "FREQ=WEEKLY;BYDAY=MO,WE".
+	RRule string
+	// ExDates are the excluded occurrences of a recurring event.
+	ExDates []time.Time
+	// Alarm is how long before Start to alert, negative if the event has no alarm.
+	Alarm time.Duration
+	// Stamp is when the event was last modified, [time.Now] is written if zero.
+	Stamp time.Time
+}
+
+// Recurring reports whether @e repeats.
+func (e *Event) Recurring() bool {
+	return e.RRule != ""
+}
+
+// Duration is how long the event lasts.
+func (e *Event) Duration() time.Duration {
+	return e.End.Sub(e.Start)
+}
@@ -0,0 +1,392 @@
+/*
+	parse.go
+	Purpose: Parse iCalendar files into events.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// prop is a content line, e.g. "DTSTART;TZID=Asia/Taipei:20261017T090000".
+type prop struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// component is a BEGIN/END block with its properties and sub components.
+type component struct {
+	Name  string
+	Props []*prop
+	Subs  []*component
+}
+
+// get returns the first property named @name, nil if none.
+func (c *component) get(name string) *prop {
+	for _, p := range c.Props {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// text returns the unescaped value of the first property named @name.
+func (c *component) text(name string) string {
+	if p := c.get(name); p != nil {
+		return unescape(p.Value)
+	}
+	return ""
+}
+
+// Parse parses the calendar from @r, times without a time zone are in @loc.
+//
+// Events that could not be parsed, e.g. without DTSTART, are skipped.
+func Parse(r io.Reader, loc *time.Location) (*Calendar, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	root, err := read(r)
+	if err != nil {
+		return nil, err
+	}
+	tzs := zones(root)
+	cal := &Calendar{ProdID: root.text("PRODID"), Name: root.text("X-WR-CALNAME")}
+	if p := root.get("REFRESH-INTERVAL"); p != nil {
+		cal.Refresh, _ = duration(p.Value)
+	}
+	for _, sub := range root.Subs {
+		if sub.Name != "VEVENT" {
+			continue
+		}
+		if e, err := event(sub, tzs, loc); err == nil {
+			cal.Events = append(cal.Events, e)
+		}
+	}
+	return cal, nil
+}
+
+// read reads the first VCALENDAR of @r.
+func read(r io.Reader) (*component, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	var (
+		lines []string
+		stack []*component
+		root  *component
+	)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		// a line starting with a space or tab continues the previous one
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		p, ok := parseLine(line)
+		if !ok {
+			continue
+		}
+		switch p.Name {
+		case "BEGIN":
+			c := &component{Name: strings.ToUpper(p.Value)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Subs = append(parent.Subs, c)
+			} else if c.Name != "VCALENDAR" {
+				return nil, ErrFormat
+			}
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 {
+				return nil, ErrFormat
+			}
+			c := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				root = c
+			}
+		default:
+			if len(stack) > 0 {
+				c := stack[len(stack)-1]
+				c.Props = append(c.Props, p)
+			}
+		}
+		if root != nil {
+			break
+		}
+	}
+	if root == nil {
+		return nil, ErrFormat
+	}
+	return root, nil
+}
+
+// parseLine parses a content line, the name and parameter names are upper cased.
+func parseLine(line string) (*prop, bool) {
+	p := &prop{Params: map[string]string{}}
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return nil, false
+	}
+	p.Name = strings.ToUpper(line[:i])
+	rest := line[i:]
+	for strings.HasPrefix(rest, ";") {
+		rest = rest[1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq < 0 {
+			return nil, false
+		}
+		name := strings.ToUpper(rest[:eq])
+		rest = rest[eq+1:]
+		var val string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return nil, false
+			}
+			val, rest = rest[1:end+1], rest[end+2:]
+		} else {
+			end := strings.IndexAny(rest, ";:")
+			if end < 0 {
+				return nil, false
+			}
+			val, rest = rest[:end], rest[end:]
+		}
+		p.Params[name] = val
+	}
+	if !strings.HasPrefix(rest, ":") {
+		return nil, false
+	}
+	p.Value = rest[1:]
+	return p, true
+}
+
+// unescape unescapes a TEXT value.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// zones maps the TZIDs of the VTIMEZONEs of @root to fixed zones of their standard offsets,
+// which are used when a TZID is not an IANA name, e.g. "Taipei Standard Time" of Outlook.
+func zones(root *component) map[string]*time.Location {
+	tzs := map[string]*time.Location{}
+	for _, sub := range root.Subs {
+		if sub.Name != "VTIMEZONE" {
+			continue
+		}
+		id := sub.text("TZID")
+		for _, o := range sub.Subs {
+			p := o.get("TZOFFSETTO")
+			if p == nil || (o.Name != "STANDARD" && tzs[id] != nil) {
+				continue
+			}
+			if off, ok := offset(p.Value); ok {
+				tzs[id] = time.FixedZone(id, off)
+			}
+		}
+	}
+	return tzs
+}
+
+// offset parses a UTC offset like "+0800" or "-0430" into seconds.
+func offset(s string) (int, bool) {
+	if len(s) != 5 && len(s) != 7 {
+		return 0, false
+	}
+	sign := 1
+	switch s[0] {
+	case '-':
+		sign = -1
+	case '+':
+	default:
+		return 0, false
+	}
+	h, err1 := strconv.Atoi(s[1:3])
+	m, err2 := strconv.Atoi(s[3:5])
+	sec := 0
+	if len(s) == 7 {
+		sec, _ = strconv.Atoi(s[5:7])
+	}
+	if err1 != nil || err2 != nil {
+		return 0, false
+	}
+	return sign * (h*3600 + m*60 + sec), true
+}
+
+// location resolves the TZID parameter of @p, @def if it has none or it is unknown.
+func location(p *prop, tzs map[string]*time.Location, def *time.Location) *time.Location {
+	id := p.Params["TZID"]
+	if id == "" {
+		return def
+	}
+	if loc, err := time.LoadLocation(strings.TrimPrefix(id, "/")); err == nil {
+		return loc
+	}
+	if loc := tzs[id]; loc != nil {
+		return loc
+	}
+	return def
+}
+
+// parseTime parses a DATE or DATE-TIME value in @loc, unless it is in UTC.
+func parseTime(v string, loc *time.Location) (t time.Time, allDay bool, err error) {
+	switch {
+	case len(v) == 8:
+		t, err = time.ParseInLocation("20060102", v, loc)
+		return t, true, err
+	case strings.HasSuffix(v, "Z"):
+		t, err = time.Parse("20060102T150405Z", v)
+		return t, false, err
+	default:
+		t, err = time.ParseInLocation("20060102T150405", v, loc)
+		return t, false, err
+	}
+}
+
+// times parses the comma separated times of @p, e.g. of EXDATE.
+func times(p *prop, tzs map[string]*time.Location, def *time.Location) []time.Time {
+	loc := location(p, tzs, def)
+	list := []time.Time{}
+	for _, v := range strings.Split(p.Value, ",") {
+		if t, _, err := parseTime(strings.TrimSpace(v), loc); err == nil {
+			list = append(list, t)
+		}
+	}
+	return list
+}
+
+// duration parses a DURATION value, e.g. "PT1H30M", "P1D" or "-PT15M".
+func duration(s string) (time.Duration, error) {
+	orig := s
+	sign := time.Duration(1)
+	if strings.HasPrefix(s, "-") {
+		sign, s = -1, s[1:]
+	}
+	s = strings.TrimPrefix(s, "+")
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return 0, fmt.Errorf("ical: invalid duration %q", orig)
+	}
+	var d time.Duration
+	num, inTime := 0, false
+	for _, r := range s[1:] {
+		switch {
+		case r >= '0' && r <= '9':
+			num = num*10 + int(r-'0')
+			continue
+		case r == 'T':
+			inTime = true
+		case r == 'W' && !inTime:
+			d += time.Duration(num) * 7 * 24 * time.Hour
+		case r == 'D' && !inTime:
+			d += time.Duration(num) * 24 * time.Hour
+		case r == 'H' && inTime:
+			d += time.Duration(num) * time.Hour
+		case r == 'M' && inTime:
+			d += time.Duration(num) * time.Minute
+		case r == 'S' && inTime:
+			d += time.Duration(num) * time.Second
+		default:
+			return 0, fmt.Errorf("ical: invalid duration %q", orig)
+		}
+		num = 0
+	}
+	return sign * d, nil
+}
+
+// event builds the event of a VEVENT.
+func event(c *component, tzs map[string]*time.Location, def *time.Location) (*Event, error) {
+	e := &Event{
+		UID:         c.text("UID"),
+		Summary:     c.text("SUMMARY"),
+		Description: c.text("DESCRIPTION"),
+		Location:    c.text("LOCATION"),
+		URL:         c.text("URL"),
+		Status:      strings.ToUpper(c.text("STATUS")),
+		RRule:       strings.TrimPrefix(c.text("RRULE"), "RRULE:"),
+		Alarm:       -1,
+	}
+	p := c.get("DTSTART")
+	if p == nil {
+		return nil, fmt.Errorf("ical: event %q without DTSTART", e.UID)
+	}
+	var err error
+	if e.Start, e.AllDay, err = parseTime(p.Value, location(p, tzs, def)); err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(p.Params["VALUE"], "DATE") {
+		e.AllDay = true
+	}
+
+	e.End = e.Start
+	if p := c.get("DTEND"); p != nil {
+		if end, _, err := parseTime(p.Value, location(p, tzs, def)); err == nil && !end.Before(e.Start) {
+			e.End = end
+		}
+	} else if p := c.get("DURATION"); p != nil {
+		if d, err := duration(p.Value); err == nil && d > 0 {
+			e.End = e.Start.Add(d)
+		}
+	} else if e.AllDay {
+		e.End = e.Start.AddDate(0, 0, 1)
+	}
+
+	for _, p := range c.Props {
+		if p.Name == "EXDATE" {
+			e.ExDates = append(e.ExDates, times(p, tzs, def)...)
+		}
+	}
+	if p := c.get("DTSTAMP"); p != nil {
+		e.Stamp, _, _ = parseTime(p.Value, time.UTC)
+	}
+	for _, sub := range c.Subs {
+		if sub.Name != "VALARM" {
+			continue
+		}
+		// only alarms relative to the start are supported, e.g. TRIGGER:-PT15M, the earliest one is kept
+		if p := sub.get("TRIGGER"); p != nil && !strings.EqualFold(p.Params["RELATED"], "END") {
+			if d, err := duration(p.Value); err == nil && -d > e.Alarm {
+				e.Alarm = -d
+			}
+		}
+	}
+	return e, nil
+}
@@ -0,0 +1,344 @@
+/*
+	client.go
+	Purpose: Outbound client of the LINE Messaging API.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Add GroupSummary
+	2026/10/17  v1.0.2 Evan Chen   Retry replies only on 429
+*/
+
+package line
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"app/core/errors"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+)
+
+const (
+	// DefaultBaseURL is the endpoint of the Messaging API.
+	DefaultBaseURL = "https://api.line.me"
+	// DefaultDataURL is the endpoint for uploading and downloading contents.
+	DefaultDataURL = "https://api-data.line.me"
+
+	// RetryKeyHeader is the header to make push requests idempotent.
+	RetryKeyHeader = "X-Line-Retry-Key"
+
+	maxBackoff = 30 * time.Second
+)
+
+// Option configures a [Client].
+type Option struct {
+	// Token is the channel access token.
+	Token string
+	// BaseURL overrides [DefaultBaseURL], it could point to a fake server for testing.
+	BaseURL string
+	// DataURL overrides [DefaultDataURL], it could point to a fake server for testing.
+	DataURL string
+	// MaxRetry is how many times a request would be retried on 429 and 5xx responses, replies only on 429.
+	MaxRetry int
+	// Backoff is the initial wait before retrying, it doubles on every retry.
+	Backoff time.Duration
+	// HTTPClient is the client to send requests with, defaults to a client with 30s timeout.
+	HTTPClient *http.Client
+}
+
+// Client sends requests to the LINE Messaging API.
+type Client struct {
+	opt  Option
+	http *http.Client
+}
+
+// NewClient creates a new [Client] with @opt, missing options are filled with default values.
+func NewClient(opt *Option) *Client {
+	o := *opt
+	if o.BaseURL == "" {
+		o.BaseURL = DefaultBaseURL
+	}
+	if o.DataURL == "" {
+		o.DataURL = DefaultDataURL
+	}
+	o.BaseURL = strings.TrimSuffix(o.BaseURL, "/")
+	o.DataURL = strings.TrimSuffix(o.DataURL, "/")
+	if o.MaxRetry < 0 {
+		o.MaxRetry = 0
+	}
+	if o.Backoff <= 0 {
+		o.Backoff = 500 * time.Millisecond
+	}
+	c := &Client{opt: o, http: o.HTTPClient}
+	if c.http == nil {
+		c.http = &http.Client{Timeout: 30 * time.Second}
+	}
+	return c
+}
+
+// NewRetryKey generates a key for [RetryKeyHeader].
+func NewRetryKey() string {
+	return uuid.NewString()
+}
+
+// Reply replies to an event with the given @token.
+//
+// A reply token could be used only once, so a reply is retried only on 429 but not on 5xx or timeouts,
+// after which LINE might have taken the reply and would reject the token again.
+func (c *Client) Reply(ctx context.Context, token string, msgs ...Message) error {
+	return c.send(ctx, "/v2/bot/message/reply", "", map[string]any{
+		"replyToken": token,
+		"messages":   msgs,
+	})
+}
+
+// Push pushes @msgs to @to, which could be a user, group or room ID.
+//
+// A retry key is generated so that retries will not deliver the messages twice,
+// use [Client.PushWithKey] if the key should survive longer than this call.
+func (c *Client) Push(ctx context.Context, to string, msgs ...Message) error {
+	return c.PushWithKey(ctx, NewRetryKey(), to, msgs...)
+}
+
+// PushWithKey is like Push, but uses @key as the [RetryKeyHeader].
+func (c *Client) PushWithKey(ctx context.Context, key, to string, msgs ...Message) error {
+	return c.send(ctx, "/v2/bot/message/push", key, map[string]any{
+		"to":       to,
+		"messages": msgs,
+	})
+}
+
+// Multicast pushes @msgs to multiple users.
+func (c *Client) Multicast(ctx context.Context, to []string, msgs ...Message) error {
+	return c.send(ctx, "/v2/bot/message/multicast", NewRetryKey(), map[string]any{
+		"to":       to,
+		"messages": msgs,
+	})
+}
+
+// Broadcast pushes @msgs to every user that has added the bot as friend.
+func (c *Client) Broadcast(ctx context.Context, msgs ...Message) error {
+	return c.send(ctx, "/v2/bot/message/broadcast", NewRetryKey(), map[string]any{
+		"messages": msgs,
+	})
+}
+
+// Content downloads the content of an image, video, audio or file message.
+// The caller is responsible for closing the returned body.
+func (c *Client) Content(ctx context.Context, messageID string) (body io.ReadCloser, contentType string, err error) {
+	res, err := c.do(ctx, http.MethodGet, c.opt.DataURL+"/v2/bot/message/"+url.PathEscape(messageID)+"/content", "", nil, "")
+	if err != nil {
+		return nil, "", err
+	}
+	return res.Body, res.Header.Get("Content-Type"), nil
+}
+
+// Profile gets the profile of a user that has added the bot as friend.
+func (c *Client) Profile(ctx context.Context, userID string) (*UserProfile, error) {
+	p := &UserProfile{}
+	return p, c.get(ctx, "/v2/bot/profile/"+url.PathEscape(userID), p)
+}
+
+// GroupMemberProfile gets the profile of a member in a group.
+func (c *Client) GroupMemberProfile(ctx context.Context, groupID, userID string) (*UserProfile, error) {
+	p := &UserProfile{}
+	return p, c.get(ctx, "/v2/bot/group/"+url.PathEscape(groupID)+"/member/"+url.PathEscape(userID), p)
+}
+
+// RoomMemberProfile gets the profile of a member in a room.
+func (c *Client) RoomMemberProfile(ctx context.Context, roomID, userID string) (*UserProfile, error) {
+	p := &UserProfile{}
+	return p, c.get(ctx, "/v2/bot/room/"+url.PathEscape(roomID)+"/member/"+url.PathEscape(userID), p)
+}
+
+// GroupSummary gets the name and icon of a group.
+func (c *Client) GroupSummary(ctx context.Context, groupID string) (*GroupProfile, error) {
+	g := &GroupProfile{}
+	return g, c.get(ctx, "/v2/bot/group/"+url.PathEscape(groupID)+"/summary", g)
+}
+
+// send posts @payload as json to @path of the base url.
+func (c *Client) send(ctx context.Context, path, retryKey string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.ErrBadRequest.SetInfo(err.Error())
+	}
+	return c.request(ctx, http.MethodPost, c.opt.BaseURL+path, "application/json", body, retryKey, nil)
+}
+
+// get gets @path of the base url and decodes the json response into @v.
+func (c *Client) get(ctx context.Context, path string, v any) error {
+	return c.request(ctx, http.MethodGet, c.opt.BaseURL+path, "", nil, "", v)
+}
+
+// request sends the request and decodes the json response into @v if it is not nil.
+func (c *Client) request(ctx context.Context, method, endpoint, contentType string, body []byte, retryKey string, v any) error {
+	res, err := c.do(ctx, method, endpoint, contentType, body, retryKey)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if v == nil {
+		io.Copy(io.Discard, res.Body)
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		return errors.ErrInternal.SetInfo(err.Error())
+	}
+	return nil
+}
+
+// do sends the request and retries on 429 and 5xx responses.
+// A POST without @retryKey could not be told apart when sent twice, so it is retried only on 429,
+// which is answered before the request is taken.
+// The response is only returned on success, and the body must be closed by the caller.
+func (c *Client) do(ctx context.Context, method, endpoint, contentType string, body []byte, retryKey string) (*http.Response, error) {
+	idempotent := method != http.MethodPost || retryKey != ""
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+		if err != nil {
+			return nil, errors.ErrBadRequest.SetInfo(err.Error())
+		}
+		req.Header.Set("Authorization", "Bearer "+c.opt.Token)
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		if retryKey != "" {
+			req.Header.Set(RetryKeyHeader, retryKey)
+		}
+
+		res, err := c.http.Do(req)
+		if err != nil {
+			if ctx.Err() != nil || attempt >= c.opt.MaxRetry || !idempotent {
+				return nil, errors.ErrServiceUnavailable.SetInfo(err.Error())
+			}
+			if err := c.wait(ctx, attempt, ""); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if res.StatusCode < 300 {
+			return res, nil
+		}
+
+		// A conflict with a retry key means a previous attempt has been accepted.
+		if res.StatusCode == http.StatusConflict && retryKey != "" && res.Header.Get("X-Line-Accepted-Request-Id") != "" {
+			return res, nil
+		}
+
+		lineErr := responseError(res)
+		res.Body.Close()
+		if !retryable(res.StatusCode, idempotent) || attempt >= c.opt.MaxRetry {
+			return nil, lineErr
+		}
+		slog.Warn("line request failed, retrying",
+			slog.String("mod", "line"), slog.String("act", "request"),
+			slog.String("url", endpoint),
+			slog.Int("status", res.StatusCode),
+			slog.Int("attempt", attempt+1))
+		if err := c.wait(ctx, attempt, res.Header.Get("Retry-After")); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// wait sleeps before the next retry, @retryAfter takes precedence over the exponential backoff.
+func (c *Client) wait(ctx context.Context, attempt int, retryAfter string) error {
+	d := c.opt.Backoff << attempt
+	if d > maxBackoff || d <= 0 {
+		d = maxBackoff
+	}
+	d += time.Duration(rand.Int63n(int64(d)/2 + 1)) // jitter
+	if after, ok := parseRetryAfter(retryAfter); ok {
+		d = after
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return errors.ErrServiceUnavailable.SetInfo(ctx.Err().Error())
+	case <-t.C:
+		return nil
+	}
+}
+
+// parseRetryAfter parses the Retry-After header which is either in seconds or a http date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if sec, err := strconv.Atoi(v); err == nil && sec >= 0 {
+		return time.Duration(sec) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// retryable tells if a response of @status should be retried, 5xx only if the request is @idempotent.
+func retryable(status int, idempotent bool) bool {
+	return status == http.StatusTooManyRequests || idempotent && status >= 500
+}
+
+// errorResponse is the error body returned by the Messaging API.
+type errorResponse struct {
+	Message string `json:"message"`
+	Details []struct {
+		Message  string `json:"message"`
+		Property string `json:"property"`
+	} `json:"details"`
+}
+
+// responseError maps a failed response to errors of app/core/errors.
+func responseError(res *http.Response) *errors.Error {
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+	e := &errorResponse{}
+	info := string(body)
+	if json.Unmarshal(body, e) == nil && e.Message != "" {
+		info = e.Message
+		for _, d := range e.Details {
+			info += fmt.Sprintf("; %s: %s", d.Property, d.Message)
+		}
+	}
+
+	switch res.StatusCode {
+	case http.StatusBadRequest:
+		return errors.ErrBadRequest.SetInfo(info)
+	case http.StatusUnauthorized:
+		return errors.ErrUnauthorized.SetInfo(info)
+	case http.StatusForbidden:
+		return errors.ErrForbidden.SetInfo(info)
+	case http.StatusNotFound:
+		return errors.ErrNotFound.SetInfo(info)
+	case http.StatusConflict:
+		return errors.ErrConflict.SetInfo(info)
+	case http.StatusTooManyRequests:
+		return errors.ErrResourceExhausted.SetInfo(info)
+	default:
+		if res.StatusCode >= 500 {
+			return errors.ErrServiceUnavailable.SetInfo(info)
+		}
+		return errors.ErrInternal.SetInfo(info)
+	}
+}
@@ -0,0 +1,83 @@
+package line
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  []int // the statuses replied in turn, 200 afterwards
+		send    func(c *Client) error
+		ok      bool
+		calls   int
+		sameKey bool
+	}{
+		{"reply accepted", nil, reply, true, 1, false},
+		{"reply on 500 is not retried", []int{500}, reply, false, 1, false},
+		{"reply on 429 is retried", []int{429, 429}, reply, true, 3, false},
+		{"reply on 400 is not retried", []int{400}, reply, false, 1, false},
+		{"push on 500 is retried with the same key", []int{500, 503}, push, true, 3, true},
+		{"push gives up", []int{500, 500, 500, 500}, push, false, 3, true},
+		{"get on 500 is retried", []int{502}, get, true, 2, false},
+	}
+	for _, tt := range tests {
+		var lock sync.Mutex
+		calls, keys := 0, map[string]bool{}
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lock.Lock()
+			defer lock.Unlock()
+			keys[r.Header.Get(RetryKeyHeader)] = true
+			calls++
+			if calls <= len(tt.status) {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(tt.status[calls-1])
+				w.Write([]byte(`{"message":"failed"}`))
+				return
+			}
+			w.Write([]byte(`{}`))
+		}))
+		c := NewClient(&Option{Token: "t", BaseURL: srv.URL, DataURL: srv.URL, MaxRetry: 2, Backoff: time.Millisecond})
+		err := tt.send(c)
+		srv.Close()
+		if (err == nil) != tt.ok || calls != tt.calls {
+			t.Errorf("%s: err = %v after %d calls, want ok %v after %d", tt.name, err, calls, tt.ok, tt.calls)
+		}
+		if tt.sameKey && (len(keys) != 1 || keys[""]) {
+			t.Errorf("%s: retry keys = %v, want one", tt.name, keys)
+		}
+	}
+}
+
+func TestReplyTimeout(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer srv.Close()
+	c := NewClient(&Option{Token: "t", BaseURL: srv.URL, MaxRetry: 2, Backoff: time.Millisecond,
+		HTTPClient: &http.Client{Timeout: 10 * time.Millisecond}})
+	if err := reply(c); err == nil || atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("reply timed out = %v after %d calls, want an error after 1", err, atomic.LoadInt32(&calls))
+	}
+}
+
+func reply(c *Client) error {
+	return c.Reply(context.Background(), "token", NewText("hi"))
+}
+
+func push(c *Client) error {
+	return c.Push(context.Background(), "U1", NewText("hi"))
+}
+
+func get(c *Client) error {
+	_, err := c.Profile(context.Background(), "U1")
+	return err
+}
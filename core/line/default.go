@@ -0,0 +1,68 @@
+/*
+	default.go
+	Purpose: A default client and its exported functions
+	so they could be used easier.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Add GroupSummary
+*/
+
+package line
+
+import (
+	"context"
+	"io"
+)
+
+var std = NewClient(&Option{})
+
+// Setup replaces the default client with a new [Client] configured by @opt.
+func Setup(opt *Option) { std = NewClient(opt) }
+
+// Default returns the default client.
+func Default() *Client { return std }
+
+// Reply replies to an event with the given @token.
+func Reply(ctx context.Context, token string, msgs ...Message) error {
+	return std.Reply(ctx, token, msgs...)
+}
+
+// Push pushes @msgs to @to, which could be a user, group or room ID.
+func Push(ctx context.Context, to string, msgs ...Message) error {
+	return std.Push(ctx, to, msgs...)
+}
+
+// PushWithKey is like Push, but uses @key as the retry key.
+func PushWithKey(ctx context.Context, key, to string, msgs ...Message) error {
+	return std.PushWithKey(ctx, key, to, msgs...)
+}
+
+// Multicast pushes @msgs to multiple users.
+func Multicast(ctx context.Context, to []string, msgs ...Message) error {
+	return std.Multicast(ctx, to, msgs...)
+}
+
+// Broadcast pushes @msgs to every user that has added the bot as friend.
+func Broadcast(ctx context.Context, msgs ...Message) error {
+	return std.Broadcast(ctx, msgs...)
+}
+
+// Content downloads the content of a message, the caller should close the returned body.
+func Content(ctx context.Context, messageID string) (io.ReadCloser, string, error) {
+	return std.Content(ctx, messageID)
+}
+
+// Profile gets the profile of a user.
+func Profile(ctx context.Context, userID string) (*UserProfile, error) {
+	return std.Profile(ctx, userID)
+}
+
+// GroupSummary gets the name and icon of a group.
+func GroupSummary(ctx context.Context, groupID string) (*GroupProfile, error) {
+	return std.GroupSummary(ctx, groupID)
+}
@@ -0,0 +1,235 @@
+/*
+	event.go
+	Purpose: Typed structures of the LINE Messaging API webhook events.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Add Event.Duplicate
+*/
+
+package line
+
+import (
+	"app/core/signal"
+)
+
+// Event types sent by the LINE platform.
+//
+//   - https://developers.line.biz/en/reference/messaging-api/#webhook-event-objects
+const (
+	EventMessage           = "message"
+	EventUnsend            = "unsend"
+	EventFollow            = "follow"
+	EventUnfollow          = "unfollow"
+	EventJoin              = "join"
+	EventLeave             = "leave"
+	EventMemberJoined      = "memberJoined"
+	EventMemberLeft        = "memberLeft"
+	EventPostback          = "postback"
+	EventVideoPlayComplete = "videoPlayComplete"
+	EventBeacon            = "beacon"
+	EventAccountLink       = "accountLink"
+)
+
+// Message types of the message event.
+const (
+	MessageText     = "text"
+	MessageImage    = "image"
+	MessageVideo    = "video"
+	MessageAudio    = "audio"
+	MessageFile     = "file"
+	MessageLocation = "location"
+	MessageSticker  = "sticker"
+)
+
+// Source types of an event.
+const (
+	SourceUser  = "user"
+	SourceGroup = "group"
+	SourceRoom  = "room"
+)
+
+// Topics that the webhook emits through [signal], listeners will receive the *Event as the first argument.
+//
+// Message events are emitted as "line.message.<message type>",
+// while other events are emitted as "line.<event type>".
+const (
+	TopicText         = "line.message.text"
+	TopicImage        = "line.message.image"
+	TopicVideo        = "line.message.video"
+	TopicAudio        = "line.message.audio"
+	TopicFile         = "line.message.file"
+	TopicLocation     = "line.message.location"
+	TopicSticker      = "line.message.sticker"
+	TopicFollow       = "line.follow"
+	TopicUnfollow     = "line.unfollow"
+	TopicJoin         = "line.join"
+	TopicLeave        = "line.leave"
+	TopicMemberJoined = "line.memberJoined"
+	TopicMemberLeft   = "line.memberLeft"
+	TopicPostback     = "line.postback"
+)
+
+// WebhookRequest is the request body the LINE platform posts to the webhook url.
+type WebhookRequest struct {
+	// Destination is the user ID of the bot that should receive the events.
+	Destination string   `json:"destination"`
+	Events      []*Event `json:"events"`
+}
+
+// Event is a single webhook event, fields are populated according to the event type.
+type Event struct {
+	Type            string          `json:"type"`
+	Mode            string          `json:"mode"`
+	Timestamp       int64           `json:"timestamp"`
+	Source          *Source         `json:"source,omitempty"`
+	WebhookEventID  string          `json:"webhookEventId"`
+	DeliveryContext DeliveryContext `json:"deliveryContext"`
+	ReplyToken      string          `json:"replyToken,omitempty"`
+
+	Message  *IncomingMessage `json:"message,omitempty"`  // message
+	Unsend   *Unsend          `json:"unsend,omitempty"`   // unsend
+	Postback *Postback        `json:"postback,omitempty"` // postback
+	Joined   *Members         `json:"joined,omitempty"`   // memberJoined
+	Left     *Members         `json:"left,omitempty"`     // memberLeft
+	Beacon   *Beacon          `json:"beacon,omitempty"`   // beacon
+	Link     *AccountLink     `json:"link,omitempty"`     // accountLink
+	Follow   *Follow          `json:"follow,omitempty"`   // follow
+
+	// Duplicate is set by the webhook if the event has been received before,
+	// e.g. LINE redelivered an event that was already handled. See [SetDeduplicator].
+	Duplicate bool `json:"-"`
+}
+
+// Topic returns the [signal] topic the event should be emitted to.
+func (e *Event) Topic() string {
+	if e.Type == EventMessage && e.Message != nil {
+		return "line." + EventMessage + "." + e.Message.Type
+	}
+	return "line." + e.Type
+}
+
+// DeliveryContext tells whether the event is a redelivered one.
+type DeliveryContext struct {
+	IsRedelivery bool `json:"isRedelivery"`
+}
+
+// Source is where the event comes from.
+type Source struct {
+	Type    string `json:"type"`
+	UserID  string `json:"userId,omitempty"`
+	GroupID string `json:"groupId,omitempty"`
+	RoomID  string `json:"roomId,omitempty"`
+}
+
+// ID returns the chat id of the source, which is the target to push messages back to.
+func (s *Source) ID() string {
+	switch s.Type {
+	case SourceGroup:
+		return s.GroupID
+	case SourceRoom:
+		return s.RoomID
+	default:
+		return s.UserID
+	}
+}
+
+// IncomingMessage is the message object of a message event.
+type IncomingMessage struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	QuoteToken string `json:"quoteToken,omitempty"`
+
+	// text
+	Text    string   `json:"text,omitempty"`
+	Emojis  []*Emoji `json:"emojis,omitempty"`
+	Mention *Mention `json:"mention,omitempty"`
+
+	// image, video, audio
+	Duration        int64            `json:"duration,omitempty"`
+	ContentProvider *ContentProvider `json:"contentProvider,omitempty"`
+
+	// file
+	FileName string `json:"fileName,omitempty"`
+	FileSize int64  `json:"fileSize,omitempty"`
+
+	// location
+	Title     string  `json:"title,omitempty"`
+	Address   string  `json:"address,omitempty"`
+	Latitude  float64 `json:"latitude,omitempty"`
+	Longitude float64 `json:"longitude,omitempty"`
+
+	// sticker
+	PackageID           string   `json:"packageId,omitempty"`
+	StickerID           string   `json:"stickerId,omitempty"`
+	StickerResourceType string   `json:"stickerResourceType,omitempty"`
+	Keywords            []string `json:"keywords,omitempty"`
+}
+
+// ContentProvider tells where the content of a media message is stored.
+type ContentProvider struct {
+	// Type is either "line" or "external"
+	Type               string `json:"type"`
+	OriginalContentURL string `json:"originalContentUrl,omitempty"`
+	PreviewImageURL    string `json:"previewImageUrl,omitempty"`
+}
+
+type Emoji struct {
+	Index     int    `json:"index"`
+	Length    int    `json:"length"`
+	ProductID string `json:"productId"`
+	EmojiID   string `json:"emojiId"`
+}
+
+type Mention struct {
+	Mentionees []*Mentionee `json:"mentionees"`
+}
+
+type Mentionee struct {
+	Index  int    `json:"index"`
+	Length int    `json:"length"`
+	Type   string `json:"type"`
+	UserID string `json:"userId,omitempty"`
+}
+
+type Unsend struct {
+	MessageID string `json:"messageId"`
+}
+
+// Postback is the payload of the postback action.
+type Postback struct {
+	Data   string            `json:"data"`
+	Params map[string]string `json:"params,omitempty"`
+}
+
+type Members struct {
+	Members []*Source `json:"members"`
+}
+
+type Beacon struct {
+	Hwid string `json:"hwid"`
+	Type string `json:"type"`
+	DM   string `json:"dm,omitempty"`
+}
+
+type AccountLink struct {
+	Result string `json:"result"`
+	Nonce  string `json:"nonce"`
+}
+
+type Follow struct {
+	IsUnblocked bool `json:"isUnblocked"`
+}
+
+// EventOf extracts the *Event from a [signal.Event] emitted by the webhook.
+func EventOf(e signal.Event) (*Event, bool) {
+	if len(e.Args) == 0 {
+		return nil, false
+	}
+	evt, ok := e.Args[0].(*Event)
+	return evt, ok
+}
@@ -0,0 +1,481 @@
+/*
+	fakeline.go
+	Purpose: A fake LINE platform which serves the Messaging API endpoints we call
+	and records every request for end-to-end tests without network access.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Serve group summaries
+*/
+
+// Package fakeline emulates the LINE platform on a local http server.
+//
+// The app is pointed at it by setting `LINE_API_URL` and `LINE_DATA_API_URL` to the address of the [Server],
+// the server answers reply, push, profile, content and rich menu requests, and records them into a transcript.
+// Webhook events are posted back to the app signed with the channel secret, either one by one with [Server.Send]
+// or from a scripted [Scenario].
+package fakeline
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/xid"
+	"golang.org/x/exp/slog"
+)
+
+// Option configures a [Server].
+type Option struct {
+	// Webhook is the url of the webhook of the app, e.g. http://localhost/webhook/line
+	Webhook string
+	// Secret is the channel secret the webhook requests are signed with.
+	Secret string
+	// Destination is the user ID of the bot, it is put in every webhook request.
+	Destination string
+	// HTTPClient is the client to post webhook requests with, defaults to a client with 10s timeout.
+	HTTPClient *http.Client
+}
+
+// Request is an API request received from the app.
+type Request struct {
+	Time     time.Time       `json:"time"`
+	Method   string          `json:"method"`
+	Path     string          `json:"path"`
+	RetryKey string          `json:"retryKey,omitempty"`
+	Status   int             `json:"status"`
+	Body     json.RawMessage `json:"body,omitempty"`
+}
+
+// Entry is a line of the simulated chat transcript.
+type Entry struct {
+	Time time.Time `json:"time"`
+	// Chat is the user, group or room ID the entry belongs to, "*" for broadcasts.
+	Chat string `json:"chat"`
+	// From is the user ID who sent it, or "bot" for messages sent by the app.
+	From string `json:"from"`
+	// Type is the message type, or the event type for events other than messages.
+	Type string `json:"type"`
+	// Text is the text, the alt text of flex messages or the data of postbacks.
+	Text string `json:"text"`
+	// Raw is the message object or the webhook event.
+	Raw json.RawMessage `json:"raw"`
+}
+
+// Bot is the [Entry.From] of messages sent by the app.
+const Bot = "bot"
+
+// Server is the fake LINE platform, it is an [http.Handler].
+type Server struct {
+	opt  Option
+	http *http.Client
+
+	lock       sync.Mutex
+	changed    chan struct{} // closed and replaced whenever the transcript grows
+	requests   []*Request
+	transcript []*Entry
+	retryKeys  map[string]string   // retry key to the accepted request id
+	tokens     map[string]string   // reply token to the chat it was issued for
+	profiles   map[string]*Profile // user ID to the profile
+	contents   map[string]*Content // message ID to its content
+	menus      map[string]json.RawMessage
+	menuImages map[string]bool
+	defaultID  string
+	links      map[string]string // user ID to the linked rich menu
+}
+
+// Profile is the profile of a simulated user.
+type Profile struct {
+	DisplayName   string `json:"displayName"`
+	PictureURL    string `json:"pictureUrl,omitempty"`
+	StatusMessage string `json:"statusMessage,omitempty"`
+	Language      string `json:"language,omitempty"`
+}
+
+// Content is the content of a simulated media message.
+type Content struct {
+	Type string
+	Data []byte
+}
+
+// New creates a fake LINE platform with @opt.
+func New(opt *Option) *Server {
+	s := &Server{
+		opt:        *opt,
+		http:       opt.HTTPClient,
+		changed:    make(chan struct{}),
+		retryKeys:  map[string]string{},
+		tokens:     map[string]string{},
+		profiles:   map[string]*Profile{},
+		contents:   map[string]*Content{},
+		menus:      map[string]json.RawMessage{},
+		menuImages: map[string]bool{},
+		links:      map[string]string{},
+	}
+	if s.http == nil {
+		s.http = &http.Client{Timeout: 10 * time.Second}
+	}
+	if s.opt.Destination == "" {
+		s.opt.Destination = "Ufakeline"
+	}
+	return s
+}
+
+// SetProfile sets the profile of @userID returned by the profile endpoints.
+func (s *Server) SetProfile(userID string, p *Profile) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.profiles[userID] = p
+}
+
+// SetContent sets the content of @messageID returned by the content endpoint.
+func (s *Server) SetContent(messageID string, c *Content) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.contents[messageID] = c
+}
+
+// Requests returns the API requests received so far.
+func (s *Server) Requests() []*Request {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]*Request(nil), s.requests...)
+}
+
+// Transcript returns the simulated chat so far.
+func (s *Server) Transcript() []*Entry {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]*Entry(nil), s.transcript...)
+}
+
+// Reset clears the recorded requests and transcript.
+func (s *Server) Reset() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.requests, s.transcript = nil, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/":
+		s.page(w, r)
+	case r.URL.Path == "/fake/transcript":
+		writeJSON(w, http.StatusOK, s.Transcript())
+	case r.URL.Path == "/fake/requests":
+		writeJSON(w, http.StatusOK, s.Requests())
+	case r.URL.Path == "/fake/send":
+		s.send(w, r)
+	case strings.HasPrefix(r.URL.Path, "/v2/bot/"):
+		s.api(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// api records the request and answers it.
+func (s *Server) api(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, 10<<20))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	rec := &Request{Time: time.Now(), Method: r.Method, Path: r.URL.Path, RetryKey: r.Header.Get("X-Line-Retry-Key")}
+	if json.Valid(body) {
+		rec.Body = body
+	}
+	rw := &recorder{ResponseWriter: w, status: http.StatusOK}
+	defer func() {
+		rec.Status = rw.status
+		s.lock.Lock()
+		s.requests = append(s.requests, rec)
+		s.lock.Unlock()
+		slog.Debug("fake api called", slog.String("mod", "fakeline"), slog.String("act", "api"),
+			slog.String("method", rec.Method), slog.String("path", rec.Path), slog.Int("status", rec.Status))
+	}()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		writeError(rw, http.StatusUnauthorized, "Authentication failed. Confirm that the access token in the authorization header is valid.")
+		return
+	}
+	path := strings.Split(strings.TrimPrefix(r.URL.Path, "/v2/bot/"), "/")
+	switch {
+	case path[0] == "message" && len(path) == 2 && r.Method == http.MethodPost:
+		s.message(rw, path[1], rec.RetryKey, body)
+	case path[0] == "message" && len(path) == 3 && path[2] == "content" && r.Method == http.MethodGet:
+		s.content(rw, path[1])
+	case path[0] == "profile" && len(path) == 2 && r.Method == http.MethodGet:
+		s.profile(rw, path[1])
+	case path[0] == "group" && len(path) == 3 && path[2] == "summary" && r.Method == http.MethodGet:
+		writeJSON(rw, http.StatusOK, map[string]string{"groupId": path[1], "groupName": path[1]})
+	case (path[0] == "group" || path[0] == "room") && len(path) == 4 && path[2] == "member" && r.Method == http.MethodGet:
+		s.profile(rw, path[3])
+	case path[0] == "richmenu" || path[0] == "user":
+		s.richMenu(rw, r.Method, path, r.Header.Get("Content-Type"), body)
+	default:
+		writeError(rw, http.StatusNotFound, "Not found")
+	}
+}
+
+// message handles reply, push, multicast and broadcast.
+func (s *Server) message(w http.ResponseWriter, kind, retryKey string, body []byte) {
+	req := &struct {
+		ReplyToken string            `json:"replyToken"`
+		To         json.RawMessage   `json:"to"`
+		Messages   []json.RawMessage `json:"messages"`
+	}{}
+	if err := json.Unmarshal(body, req); err != nil {
+		writeError(w, http.StatusBadRequest, "The request body has 1 error(s)")
+		return
+	}
+	if len(req.Messages) == 0 || len(req.Messages) > 5 {
+		writeError(w, http.StatusBadRequest, "messages: Size must be between 1 and 5")
+		return
+	}
+
+	var chats []string
+	switch kind {
+	case "reply":
+		s.lock.Lock()
+		chat, ok := s.tokens[req.ReplyToken]
+		delete(s.tokens, req.ReplyToken)
+		s.lock.Unlock()
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Invalid reply token")
+			return
+		}
+		chats = []string{chat}
+	case "push":
+		var to string
+		if json.Unmarshal(req.To, &to) != nil || to == "" {
+			writeError(w, http.StatusBadRequest, "to: must be specified")
+			return
+		}
+		chats = []string{to}
+	case "multicast":
+		if json.Unmarshal(req.To, &chats) != nil || len(chats) == 0 {
+			writeError(w, http.StatusBadRequest, "to: must be specified")
+			return
+		}
+	case "broadcast":
+		chats = []string{"*"}
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	if retryKey != "" {
+		s.lock.Lock()
+		accepted, dup := s.retryKeys[retryKey]
+		if !dup {
+			s.retryKeys[retryKey] = xid.New().String()
+		}
+		s.lock.Unlock()
+		if dup {
+			w.Header().Set("X-Line-Accepted-Request-Id", accepted)
+			writeError(w, http.StatusConflict, "The retry key is already accepted")
+			return
+		}
+	}
+
+	now := time.Now()
+	for _, chat := range chats {
+		for _, m := range req.Messages {
+			typ, text := summary(m)
+			s.record(&Entry{Time: now, Chat: chat, From: Bot, Type: typ, Text: text, Raw: m})
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+func (s *Server) content(w http.ResponseWriter, id string) {
+	s.lock.Lock()
+	c, ok := s.contents[id]
+	s.lock.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	w.Header().Set("Content-Type", c.Type)
+	w.Write(c.Data)
+}
+
+func (s *Server) profile(w http.ResponseWriter, userID string) {
+	s.lock.Lock()
+	p, ok := s.profiles[userID]
+	s.lock.Unlock()
+	if !ok {
+		p = &Profile{DisplayName: userID}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"userId":        userID,
+		"displayName":   p.DisplayName,
+		"pictureUrl":    p.PictureURL,
+		"statusMessage": p.StatusMessage,
+		"language":      p.Language,
+	})
+}
+
+// richMenu handles the rich menu endpoints under /v2/bot/richmenu and /v2/bot/user.
+func (s *Server) richMenu(w http.ResponseWriter, method string, path []string, contentType string, body []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	switch {
+	case path[0] == "richmenu" && len(path) == 1 && method == http.MethodPost:
+		menu := map[string]json.RawMessage{}
+		if json.Unmarshal(body, &menu) != nil {
+			writeError(w, http.StatusBadRequest, "The request body has 1 error(s)")
+			return
+		}
+		id := "richmenu-" + xid.New().String()
+		menu["richMenuId"], _ = json.Marshal(id)
+		s.menus[id], _ = json.Marshal(menu)
+		writeJSON(w, http.StatusOK, map[string]string{"richMenuId": id})
+
+	case path[0] == "richmenu" && len(path) == 2 && path[1] == "list" && method == http.MethodGet:
+		list := make([]json.RawMessage, 0, len(s.menus))
+		for _, m := range s.menus {
+			list = append(list, m)
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"richmenus": list})
+
+	case path[0] == "richmenu" && len(path) == 2 && method == http.MethodDelete:
+		if s.menus[path[1]] == nil {
+			writeError(w, http.StatusNotFound, "Not found")
+			return
+		}
+		delete(s.menus, path[1])
+		delete(s.menuImages, path[1])
+		if s.defaultID == path[1] {
+			s.defaultID = ""
+		}
+		writeJSON(w, http.StatusOK, map[string]any{})
+
+	case path[0] == "richmenu" && len(path) == 3 && path[2] == "content" && method == http.MethodPost:
+		if s.menus[path[1]] == nil {
+			writeError(w, http.StatusNotFound, "Not found")
+			return
+		}
+		if contentType != "image/png" && contentType != "image/jpeg" {
+			writeError(w, http.StatusBadRequest, "Unsupported content type")
+			return
+		}
+		if s.menuImages[path[1]] {
+			writeError(w, http.StatusBadRequest, "An image has already been uploaded to the richmenu")
+			return
+		}
+		s.menuImages[path[1]] = true
+		writeJSON(w, http.StatusOK, map[string]any{})
+
+	case path[0] == "user" && len(path) >= 3 && path[2] == "richmenu":
+		s.userRichMenu(w, method, path)
+
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// userRichMenu handles the default menu of /v2/bot/user/all/richmenu and the linked menu of /v2/bot/user/{userId}/richmenu.
+func (s *Server) userRichMenu(w http.ResponseWriter, method string, path []string) {
+	user := path[1]
+	current := s.links[user]
+	if user == "all" {
+		current = s.defaultID
+	}
+	switch {
+	case len(path) == 3 && method == http.MethodGet:
+		if current == "" {
+			writeError(w, http.StatusNotFound, "the user has no richmenu")
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"richMenuId": current})
+	case len(path) == 3 && method == http.MethodDelete:
+		if user == "all" {
+			s.defaultID = ""
+		} else {
+			delete(s.links, user)
+		}
+		writeJSON(w, http.StatusOK, map[string]any{})
+	case len(path) == 4 && method == http.MethodPost:
+		if s.menus[path[3]] == nil {
+			writeError(w, http.StatusNotFound, "Not found")
+			return
+		}
+		if !s.menuImages[path[3]] {
+			writeError(w, http.StatusBadRequest, "must upload richmenu image before applying it to user")
+			return
+		}
+		if user == "all" {
+			s.defaultID = path[3]
+		} else {
+			s.links[user] = path[3]
+		}
+		writeJSON(w, http.StatusOK, map[string]any{})
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// record appends @e to the transcript and wakes up the waiters.
+func (s *Server) record(e *Entry) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.transcript = append(s.transcript, e)
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// summary returns the type and a readable text of a message object.
+func summary(raw json.RawMessage) (typ, text string) {
+	m := &struct {
+		Type        string `json:"type"`
+		Text        string `json:"text"`
+		AltText     string `json:"altText"`
+		PackageID   string `json:"packageId"`
+		StickerID   string `json:"stickerId"`
+		OriginalURL string `json:"originalContentUrl"`
+		Title       string `json:"title"`
+	}{}
+	json.Unmarshal(raw, m)
+	switch m.Type {
+	case "text":
+		return m.Type, m.Text
+	case "flex", "template", "imagemap":
+		return m.Type, m.AltText
+	case "sticker":
+		return m.Type, m.PackageID + "/" + m.StickerID
+	case "location":
+		return m.Type, m.Title
+	default:
+		return m.Type, m.OriginalURL
+	}
+}
+
+type recorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *recorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error in the format of the Messaging API.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
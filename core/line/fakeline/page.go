@@ -0,0 +1,54 @@
+/*
+	page.go
+	Purpose: Show the simulated chat transcript in the browser.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package fakeline
+
+import (
+	"html/template"
+	"net/http"
+)
+
+var pageTmpl = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="2">
+<title>fakeline</title>
+<style>
+body { font-family: sans-serif; max-width: 720px; margin: 2em auto; background: #8cabd9; }
+.msg { margin: .4em 0; display: flex; flex-direction: column; }
+.msg.bot { align-items: flex-start; }
+.msg.user { align-items: flex-end; }
+.bubble { padding: .5em .8em; border-radius: 1em; max-width: 75%; white-space: pre-wrap; background: #fff; }
+.user .bubble { background: #85e249; }
+.meta { font-size: .75em; color: #333; }
+</style>
+</head>
+<body>
+<p><a href="/fake/transcript">transcript.json</a> · <a href="/fake/requests">requests.json</a></p>
+{{range .}}
+<div class="msg {{if eq .From "bot"}}bot{{else}}user{{end}}">
+	<span class="meta">{{.Time.Format "15:04:05"}} {{.Chat}} · {{.From}} · {{.Type}}</span>
+	<span class="bubble">{{.Text}}</span>
+</div>
+{{else}}
+<p>No messages yet.</p>
+{{end}}
+</body>
+</html>
+`))
+
+// page renders the transcript as a chat.
+func (s *Server) page(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	pageTmpl.Execute(w, s.Transcript())
+}
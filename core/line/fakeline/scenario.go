@@ -0,0 +1,179 @@
+/*
+	scenario.go
+	Purpose: Run scripted conversations against the app and check its answers.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package fakeline
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"app/core/line"
+
+	"golang.org/x/exp/slog"
+)
+
+// DefaultTimeout is how long an [Expect] waits for the app by default.
+const DefaultTimeout = 5 * time.Second
+
+// Scenario is a scripted conversation, steps are run in order and the first failure stops it.
+//
+//	{
+//	  "profiles": {"U1": {"displayName": "Evan"}},
+//	  "steps": [
+//	    {"say": {"user": "U1", "text": "/remind in 10m to stretch"}},
+//	    {"expect": {"chat": "U1", "contains": "提醒"}},
+//	    {"wait": "1s"}
+//	  ]
+//	}
+type Scenario struct {
+	// Profiles are the profiles of the simulated users.
+	Profiles map[string]*Profile `json:"profiles,omitempty"`
+	// Contents are the contents of media messages by message ID, the data is base64 encoded.
+	Contents map[string]*struct {
+		Type string `json:"type"`
+		Data string `json:"data"`
+	} `json:"contents,omitempty"`
+	Steps []*Step `json:"steps"`
+}
+
+// Step is a step of a [Scenario], only one of the fields should be set.
+type Step struct {
+	// Say sends a text message or postback from a user.
+	Say *Say `json:"say,omitempty"`
+	// Event sends a raw webhook event, missing fields are filled as [Server.Send] does.
+	Event *line.Event `json:"event,omitempty"`
+	// Expect waits for the app to send a matching message.
+	Expect *Expect `json:"expect,omitempty"`
+	// Wait sleeps for a duration, e.g. "2s".
+	Wait string `json:"wait,omitempty"`
+}
+
+// Expect matches a message sent by the app after the previous step.
+type Expect struct {
+	// Chat is the chat the message is sent to, any chat if empty.
+	Chat string `json:"chat,omitempty"`
+	// Type is the message type, any type if empty.
+	Type string `json:"type,omitempty"`
+	// Contains is a substring of the text, or alt text of flex messages.
+	Contains string `json:"contains,omitempty"`
+	// Timeout is how long to wait for the message, defaults to [DefaultTimeout].
+	Timeout string `json:"timeout,omitempty"`
+}
+
+func (e *Expect) match(entry *Entry) bool {
+	return entry.From == Bot &&
+		(e.Chat == "" || e.Chat == entry.Chat) &&
+		(e.Type == "" || e.Type == entry.Type) &&
+		strings.Contains(entry.Text, e.Contains)
+}
+
+func (e *Expect) String() string {
+	b, _ := json.Marshal(e)
+	return string(b)
+}
+
+// LoadScenario reads a scenario from a json file.
+func LoadScenario(file string) (*Scenario, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	sc := &Scenario{}
+	if err := json.Unmarshal(content, sc); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return sc, nil
+}
+
+// Run runs @sc against the app, an error tells which step failed.
+func (s *Server) Run(ctx context.Context, sc *Scenario) error {
+	for id, p := range sc.Profiles {
+		s.SetProfile(id, p)
+	}
+	for id, c := range sc.Contents {
+		data, err := base64.StdEncoding.DecodeString(c.Data)
+		if err != nil {
+			return fmt.Errorf("content %s: %w", id, err)
+		}
+		s.SetContent(id, &Content{Type: c.Type, Data: data})
+	}
+
+	// expectations only match messages after the previous step
+	seen := len(s.Transcript())
+	for i, step := range sc.Steps {
+		var err error
+		switch {
+		case step.Say != nil:
+			err = s.Send(ctx, s.Event(step.Say))
+		case step.Event != nil:
+			evt := *step.Event
+			err = s.Send(ctx, &evt)
+		case step.Expect != nil:
+			seen, err = s.expect(ctx, step.Expect, seen)
+		case step.Wait != "":
+			var d time.Duration
+			if d, err = time.ParseDuration(step.Wait); err == nil {
+				select {
+				case <-time.After(d):
+				case <-ctx.Done():
+					err = ctx.Err()
+				}
+			}
+		default:
+			err = fmt.Errorf("empty step")
+		}
+		if err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+		slog.Info("scenario step passed", slog.String("mod", "fakeline"), slog.String("act", "run"), slog.Int("step", i+1))
+	}
+	return nil
+}
+
+// expect waits for an entry matching @e after index @from of the transcript,
+// and returns the index after the matched entry.
+func (s *Server) expect(ctx context.Context, e *Expect, from int) (int, error) {
+	timeout := DefaultTimeout
+	if e.Timeout != "" {
+		d, err := time.ParseDuration(e.Timeout)
+		if err != nil {
+			return from, err
+		}
+		timeout = d
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		s.lock.Lock()
+		changed := s.changed
+		for i := from; i < len(s.transcript); i++ {
+			if e.match(s.transcript[i]) {
+				s.lock.Unlock()
+				return i + 1, nil
+			}
+		}
+		s.lock.Unlock()
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			return from, fmt.Errorf("no message matched %s in %s", e, timeout)
+		case <-ctx.Done():
+			return from, ctx.Err()
+		}
+	}
+}
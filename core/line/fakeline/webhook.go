@@ -0,0 +1,156 @@
+/*
+	webhook.go
+	Purpose: Post signed webhook events to the app as the LINE platform would.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package fakeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"app/core/line"
+
+	"github.com/rs/xid"
+)
+
+// Say is a message or postback from a simulated user.
+type Say struct {
+	// User is the user ID of the sender.
+	User string `json:"user"`
+	// Group or Room is the chat the message is sent in, it is a one-on-one chat if both are empty.
+	Group string `json:"group,omitempty"`
+	Room  string `json:"room,omitempty"`
+	// Text is sent as a text message.
+	Text string `json:"text,omitempty"`
+	// Postback is sent as the data of a postback event if Text is empty.
+	Postback string `json:"postback,omitempty"`
+}
+
+// Event builds the webhook event of @say, which is a text message or a postback.
+func (s *Server) Event(say *Say) *line.Event {
+	src := &line.Source{Type: line.SourceUser, UserID: say.User}
+	if say.Group != "" {
+		src.Type, src.GroupID = line.SourceGroup, say.Group
+	} else if say.Room != "" {
+		src.Type, src.RoomID = line.SourceRoom, say.Room
+	}
+	evt := &line.Event{Source: src}
+	if say.Text != "" {
+		evt.Type = line.EventMessage
+		evt.Message = &line.IncomingMessage{ID: strconv.FormatInt(time.Now().UnixNano(), 10), Type: line.MessageText, Text: say.Text}
+	} else {
+		evt.Type = line.EventPostback
+		evt.Postback = &line.Postback{Data: say.Postback}
+	}
+	return evt
+}
+
+// Send signs @events and posts them to the webhook of the app in a single request.
+//
+// Missing fields of the events are filled like LINE does, which are the webhook event ID,
+// timestamp, mode and a reply token that the app could reply with once.
+func (s *Server) Send(ctx context.Context, events ...*line.Event) error {
+	now := time.Now()
+	for _, evt := range events {
+		if evt.WebhookEventID == "" {
+			evt.WebhookEventID = xid.New().String()
+		}
+		if evt.Timestamp == 0 {
+			evt.Timestamp = now.UnixMilli()
+		}
+		if evt.Mode == "" {
+			evt.Mode = "active"
+		}
+		if evt.ReplyToken == "" && evt.Source != nil && replyable(evt.Type) {
+			evt.ReplyToken = xid.New().String()
+		}
+		if evt.ReplyToken != "" && evt.Source != nil {
+			s.lock.Lock()
+			s.tokens[evt.ReplyToken] = evt.Source.ID()
+			s.lock.Unlock()
+		}
+	}
+	body, err := json.Marshal(&line.WebhookRequest{Destination: s.opt.Destination, Events: events})
+	if err != nil {
+		return err
+	}
+
+	// recorded before posting, as the app may answer before the request returns
+	for _, evt := range events {
+		raw, _ := json.Marshal(evt)
+		e := &Entry{Time: now, Type: evt.Type, Raw: raw}
+		if evt.Source != nil {
+			e.Chat, e.From = evt.Source.ID(), evt.Source.UserID
+		}
+		switch {
+		case evt.Message != nil:
+			e.Type, e.Text = evt.Message.Type, evt.Message.Text
+		case evt.Postback != nil:
+			e.Text = evt.Postback.Data
+		}
+		s.record(e)
+	}
+	return s.post(ctx, body)
+}
+
+// post posts a signed webhook request, the app should answer 200 as LINE requires.
+func (s *Server) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.opt.Webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(line.SignatureHeader, line.Sign([]byte(s.opt.Secret), body))
+	res, err := s.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("webhook responded %d: %s", res.StatusCode, bytes.TrimSpace(msg))
+	}
+	io.Copy(io.Discard, res.Body)
+	return nil
+}
+
+// replyable checks if LINE gives a reply token to events of @typ.
+func replyable(typ string) bool {
+	switch typ {
+	case line.EventUnfollow, line.EventLeave, line.EventMemberLeft, line.EventUnsend, line.EventVideoPlayComplete:
+		return false
+	}
+	return true
+}
+
+// send handles POST /fake/send with a [Say] as the body, to chat with the app by hand.
+func (s *Server) send(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	say := &Say{}
+	if err := json.NewDecoder(r.Body).Decode(say); err != nil || say.User == "" || (say.Text == "" && say.Postback == "") {
+		writeError(w, http.StatusBadRequest, "user and either text or postback are required")
+		return
+	}
+	if err := s.Send(r.Context(), s.Event(say)); err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
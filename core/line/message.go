@@ -0,0 +1,113 @@
+/*
+	message.go
+	Purpose: Message objects that could be sent through the LINE Messaging API.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Add GroupProfile
+	2026/10/17  v1.0.2 Evan Chen   Add quick replies to text messages
+*/
+
+package line
+
+// Message is a message object that could be sent through the LINE Messaging API.
+//
+//   - https://developers.line.biz/en/reference/messaging-api/#message-objects
+type Message interface {
+	// MessageType returns the type of the message, e.g. "text".
+	MessageType() string
+}
+
+// TextMessage is a plain text message.
+type TextMessage struct {
+	Type       string      `json:"type"`
+	Text       string      `json:"text"`
+	QuickReply *QuickReply `json:"quickReply,omitempty"`
+}
+
+func (m *TextMessage) MessageType() string { return m.Type }
+
+// NewText creates a text message.
+func NewText(text string) *TextMessage {
+	return &TextMessage{Type: MessageText, Text: text}
+}
+
+// WithQuickReply shows @items above the keyboard with the message, and returns the message.
+func (m *TextMessage) WithQuickReply(items ...*QuickReplyItem) *TextMessage {
+	m.QuickReply = &QuickReply{Items: items}
+	return m
+}
+
+// MaxQuickReply is the most items of a quick reply.
+const MaxQuickReply = 13
+
+// QuickReply are the buttons shown above the keyboard with a message.
+//
+//   - https://developers.line.biz/en/reference/messaging-api/#quick-reply
+type QuickReply struct {
+	Items []*QuickReplyItem `json:"items"`
+}
+
+// QuickReplyItem is a button of a quick reply.
+type QuickReplyItem struct {
+	Type     string `json:"type"`
+	ImageURL string `json:"imageUrl,omitempty"`
+	// Action is the action object of the button, e.g. a *flex.Action.
+	Action any `json:"action"`
+}
+
+// NewQuickReplyItem creates a quick reply button of @action.
+func NewQuickReplyItem(action any) *QuickReplyItem {
+	return &QuickReplyItem{Type: "action", Action: action}
+}
+
+// ImageMessage is a message with an image hosted at OriginalContentURL.
+type ImageMessage struct {
+	Type               string `json:"type"`
+	OriginalContentURL string `json:"originalContentUrl"`
+	PreviewImageURL    string `json:"previewImageUrl"`
+}
+
+func (m *ImageMessage) MessageType() string { return m.Type }
+
+// NewImage creates an image message, @preview defaults to @original if empty.
+func NewImage(original, preview string) *ImageMessage {
+	if preview == "" {
+		preview = original
+	}
+	return &ImageMessage{Type: MessageImage, OriginalContentURL: original, PreviewImageURL: preview}
+}
+
+// StickerMessage is a message with a LINE sticker.
+type StickerMessage struct {
+	Type      string `json:"type"`
+	PackageID string `json:"packageId"`
+	StickerID string `json:"stickerId"`
+}
+
+func (m *StickerMessage) MessageType() string { return m.Type }
+
+// NewSticker creates a sticker message.
+func NewSticker(packageID, stickerID string) *StickerMessage {
+	return &StickerMessage{Type: MessageSticker, PackageID: packageID, StickerID: stickerID}
+}
+
+// UserProfile is the user profile returned by the profile endpoints.
+type UserProfile struct {
+	UserID        string `json:"userId"`
+	DisplayName   string `json:"displayName"`
+	PictureURL    string `json:"pictureUrl,omitempty"`
+	StatusMessage string `json:"statusMessage,omitempty"`
+	Language      string `json:"language,omitempty"`
+}
+
+// GroupProfile is the summary of a group.
+type GroupProfile struct {
+	GroupID    string `json:"groupId"`
+	GroupName  string `json:"groupName"`
+	PictureURL string `json:"pictureUrl,omitempty"`
+}
@@ -0,0 +1,133 @@
+/*
+	richmenu.go
+	Purpose: Rich menu endpoints of the LINE Messaging API.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package line
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"app/core/errors"
+	"app/core/flex"
+)
+
+// RichMenu is the menu shown at the bottom of the chat.
+//
+//   - https://developers.line.biz/en/reference/messaging-api/#rich-menu
+type RichMenu struct {
+	RichMenuID  string         `json:"richMenuId,omitempty"`
+	Size        RichMenuSize   `json:"size"`
+	Selected    bool           `json:"selected"`
+	Name        string         `json:"name"`
+	ChatBarText string         `json:"chatBarText"`
+	Areas       []RichMenuArea `json:"areas"`
+}
+
+// RichMenuSize is the size of the rich menu image, e.g. 2500x1686 or 2500x843.
+type RichMenuSize struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// RichMenuArea is a tappable area of a rich menu.
+type RichMenuArea struct {
+	Bounds RichMenuBounds `json:"bounds"`
+	Action *flex.Action   `json:"action"`
+}
+
+// RichMenuBounds is the position of an area in pixels of the image.
+type RichMenuBounds struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// RichMenus lists the rich menus of the channel.
+func (c *Client) RichMenus(ctx context.Context) ([]*RichMenu, error) {
+	res := &struct {
+		RichMenus []*RichMenu `json:"richmenus"`
+	}{}
+	err := c.get(ctx, "/v2/bot/richmenu/list", res)
+	return res.RichMenus, err
+}
+
+// CreateRichMenu creates a rich menu and returns its ID, an image should be uploaded before using it.
+func (c *Client) CreateRichMenu(ctx context.Context, menu *RichMenu) (string, error) {
+	body, err := json.Marshal(menu)
+	if err != nil {
+		return "", errors.ErrBadRequest.SetInfo(err.Error())
+	}
+	res := &struct {
+		RichMenuID string `json:"richMenuId"`
+	}{}
+	err = c.request(ctx, http.MethodPost, c.opt.BaseURL+"/v2/bot/richmenu", "application/json", body, "", res)
+	return res.RichMenuID, err
+}
+
+// UploadRichMenuImage uploads the image of a rich menu, @contentType is either image/png or image/jpeg.
+func (c *Client) UploadRichMenuImage(ctx context.Context, richMenuID, contentType string, image []byte) error {
+	return c.request(ctx, http.MethodPost,
+		c.opt.DataURL+"/v2/bot/richmenu/"+url.PathEscape(richMenuID)+"/content", contentType, image, "", nil)
+}
+
+// DeleteRichMenu deletes a rich menu.
+func (c *Client) DeleteRichMenu(ctx context.Context, richMenuID string) error {
+	return c.request(ctx, http.MethodDelete, c.opt.BaseURL+"/v2/bot/richmenu/"+url.PathEscape(richMenuID), "", nil, "", nil)
+}
+
+// DefaultRichMenu gets the ID of the default rich menu, empty string is returned if not set.
+func (c *Client) DefaultRichMenu(ctx context.Context) (string, error) {
+	res := &struct {
+		RichMenuID string `json:"richMenuId"`
+	}{}
+	err := c.get(ctx, "/v2/bot/user/all/richmenu", res)
+	if isNotFound(err) {
+		return "", nil
+	}
+	return res.RichMenuID, err
+}
+
+// SetDefaultRichMenu sets the rich menu shown to users without a linked menu.
+func (c *Client) SetDefaultRichMenu(ctx context.Context, richMenuID string) error {
+	return c.request(ctx, http.MethodPost, c.opt.BaseURL+"/v2/bot/user/all/richmenu/"+url.PathEscape(richMenuID), "", nil, "", nil)
+}
+
+// UserRichMenu gets the ID of the rich menu linked to a user, empty string is returned if not linked.
+func (c *Client) UserRichMenu(ctx context.Context, userID string) (string, error) {
+	res := &struct {
+		RichMenuID string `json:"richMenuId"`
+	}{}
+	err := c.get(ctx, "/v2/bot/user/"+url.PathEscape(userID)+"/richmenu", res)
+	if isNotFound(err) {
+		return "", nil
+	}
+	return res.RichMenuID, err
+}
+
+// LinkRichMenu links a rich menu to a user, which takes precedence over the default one.
+func (c *Client) LinkRichMenu(ctx context.Context, userID, richMenuID string) error {
+	return c.request(ctx, http.MethodPost,
+		c.opt.BaseURL+"/v2/bot/user/"+url.PathEscape(userID)+"/richmenu/"+url.PathEscape(richMenuID), "", nil, "", nil)
+}
+
+// UnlinkRichMenu unlinks the rich menu of a user.
+func (c *Client) UnlinkRichMenu(ctx context.Context, userID string) error {
+	return c.request(ctx, http.MethodDelete, c.opt.BaseURL+"/v2/bot/user/"+url.PathEscape(userID)+"/richmenu", "", nil, "", nil)
+}
+
+func isNotFound(err error) bool {
+	e, ok := err.(*errors.Error)
+	return ok && e.Code == errors.ErrNotFound.Code
+}
@@ -0,0 +1,116 @@
+/*
+	webhook.go
+	Purpose: Receive LINE webhook events and emit them through the signal system.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Mark duplicated events with a deduplicator
+*/
+
+// Package line integrates the LINE Messaging API.
+package line
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"app/core/config"
+	"app/core/errors"
+	"app/core/property"
+	"app/core/server"
+	"app/core/signal"
+
+	"golang.org/x/exp/slog"
+)
+
+// SignatureHeader is the header LINE puts the request signature in.
+const SignatureHeader = "X-Line-Signature"
+
+// maxWebhookBody limits the size of a webhook request body.
+const maxWebhookBody = 1 << 20
+
+// VerifySignature checks if @signature is the base64 encoded HMAC-SHA256 of @body keyed by @secret.
+func VerifySignature(secret, body []byte, signature string) bool {
+	if len(secret) == 0 || signature == "" {
+		return false
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(sig, mac.Sum(nil))
+}
+
+// Sign returns the signature of @body keyed by @secret,
+// which is the value LINE would put in the [SignatureHeader].
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Deduplicator records the webhook event ID of @evt and reports whether it has been received before.
+type Deduplicator func(ctx context.Context, evt *Event) (duplicate bool)
+
+var dedup Deduplicator
+
+// SetDeduplicator sets how events received before are detected, events are never duplicate if not set.
+func SetDeduplicator(f Deduplicator) { dedup = f }
+
+// Webhook is the http handler for the LINE webhook.
+//
+// It verifies the request with the channel secret set by `property.LINE_CHANNEL_SECRET`,
+// and emits every event to its [Event.Topic].
+func Webhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		server.HttpAbort(w, r, errors.ErrBadRequest.SetInfo(err.Error()))
+		return
+	}
+
+	secret := config.GetString(property.LINE_CHANNEL_SECRET)
+	if !VerifySignature([]byte(secret), body, r.Header.Get(SignatureHeader)) {
+		slog.Warn("invalid webhook signature",
+			slog.String("mod", "line"), slog.String("act", "webhook"),
+			slog.String("ip", r.RemoteAddr))
+		server.HttpAbort(w, r, errors.ErrUnauthorized)
+		return
+	}
+
+	hook := &WebhookRequest{}
+	if err := json.Unmarshal(body, hook); err != nil {
+		server.HttpAbort(w, r, errors.ErrBadRequest.SetInfo(err.Error()))
+		return
+	}
+
+	for _, evt := range hook.Events {
+		if dedup != nil && evt.WebhookEventID != "" {
+			evt.Duplicate = dedup(r.Context(), evt)
+		}
+		slog.Debug("webhook event received",
+			slog.String("mod", "line"), slog.String("act", "webhook"),
+			slog.String("topic", evt.Topic()),
+			slog.String("id", evt.WebhookEventID),
+			slog.Bool("redelivery", evt.DeliveryContext.IsRedelivery),
+			slog.Bool("duplicate", evt.Duplicate))
+		signal.Emit(evt.Topic(), evt)
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
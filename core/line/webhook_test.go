@@ -0,0 +1,99 @@
+package line
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"app/core/config"
+	"app/core/property"
+	"app/core/signal"
+)
+
+func TestVerifySignature(t *testing.T) {
+	secret, body := []byte("secret"), []byte(`{"events":[]}`)
+	valid := Sign(secret, body)
+	tests := []struct {
+		name   string
+		secret []byte
+		body   []byte
+		sig    string
+		want   bool
+	}{
+		{"valid", secret, body, valid, true},
+		{"missing signature", secret, body, "", false},
+		{"no secret", nil, body, Sign(nil, body), false},
+		{"wrong secret", []byte("other"), body, valid, false},
+		{"body changed", secret, []byte(`{"events":[{}]}`), valid, false},
+		{"not base64", secret, body, "%%%", false},
+		{"truncated", secret, body, valid[:len(valid)-4], false},
+		{"hex instead of base64", secret, body, strings.Repeat("0", 64), false},
+	}
+	for _, tt := range tests {
+		if got := VerifySignature(tt.secret, tt.body, tt.sig); got != tt.want {
+			t.Errorf("%s: VerifySignature = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	config.Set(property.LINE_CHANNEL_SECRET, "secret")
+	defer SetDeduplicator(nil)
+	seen := map[string]bool{}
+	SetDeduplicator(func(_ context.Context, evt *Event) bool {
+		dup := seen[evt.WebhookEventID]
+		seen[evt.WebhookEventID] = true
+		return dup
+	})
+	events := signal.On("line.follow")
+	defer signal.Off("line.follow", events)
+
+	body := `{"destination":"U0","events":[{"type":"follow","webhookEventId":"E1","source":{"type":"user","userId":"U1"}}]}`
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		sig    string
+		status int
+		// dup is whether the event is emitted as a duplicate, nil if not emitted
+		dup *bool
+	}{
+		{"valid", http.MethodPost, body, Sign([]byte("secret"), []byte(body)), http.StatusOK, ptr(false)},
+		{"delivered again", http.MethodPost, body, Sign([]byte("secret"), []byte(body)), http.StatusOK, ptr(true)},
+		{"missing signature", http.MethodPost, body, "", http.StatusUnauthorized, nil},
+		{"signed by another secret", http.MethodPost, body, Sign([]byte("other"), []byte(body)), http.StatusUnauthorized, nil},
+		{"body changed", http.MethodPost, strings.Replace(body, "U1", "U2", 1), Sign([]byte("secret"), []byte(body)), http.StatusUnauthorized, nil},
+		{"not json", http.MethodPost, "{", Sign([]byte("secret"), []byte("{")), http.StatusBadRequest, nil},
+		{"get", http.MethodGet, "", "", http.StatusMethodNotAllowed, nil},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, "/webhook", bytes.NewBufferString(tt.body))
+		if tt.sig != "" {
+			req.Header.Set(SignatureHeader, tt.sig)
+		}
+		rec := httptest.NewRecorder()
+		Webhook(rec, req)
+		if rec.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d", tt.name, rec.Code, tt.status)
+		}
+		select {
+		case e := <-events:
+			evt := e.Args[0].(*Event)
+			if tt.dup == nil {
+				t.Errorf("%s: emitted %+v", tt.name, evt)
+			} else if evt.Duplicate != *tt.dup || evt.Source.UserID != "U1" {
+				t.Errorf("%s: emitted duplicate %v from %s, want %v from U1", tt.name, evt.Duplicate, evt.Source.UserID, *tt.dup)
+			}
+		case <-time.After(50 * time.Millisecond):
+			if tt.dup != nil {
+				t.Errorf("%s: not emitted", tt.name)
+			}
+		}
+	}
+}
+
+func ptr(b bool) *bool { return &b }
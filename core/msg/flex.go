@@ -0,0 +1,76 @@
+/*
+	flex.go
+
+	Purpose: To render localized flex messages from message packs.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package msg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"app/core/flex"
+	"app/core/property"
+)
+
+// flexFuncs are the extra functions for flex templates,
+// as values written into json strings should be escaped.
+//
+//   - {{json .}} writes the value as json, e.g. "text": {{json .Title}}
+//   - {{esc .}} escapes a string to be put inside quotes, e.g. "text": "{{esc .Title}} ({{.Count}})"
+var flexFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"esc": func(v any) string {
+		b, _ := json.Marshal(fmt.Sprint(v))
+		return strings.TrimSuffix(strings.TrimPrefix(string(b), `"`), `"`)
+	},
+}
+
+// Flex renders the flex template of @key into a flex message, with the text template of @key as its alt text.
+//
+//   - It will use the default locale if the given locale is not found.
+//   - An error is returned if the template is not found, fails to render or exceeds the flex limits.
+func Flex(key, locale string, data any) (*flex.Message, error) {
+	tmpl, ok := flexStore[locale][key]
+	if !ok {
+		if tmpl, ok = flexStore[property.DefaultLocale][key]; !ok {
+			return nil, fmt.Errorf("flex template %s not found", key)
+		}
+		locale = property.DefaultLocale
+	}
+
+	buff := &bytes.Buffer{}
+	if err := tmpl.Execute(buff, data); err != nil {
+		return nil, fmt.Errorf("flex template %s: %w", key, err)
+	}
+	contents, err := flex.Unmarshal(buff.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("flex template %s: %w", key, err)
+	}
+
+	alt := []rune(T(key, locale, data))
+	if len(alt) == 0 {
+		alt = []rune(key)
+	} else if len(alt) > flex.MaxAltText {
+		alt = append(alt[:flex.MaxAltText-1], '…')
+	}
+	m := flex.NewMessage(string(alt), contents)
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("flex template %s: %w", key, err)
+	}
+	return m, nil
+}
@@ -9,6 +9,9 @@
 	   Date        Ver    Name     Description
 	---------- ------- ----------- -------------------------------------------
 	2023/03/02  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.1.0 Evan Chen   Add flex templates
+	2026/10/17  v1.1.1 Evan Chen   Add HasLocale
+	2026/10/17  v1.1.2 Evan Chen   Add Pack.Add to build packs in code
 */
 
 // Package msg is a package to generate translated messages
@@ -39,13 +42,26 @@ type impMsg struct {
 	Key    string `json:"key"`
 	Locale string `json:"locale"`
 	Tmpl   string `json:"tmpl"`
+	// Flex is the template of a flex container, and Tmpl is used as its alt text.
+	// It could either be a json object, or a string if it needs actions like {{range}} outside json strings.
+	Flex json.RawMessage `json:"flex,omitempty"`
+}
+
+// Add adds the message @key with the text template @tmpl and an optional flex template, and returns the pack.
+func (pk *Pack) Add(key, tmpl string, flex json.RawMessage) *Pack {
+	pk.Messages = append(pk.Messages, impMsg{Key: key, Tmpl: tmpl, Flex: flex})
+	return pk
 }
 
 // store is the collection of current registered messages
 var store map[string]map[string]*template.Template
 
+// flexStore is the collection of current registered flex templates
+var flexStore map[string]map[string]*template.Template
+
 func init() {
 	store = make(map[string]map[string]*template.Template)
+	flexStore = make(map[string]map[string]*template.Template)
 }
 
 // T is a function to translate messages, and any occurred errors will only be logged.
@@ -83,6 +99,12 @@ func Tctx(ctx context.Context, key string, data any) string {
 	return T(key, property.DefaultLocale, data)
 }
 
+// HasLocale checks if any message pack of @locale is loaded.
+func HasLocale(locale string) bool {
+	_, ok := store[locale]
+	return ok
+}
+
 type plain struct {
 	Info string
 }
@@ -114,11 +136,25 @@ func Load(pk *Pack) error {
 		if err != nil {
 			return err
 		}
+		locale := pklocal
 		if msg.Locale != "" {
-			store_locale(msg.Locale)
-			store[msg.Locale][msg.Key] = tmpl
-		} else {
-			store[pklocal][msg.Key] = tmpl
+			locale = msg.Locale
+			store_locale(locale)
+		}
+		store[locale][msg.Key] = tmpl
+
+		if len(msg.Flex) > 0 {
+			src := string(msg.Flex)
+			if msg.Flex[0] == '"' {
+				if err := json.Unmarshal(msg.Flex, &src); err != nil {
+					return err
+				}
+			}
+			ftmpl, err := template.New("").Funcs(flexFuncs).Parse(src)
+			if err != nil {
+				return fmt.Errorf("flex template %s: %w", msg.Key, err)
+			}
+			flexStore[locale][msg.Key] = ftmpl
 		}
 	}
 
@@ -130,6 +166,9 @@ func store_locale(locale string) {
 	if exist := store[locale]; exist == nil {
 		store[locale] = make(map[string]*template.Template)
 	}
+	if exist := flexStore[locale]; exist == nil {
+		flexStore[locale] = make(map[string]*template.Template)
+	}
 }
 
 // LoadFS is like Load, but it loads message packs from the given fs.FS
@@ -0,0 +1,281 @@
+/*
+	outbox.go
+	Purpose: Deliver outbound messages from the outbox with retries.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Hold pushes with a Holder, e.g. quiet hours
+*/
+
+// Package outbox persists outbound pushes before they are sent, so they are not lost on crashes.
+//
+// Skills call [Enqueue] in the same transaction as their state change,
+// and the worker of [Service] delivers the messages with retries.
+// Messages failed after [Option.MaxAttempts] are marked dead and could be requeued by admins.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"app/core/database"
+	"app/core/errors"
+	"app/core/line"
+	"app/core/util"
+
+	"golang.org/x/exp/slog"
+)
+
+// Option configures the worker.
+type Option struct {
+	// MaxAttempts is how many failed attempts a message is marked dead after.
+	MaxAttempts int
+	// Backoff is the wait before the first retry, it doubles on every retry up to an hour.
+	Backoff time.Duration
+	// Interval is the longest time the worker sleeps between checks.
+	Interval time.Duration
+	// FlushTimeout is how long the worker keeps delivering pending messages on shutdown.
+	FlushTimeout time.Duration
+}
+
+var opt = Option{
+	MaxAttempts:  8,
+	Backoff:      30 * time.Second,
+	Interval:     30 * time.Second,
+	FlushTimeout: 5 * time.Second,
+}
+
+// Setup sets the options, empty fields are left unchanged.
+func Setup(o *Option) {
+	if o.MaxAttempts > 0 {
+		opt.MaxAttempts = o.MaxAttempts
+	}
+	if o.Backoff > 0 {
+		opt.Backoff = o.Backoff
+	}
+	if o.Interval > 0 {
+		opt.Interval = o.Interval
+	}
+	if o.FlushTimeout > 0 {
+		opt.FlushTimeout = o.FlushTimeout
+	}
+}
+
+// maxBackoff caps the wait between retries.
+const maxBackoff = time.Hour
+
+// batch is how many messages are delivered in a round.
+const batch = 50
+
+// Push enqueues a push of @msgs to @to outside of any transaction and wakes up the worker.
+func Push(ctx context.Context, to string, msgs ...line.Message) error {
+	if _, err := Enqueue(ctx, database.DB(), to, msgs...); err != nil {
+		return err
+	}
+	Notify()
+	return nil
+}
+
+// Holder returns until when pushes to @to should be held at @now, e.g. the end of quiet hours of a group.
+// A zero time or a time not after @now means the push is not held.
+type Holder func(ctx context.Context, to string, now time.Time) time.Time
+
+var hold Holder
+
+// SetHolder sets how pushes are held, held messages are postponed without counting as failed attempts.
+func SetHolder(f Holder) { hold = f }
+
+// Notify wakes up the worker to deliver newly committed messages.
+func Notify() {
+	select {
+	case Service.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Service is the [service.Service] which runs the worker.
+var Service = &worker{wake: make(chan struct{}, 1)}
+
+type worker struct {
+	lock sync.Mutex
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+func (w *worker) Init() error { return nil }
+
+// Load starts the worker if not started.
+func (w *worker) Load() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.stop != nil {
+		return
+	}
+	w.stop, w.done = make(chan struct{}), make(chan struct{})
+	go w.run(w.stop, w.done)
+}
+
+// Del stops the worker and flushes the pending messages within [Option.FlushTimeout].
+func (w *worker) Del() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.stop == nil {
+		return
+	}
+	close(w.stop)
+	<-w.done
+	w.stop = nil
+
+	ctx, cancel := context.WithTimeout(context.Background(), opt.FlushTimeout)
+	defer cancel()
+	for ctx.Err() == nil {
+		if n := deliver(ctx, time.Now()); n == 0 {
+			break
+		}
+	}
+}
+
+func (w *worker) run(stop, done chan struct{}) {
+	defer close(done)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stop
+		cancel()
+	}()
+
+	for {
+		deliver(ctx, time.Now())
+
+		wait := opt.Interval
+		if t, ok, err := next(ctx); err == nil && ok {
+			if d := time.Until(t); d < wait {
+				wait = d
+			}
+		}
+		if wait < time.Second {
+			wait = time.Second
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-w.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// deliver delivers a batch of due messages, it returns how many were attempted.
+func deliver(ctx context.Context, now time.Time) int {
+	list, err := due(ctx, now, batch)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("list outbox failed", util.ErrAtrr(err), slog.String("mod", "outbox"), slog.String("act", "deliver"))
+		}
+		return 0
+	}
+	for i, m := range list {
+		if ctx.Err() != nil {
+			return i
+		}
+		send(ctx, m)
+	}
+	return len(list)
+}
+
+// send pushes @m with its retry key, and records the outcome.
+func send(ctx context.Context, m *Message) {
+	if held(ctx, m) {
+		return
+	}
+
+	msgs := make([]line.Message, len(m.Messages))
+	for i, raw := range m.Messages {
+		msgs[i] = rawMessage(raw)
+	}
+	err := line.PushWithKey(ctx, m.RetryKey, m.To, msgs...)
+	if err != nil && ctx.Err() != nil {
+		// interrupted by shutdown, leave it pending to be flushed or delivered on next start
+		return
+	}
+	// the outcome is recorded even if ctx is cancelled afterwards
+	rec := context.Background()
+	if err == nil {
+		if err := markSent(rec, m.ID); err != nil {
+			slog.Error("mark sent failed", util.ErrAtrr(err), slog.String("mod", "outbox"), slog.String("act", "deliver"), slog.String("id", m.ID))
+		}
+		slog.Info("message delivered", slog.String("mod", "outbox"), slog.String("act", "deliver"),
+			slog.String("id", m.ID), slog.Int("attempts", m.Attempts+1))
+		return
+	}
+
+	dead := m.Attempts+1 >= opt.MaxAttempts || permanent(err)
+	retryAt := time.Now().Add(backoff(m.Attempts))
+	if err := markFailed(rec, m.ID, err, retryAt, dead); err != nil {
+		slog.Error("mark failed failed", util.ErrAtrr(err), slog.String("mod", "outbox"), slog.String("act", "deliver"), slog.String("id", m.ID))
+	}
+	args := []any{util.ErrAtrr(err), slog.String("mod", "outbox"), slog.String("act", "deliver"),
+		slog.String("id", m.ID), slog.Int("attempts", m.Attempts+1)}
+	if dead {
+		slog.Error("message dead", args...)
+		return
+	}
+	slog.Warn("message delivery failed", append(args, slog.Time("retry_at", retryAt))...)
+}
+
+// held postpones @m if pushes to its recipient are held by the [Holder].
+func held(ctx context.Context, m *Message) bool {
+	if hold == nil {
+		return false
+	}
+	now := time.Now()
+	until := hold(ctx, m.To, now)
+	if !until.After(now) {
+		return false
+	}
+	if err := postpone(ctx, m.ID, until); err != nil {
+		slog.Error("postpone failed", util.ErrAtrr(err), slog.String("mod", "outbox"), slog.String("act", "deliver"), slog.String("id", m.ID))
+		return true
+	}
+	slog.Info("message held", slog.String("mod", "outbox"), slog.String("act", "deliver"),
+		slog.String("id", m.ID), slog.Time("until", until))
+	return true
+}
+
+// backoff returns the wait after @attempts failed attempts.
+func backoff(attempts int) time.Duration {
+	d := opt.Backoff << attempts
+	if d > maxBackoff || d <= 0 {
+		d = maxBackoff
+	}
+	return d
+}
+
+// permanent checks if @err will not be solved by retrying, e.g. an invalid message.
+func permanent(err error) bool {
+	e, ok := err.(*errors.Error)
+	return ok && e.Code == errors.ErrBadRequest.Code
+}
+
+// rawMessage is a message object already encoded as json.
+type rawMessage json.RawMessage
+
+func (m rawMessage) MessageType() string {
+	t := &struct {
+		Type string `json:"type"`
+	}{}
+	json.Unmarshal(m, t)
+	return t.Type
+}
+
+func (m rawMessage) MarshalJSON() ([]byte, error) { return m, nil }
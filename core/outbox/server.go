@@ -0,0 +1,94 @@
+/*
+	server.go
+	Purpose: gRPC service for admins to manage failed messages.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package outbox
+
+import (
+	"context"
+	"strings"
+
+	"app/core/auth"
+	pb "app/service"
+
+	"golang.org/x/exp/slog"
+)
+
+func init() {
+	auth.Guard(auth.ADMIN,
+		pb.OutboxService_ListFailed_FullMethodName,
+		pb.OutboxService_Requeue_FullMethodName,
+	)
+}
+
+// defaultPageSize is the page size if not given by the pager.
+const defaultPageSize = 20
+
+// Server is the implementation of [pb.OutboxServiceServer].
+var Server = &server{}
+
+type server struct {
+	pb.UnimplementedOutboxServiceServer
+}
+
+func (*server) ListFailed(ctx context.Context, req *pb.ListFailedRequest) (*pb.ListFailedResponse, error) {
+	size, page := int32(defaultPageSize), int32(1)
+	if p := req.GetPager(); p != nil {
+		if p.Size > 0 {
+			size = p.Size
+		}
+		if p.Page > 0 {
+			page = p.Page
+		}
+	}
+	list, total, err := ListFailed(ctx, int((page-1)*size), int(size))
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.ListFailedResponse{
+		Messages: make([]*pb.OutboxMessage, len(list)),
+		Pager:    &pb.PagerResult{Size: size, Page: page, Total: int32(total)},
+	}
+	for i, m := range list {
+		raw := make([]string, len(m.Messages))
+		for j, msg := range m.Messages {
+			raw[j] = string(msg)
+		}
+		res.Messages[i] = &pb.OutboxMessage{
+			Id:        m.ID,
+			To:        m.To,
+			Messages:  "[" + strings.Join(raw, ",") + "]",
+			Status:    m.Status,
+			Attempts:  int32(m.Attempts),
+			LastError: m.LastError,
+			CreatedAt: m.CreatedAt.Unix(),
+			UpdatedAt: m.UpdatedAt.Unix(),
+		}
+	}
+	return res, nil
+}
+
+func (*server) Requeue(ctx context.Context, req *pb.RequeueRequest) (*pb.RequeueResponse, error) {
+	n, err := Requeue(ctx, req.GetIds()...)
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 {
+		Notify()
+	}
+	usr, _ := auth.GetUser(ctx)
+	args := []any{slog.String("mod", "outbox"), slog.String("act", "requeue"), slog.Int("count", n)}
+	if usr != nil {
+		args = append(args, slog.String("usr", usr.Username))
+	}
+	slog.Info("messages requeued", args...)
+	return &pb.RequeueResponse{Count: int32(n)}, nil
+}
@@ -0,0 +1,226 @@
+/*
+	store.go
+	Purpose: Persist outbound messages in the database.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Add postpone
+*/
+
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"app/core/database"
+	"app/core/errors"
+	"app/core/line"
+
+	"github.com/rs/xid"
+)
+
+func init() {
+	database.Register(&database.Migration{
+		ID: "20261017_outbox",
+		Stmts: []string{
+			`CREATE TABLE outbox_messages (
+				id TEXT PRIMARY KEY,
+				recipient TEXT NOT NULL,
+				messages TEXT NOT NULL,
+				retry_key TEXT NOT NULL,
+				status TEXT NOT NULL,
+				attempts INTEGER NOT NULL DEFAULT 0,
+				last_error TEXT NOT NULL DEFAULT '',
+				next_attempt_at BIGINT NOT NULL,
+				created_at BIGINT NOT NULL,
+				updated_at BIGINT NOT NULL
+			)`,
+			`CREATE INDEX idx_outbox_messages_status_next ON outbox_messages (status, next_attempt_at)`,
+		},
+	})
+}
+
+// Status of an outbound message.
+const (
+	PENDING = "pending" // waiting to be delivered
+	SENT    = "sent"    // delivered
+	DEAD    = "dead"    // failed after exhausting the retries, it could be requeued by admins
+)
+
+// Message is an outbound push in the outbox.
+type Message struct {
+	ID        string
+	To        string
+	Messages  []json.RawMessage
+	RetryKey  string
+	Status    string
+	Attempts  int
+	LastError string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Enqueue adds a push of @msgs to @to, it is delivered by the worker after @ex is committed.
+//
+// @ex is usually the transaction of [database.Tx] which changes the state the messages are about,
+// so either both or none of them are persisted. Call [Notify] after commit to deliver it without delay.
+func Enqueue(ctx context.Context, ex database.Executor, to string, msgs ...line.Message) (string, error) {
+	if len(msgs) == 0 {
+		return "", errors.ErrBadRequest.SetInfo("no messages")
+	}
+	body, err := json.Marshal(msgs)
+	if err != nil {
+		return "", errors.ErrBadRequest.SetInfo(err.Error())
+	}
+	id := xid.New().String()
+	now := time.Now().Unix()
+	_, err = ex.ExecContext(ctx, `INSERT INTO outbox_messages
+		(id, recipient, messages, retry_key, status, next_attempt_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6, $6)`,
+		id, to, string(body), line.NewRetryKey(), PENDING, now)
+	if err != nil {
+		return "", database.Err(err)
+	}
+	return id, nil
+}
+
+const columns = "id, recipient, messages, retry_key, status, attempts, last_error, created_at, updated_at"
+
+func scan(row interface{ Scan(...any) error }) (*Message, error) {
+	m := &Message{}
+	var body string
+	var created, updated int64
+	if err := row.Scan(&m.ID, &m.To, &body, &m.RetryKey, &m.Status, &m.Attempts, &m.LastError, &created, &updated); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(body), &m.Messages); err != nil {
+		return nil, err
+	}
+	m.CreatedAt, m.UpdatedAt = time.Unix(created, 0), time.Unix(updated, 0)
+	return m, nil
+}
+
+// due lists pending messages to be attempted at @now, oldest first.
+func due(ctx context.Context, now time.Time, limit int) ([]*Message, error) {
+	return query(ctx, "SELECT "+columns+" FROM outbox_messages WHERE status = $1 AND next_attempt_at <= $2 ORDER BY created_at, id LIMIT $3",
+		PENDING, now.Unix(), limit)
+}
+
+// next returns when the next pending message should be attempted, ok is false if there is none.
+func next(ctx context.Context) (t time.Time, ok bool, err error) {
+	var at sql.NullInt64
+	err = database.DB().QueryRowContext(ctx,
+		"SELECT MIN(next_attempt_at) FROM outbox_messages WHERE status = $1", PENDING).Scan(&at)
+	if err != nil || !at.Valid {
+		return t, false, database.Err(err)
+	}
+	return time.Unix(at.Int64, 0), true, nil
+}
+
+func markSent(ctx context.Context, id string) error {
+	_, err := database.DB().ExecContext(ctx,
+		"UPDATE outbox_messages SET status = $1, last_error = '', updated_at = $2 WHERE id = $3",
+		SENT, time.Now().Unix(), id)
+	return database.Err(err)
+}
+
+// postpone delays the next attempt of a pending message to @at, the attempts are not counted.
+func postpone(ctx context.Context, id string, at time.Time) error {
+	_, err := database.DB().ExecContext(ctx,
+		"UPDATE outbox_messages SET next_attempt_at = $1, updated_at = $2 WHERE id = $3 AND status = $4",
+		at.Unix(), time.Now().Unix(), id, PENDING)
+	return database.Err(err)
+}
+
+// markFailed records a failed attempt, the message is retried at @retryAt or dead if @dead.
+func markFailed(ctx context.Context, id string, cause error, retryAt time.Time, dead bool) error {
+	status := PENDING
+	if dead {
+		status = DEAD
+	}
+	_, err := database.DB().ExecContext(ctx, `UPDATE outbox_messages
+		SET status = $1, attempts = attempts + 1, last_error = $2, next_attempt_at = $3, updated_at = $4 WHERE id = $5`,
+		status, cause.Error(), retryAt.Unix(), time.Now().Unix(), id)
+	return database.Err(err)
+}
+
+// ListFailed lists dead messages, latest first, with the total count.
+func ListFailed(ctx context.Context, offset, limit int) ([]*Message, int, error) {
+	var total int
+	if err := database.DB().QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM outbox_messages WHERE status = $1", DEAD).Scan(&total); err != nil {
+		return nil, 0, database.Err(err)
+	}
+	list, err := query(ctx, "SELECT "+columns+" FROM outbox_messages WHERE status = $1 ORDER BY updated_at DESC, id DESC LIMIT $2 OFFSET $3",
+		DEAD, limit, offset)
+	return list, total, err
+}
+
+// Requeue puts the dead messages of @ids back to pending, every dead message if @ids is empty.
+// A new retry key is used, as the old one may have expired at LINE.
+func Requeue(ctx context.Context, ids ...string) (int, error) {
+	n := 0
+	err := database.Tx(ctx, func(tx *sql.Tx) error {
+		if len(ids) == 0 {
+			rows, err := tx.QueryContext(ctx, "SELECT id FROM outbox_messages WHERE status = $1", DEAD)
+			if err != nil {
+				return err
+			}
+			for rows.Next() {
+				var id string
+				if err := rows.Scan(&id); err != nil {
+					rows.Close()
+					return err
+				}
+				ids = append(ids, id)
+			}
+			rows.Close()
+			if err := rows.Err(); err != nil {
+				return err
+			}
+		}
+		now := time.Now().Unix()
+		for _, id := range ids {
+			res, err := tx.ExecContext(ctx, `UPDATE outbox_messages
+				SET status = $1, attempts = 0, retry_key = $2, next_attempt_at = $3, updated_at = $3 WHERE id = $4 AND status = $5`,
+				PENDING, line.NewRetryKey(), now, id, DEAD)
+			if err != nil {
+				return err
+			}
+			c, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+			n += int(c)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, database.Err(err)
+	}
+	return n, nil
+}
+
+func query(ctx context.Context, q string, args ...any) ([]*Message, error) {
+	rows, err := database.DB().QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	defer rows.Close()
+	list := []*Message{}
+	for rows.Next() {
+		m, err := scan(rows)
+		if err != nil {
+			return nil, database.Err(err)
+		}
+		list = append(list, m)
+	}
+	return list, database.Err(rows.Err())
+}
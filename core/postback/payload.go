@@ -0,0 +1,151 @@
+/*
+	payload.go
+	Purpose: Encode, sign and verify the data of postback actions.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package postback
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"app/core/auth"
+)
+
+// prefix marks the data made by [Data], other data is left to other handlers.
+const prefix = "pb1|"
+
+// sigSize is the bytes of the truncated signature, which is 22 characters in base64.
+const sigSize = 16
+
+// Errors of verifying a payload.
+var (
+	ErrInvalid = errors.New("postback: invalid payload")
+	ErrExpired = errors.New("postback: payload expired")
+)
+
+// Args are the arguments of an action, kept as strings and read back with the typed getters.
+// The getters return the zero value if an argument is missing,
+// malformed values could not happen since the payload is signed.
+type Args map[string]string
+
+// Set sets @key to @v formatted by its type, e.g. a [time.Time] is kept as unix seconds, and returns the args.
+func (a Args) Set(key string, v any) Args {
+	switch v := v.(type) {
+	case string:
+		a[key] = v
+	case bool:
+		a[key] = strconv.FormatBool(v)
+	case time.Time:
+		a[key] = strconv.FormatInt(v.Unix(), 10)
+	case time.Duration:
+		a[key] = v.String()
+	default:
+		a[key] = fmt.Sprint(v)
+	}
+	return a
+}
+
+// String returns the argument @key.
+func (a Args) String(key string) string {
+	return a[key]
+}
+
+// Int returns the argument @key as an int.
+func (a Args) Int(key string) int {
+	n, _ := strconv.Atoi(a[key])
+	return n
+}
+
+// Int64 returns the argument @key as an int64.
+func (a Args) Int64(key string) int64 {
+	n, _ := strconv.ParseInt(a[key], 10, 64)
+	return n
+}
+
+// Float returns the argument @key as a float64.
+func (a Args) Float(key string) float64 {
+	f, _ := strconv.ParseFloat(a[key], 64)
+	return f
+}
+
+// Bool returns the argument @key as a bool.
+func (a Args) Bool(key string) bool {
+	b, _ := strconv.ParseBool(a[key])
+	return b
+}
+
+// Time returns the argument @key set as a [time.Time], in the local time zone.
+func (a Args) Time(key string) time.Time {
+	n, err := strconv.ParseInt(a[key], 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(n, 0)
+}
+
+// Duration returns the argument @key set as a [time.Duration].
+func (a Args) Duration(key string) time.Duration {
+	d, _ := time.ParseDuration(a[key])
+	return d
+}
+
+// sign signs the action @name, its encoded args and expiry with [auth.Secret].
+func sign(name, args, exp string) string {
+	h := hmac.New(sha256.New, auth.Secret)
+	h.Write([]byte(name + "|" + args + "|" + exp))
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil)[:sigSize])
+}
+
+// encode makes the payload "pb1|name|args|exp|sig" of action @name, which expires at @exp.
+func encode(name string, args Args, exp time.Time) string {
+	q := url.Values{}
+	for k, v := range args {
+		q.Set(k, v)
+	}
+	enc, e := q.Encode(), strconv.FormatInt(exp.Unix(), 36)
+	return prefix + name + "|" + enc + "|" + e + "|" + sign(name, enc, e)
+}
+
+// decode verifies @data at @now and returns the action name and args.
+// [ErrExpired] is only returned for payloads with a valid signature.
+func decode(data string, now time.Time) (string, Args, error) {
+	fields := strings.Split(strings.TrimPrefix(data, prefix), "|")
+	if !strings.HasPrefix(data, prefix) || len(fields) != 4 {
+		return "", nil, ErrInvalid
+	}
+	name, enc, e, sig := fields[0], fields[1], fields[2], fields[3]
+	if !hmac.Equal([]byte(sig), []byte(sign(name, enc, e))) {
+		return "", nil, ErrInvalid
+	}
+	exp, err := strconv.ParseInt(e, 36, 64)
+	if err != nil {
+		return "", nil, ErrInvalid
+	}
+	q, err := url.ParseQuery(enc)
+	if err != nil {
+		return "", nil, ErrInvalid
+	}
+	if now.Unix() > exp {
+		return name, nil, ErrExpired
+	}
+	args := Args{}
+	for k := range q {
+		args[k] = q.Get(k)
+	}
+	return name, args, nil
+}
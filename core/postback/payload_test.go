@@ -0,0 +1,99 @@
+package postback
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"app/core/auth"
+)
+
+func TestDecode(t *testing.T) {
+	now := time.Date(2026, 10, 17, 10, 30, 0, 0, time.UTC)
+	args := Args{"id": "db9m1lfh7ojognlbqje0", "note": "a|b&c=d 完成"}
+	valid := encode("todo.done", args, now.Add(time.Hour))
+	fields := strings.Split(valid, "|")
+	join := func(f ...string) string { return strings.Join(f, "|") }
+
+	other := auth.Secret
+	auth.Secret = []byte("another secret")
+	foreign := encode("todo.done", args, now.Add(time.Hour))
+	auth.Secret = other
+
+	tests := []struct {
+		name string
+		data string
+		at   time.Time
+		err  error
+	}{
+		{"valid", valid, now, nil},
+		{"valid until the second it expires", valid, now.Add(time.Hour), nil},
+		{"expired", valid, now.Add(time.Hour + time.Second), ErrExpired},
+		{"args changed", join(fields[0], fields[1], "id=db9m1lfh7ojognlbqje1", fields[3], fields[4]), now, ErrInvalid},
+		{"args added", join(fields[0], fields[1], fields[2]+"&admin=1", fields[3], fields[4]), now, ErrInvalid},
+		{"action changed", join(fields[0], "todo.delete", fields[2], fields[3], fields[4]), now, ErrInvalid},
+		{"expiry extended", join(fields[0], fields[1], fields[2], "zzzzzz", fields[4]), now.Add(2 * time.Hour), ErrInvalid},
+		// a forged payload is invalid rather than expired, even if its expiry is in the past
+		{"expired and tampered", join(fields[0], "todo.delete", fields[2], fields[3], fields[4]), now.Add(2 * time.Hour), ErrInvalid},
+		{"signature changed", join(fields[0], fields[1], fields[2], fields[3], strings.Repeat("A", len(fields[4]))), now, ErrInvalid},
+		{"signature removed", join(fields[0], fields[1], fields[2], fields[3], ""), now, ErrInvalid},
+		{"signed by another secret", foreign, now, ErrInvalid},
+		{"no prefix", strings.TrimPrefix(valid, prefix), now, ErrInvalid},
+		{"too many fields", valid + "|x", now, ErrInvalid},
+		{"free-form data", "action=done&id=1", now, ErrInvalid},
+		{"empty", "", now, ErrInvalid},
+	}
+	for _, tt := range tests {
+		name, got, err := decode(tt.data, tt.at)
+		if err != tt.err {
+			t.Errorf("%s: decode = %v, want %v", tt.name, err, tt.err)
+			continue
+		}
+		if err == nil && (name != "todo.done" || got.String("id") != args["id"] || got.String("note") != args["note"] || len(got) != 2) {
+			t.Errorf("%s: decode = %s %v, want todo.done %v", tt.name, name, got, args)
+		}
+	}
+}
+
+func TestData(t *testing.T) {
+	defer func(old map[string]*Action) { actions = old }(actions)
+	actions = map[string]*Action{}
+	Register(&Action{Name: "short", TTL: time.Minute}, &Action{Name: "long"})
+
+	now := time.Now()
+	tests := []struct {
+		name  string
+		after time.Duration
+		err   error
+	}{
+		{"short", 30 * time.Second, nil},
+		{"short", 2 * time.Minute, ErrExpired},
+		{"long", 2 * time.Minute, nil},
+		{"long", opt.TTL + time.Minute, ErrExpired},
+	}
+	for _, tt := range tests {
+		data := Data(tt.name, Args{"id": "1"})
+		if len(data) > 300 {
+			t.Errorf("%s: data of %d characters is over the limit of LINE", tt.name, len(data))
+		}
+		if _, _, err := decode(data, now.Add(tt.after)); err != tt.err {
+			t.Errorf("%s after %s: decode = %v, want %v", tt.name, tt.after, err, tt.err)
+		}
+	}
+}
+
+func TestArgs(t *testing.T) {
+	at := time.Unix(1792225800, 0)
+	a := Args{}.Set("s", "x").Set("n", 42).Set("f", 1.5).Set("b", true).Set("t", at).Set("d", 10*time.Minute)
+	_, got, err := decode(encode("a", a, time.Now().Add(time.Minute)), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String("s") != "x" || got.Int("n") != 42 || got.Int64("n") != 42 || got.Float("f") != 1.5 || !got.Bool("b") ||
+		!got.Time("t").Equal(at) || got.Duration("d") != 10*time.Minute {
+		t.Errorf("args = %v", got)
+	}
+	if got.Int("missing") != 0 || !got.Time("missing").IsZero() || got.Bool("s") {
+		t.Errorf("missing or malformed args are not zero")
+	}
+}
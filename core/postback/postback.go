@@ -0,0 +1,188 @@
+/*
+	postback.go
+	Purpose: Register named actions and dispatch signed postbacks to them.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Recover panics of the handlers
+*/
+
+// Package postback dispatches the postbacks of buttons and quick replies to registered actions.
+//
+// The data of a postback is free-form and could be forged by a crafted client,
+// so the buttons made by [Button], [QuickReply] or [Data] carry a compact payload
+// with the action name, its [Args] and an expiry, signed with [auth.Secret].
+// The secret should be set or kept by [auth.LoadSecret], otherwise the buttons are rejected after a restart.
+// Incoming postbacks are verified before dispatched to the [Action],
+// expired or tampered ones are replied with a localized message.
+//
+//	postback.Register(&postback.Action{Name: "todo.done", Handler: done})
+//	flex.NewPostbackAction("完成", postback.Data("todo.done", postback.Args{"id": t.ID}), "完成")
+//
+// Postbacks without the payload, e.g. made by other handlers of [line.TopicPostback], are ignored.
+package postback
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"app/core/command"
+	"app/core/dedup"
+	"app/core/errors"
+	"app/core/flex"
+	"app/core/line"
+	"app/core/property"
+	"app/core/util"
+
+	"golang.org/x/exp/slog"
+)
+
+// Option configures the postback actions.
+type Option struct {
+	// TTL is how long a payload is valid, unless set by the [Action].
+	TTL time.Duration
+}
+
+var opt = Option{TTL: 7 * 24 * time.Hour}
+
+// Setup sets the options, empty fields are left unchanged.
+func Setup(o *Option) {
+	if o.TTL > 0 {
+		opt.TTL = o.TTL
+	}
+}
+
+// Handler handles the postback of an action.
+type Handler func(c *Context) error
+
+// Action is a named action that buttons and quick replies could trigger.
+type Action struct {
+	// Name is the unique name of the action, e.g. "todo.done", it should not contain "|".
+	Name string
+	// TTL is how long the payloads of the action are valid, defaults to [Option.TTL].
+	TTL time.Duration
+	// Handler handles the postback, an error is converted and replied as in commands.
+	Handler Handler
+}
+
+// Context is passed to the [Handler], it carries the event and the verified arguments.
+type Context struct {
+	*command.Context
+	// Action is the triggered action.
+	Action *Action
+	// Args are the arguments signed into the payload.
+	Args Args
+	// Params are the values picked by a datetime picker, e.g. "date", "time" or "datetime".
+	Params map[string]string
+}
+
+var actions = map[string]*Action{}
+
+// Register registers actions, actions with the same name will be overwritten.
+//
+// It is usually called in the init function of each feature package.
+func Register(acts ...*Action) {
+	for _, a := range acts {
+		actions[a.Name] = a
+	}
+}
+
+// Data returns the signed payload that triggers action @name with @args.
+// The data of a postback is limited to 300 characters, so the args should be short, like IDs.
+func Data(name string, args Args) string {
+	ttl := opt.TTL
+	if a, ok := actions[name]; ok && a.TTL > 0 {
+		ttl = a.TTL
+	}
+	return encode(name, args, time.Now().Add(ttl))
+}
+
+// Button returns a postback action with the payload of action @name,
+// @displayText is shown in the chat as the user if not empty.
+func Button(label, displayText, name string, args Args) *flex.Action {
+	return flex.NewPostbackAction(label, Data(name, args), displayText)
+}
+
+// QuickReply returns a quick reply button with the payload of action @name, @label is also shown as the user.
+func QuickReply(label, name string, args Args) *line.QuickReplyItem {
+	return line.NewQuickReplyItem(Button(label, label, name, args))
+}
+
+// Service is the [service.Service] which verifies postbacks and dispatches them to the actions.
+var Service = &postback{}
+
+type postback struct {
+	close func()
+}
+
+func (s *postback) Init() error {
+	s.close = dedup.Handle("postback", line.TopicPostback, dedup.ExactlyOnce, func(ctx context.Context, evt *line.Event) error {
+		if evt.Postback != nil && strings.HasPrefix(evt.Postback.Data, prefix) {
+			dispatch(ctx, evt)
+		}
+		return nil
+	})
+	return nil
+}
+
+func (s *postback) Load() {}
+
+func (s *postback) Del() {
+	if s.close != nil {
+		s.close()
+	}
+}
+
+// dispatch verifies the payload of @evt and runs its action.
+func dispatch(ctx context.Context, evt *line.Event) {
+	c := &Context{Context: command.NewContext(ctx, evt), Params: evt.Postback.Params}
+	name, args, err := decode(evt.Postback.Data, time.Now())
+	a, ok := actions[name]
+	switch {
+	case err == ErrExpired:
+		slog.Info("postback expired", slog.String("mod", "postback"), slog.String("act", name))
+		reply(c, "postback.expired")
+		return
+	case err != nil || !ok:
+		slog.Warn("postback rejected", slog.String("mod", "postback"), slog.String("act", name),
+			slog.String("data", evt.Postback.Data))
+		reply(c, "postback.invalid")
+		return
+	}
+
+	c.Action, c.Args = a, args
+	if err := run(c); err != nil {
+		ce := errors.Convert(err).Exec(c.Locale, nil)
+		slog.Error(ce.Error(), ce.Attr(), slog.String("mod", "postback"), slog.String("act", name))
+		if rerr := c.Reply(line.NewText(ce.Error())); rerr != nil {
+			slog.Error("reply failed", util.ErrAtrr(rerr), slog.String("mod", "postback"))
+		}
+	}
+}
+
+// run calls the handler of the action of @c, a panic is recovered as an internal error so it is replied like one.
+func run(c *Context) (err error) {
+	defer func() {
+		if pan := recover(); pan != nil {
+			err = errors.ErrInternal.SetInfo(fmt.Sprint(pan))
+			if property.IsDebug() {
+				slog.Error("postback panicked", slog.String("mod", "postback"), slog.String("act", c.Action.Name),
+					slog.String("stack", util.Stack()))
+			}
+		}
+	}()
+	return c.Action.Handler(c)
+}
+
+// reply replies the localized message @key.
+func reply(c *Context, key string) {
+	if err := c.ReplyText(key, nil); err != nil {
+		slog.Error("reply failed", util.ErrAtrr(err), slog.String("mod", "postback"))
+	}
+}
@@ -9,6 +9,15 @@
 	   Date        Ver    Name     Description
 	---------- ------- ----------- -------------------------------------------
 	2023/02/22  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Add storage and media configs
+	2026/10/17  v1.0.2 Evan Chen   Add note configs
+	2026/10/17  v1.0.3 Evan Chen   Add expense configs
+	2026/10/17  v1.0.4 Evan Chen   Add digest configs
+	2026/10/17  v1.0.5 Evan Chen   Add calendar configs
+	2026/10/17  v1.0.6 Evan Chen   Add postback configs
+	2026/10/17  v1.0.7 Evan Chen   Add LIFF configs
+	2026/10/17  v1.0.8 Evan Chen   Add webhook configs
+	2026/10/17  v1.0.9 Evan Chen   Add SECRET_FILE
 
 */
 
@@ -42,11 +51,137 @@ const (
 //-------------------------------------------------
 
 const (
-	DEBUG      config.Key = "DEBUG"      // config key to set run mode to debug
-	CUSTOM     config.Key = "CUST"       // config key to set where the custom folder is located
-	SECRET     config.Key = "SECRET"     // config key to set the key to sign jwt tokens
-	NO_CRON    config.Key = "NO_CRON"    // config key to disable cron jobs
-	AUTO_LOGIN config.Key = "AUTO_LOGIN" // config key to set the authentication mechanism to always identify requests as given user
+	DEBUG       config.Key = "DEBUG"       // config key to set run mode to debug
+	CUSTOM      config.Key = "CUST"        // config key to set where the custom folder is located
+	SECRET      config.Key = "SECRET"      // config key to set the key to sign jwt tokens
+	SECRET_FILE config.Key = "SECRET_FILE" // config key for the file to keep a generated key in when SECRET is not set
+	NO_CRON     config.Key = "NO_CRON"     // config key to disable cron jobs
+	CRON        config.Key = "CRON_"       // prefix of config keys to override the schedule of a cron job, ex: CRON_REMINDER_PURGE=0 3 * * *
+	AUTO_LOGIN  config.Key = "AUTO_LOGIN"  // config key to set the authentication mechanism to always identify requests as given user
+	BASE_URL    config.Key = "BASE_URL"    // config key for the external url of the server to make links with, ex: https://bot.example.com
+)
+
+//-------------------------------------------------
+//- LINE related configs                          -
+//-------------------------------------------------
+
+const (
+	LINE_CHANNEL_SECRET config.Key = "LINE_CHANNEL_SECRET" // config key for the channel secret to verify webhook signatures
+	LINE_CHANNEL_TOKEN  config.Key = "LINE_CHANNEL_TOKEN"  // config key for the channel access token to call the messaging api
+	LINE_API_URL        config.Key = "LINE_API_URL"        // config key to override the messaging api endpoint
+	LINE_DATA_API_URL   config.Key = "LINE_DATA_API_URL"   // config key to override the messaging api endpoint for contents
+	LINE_MAX_RETRY      config.Key = "LINE_MAX_RETRY"      // config key to set how many times a failed request would be retried
+	LINE_DEDUP_STORE    config.Key = "LINE_DEDUP_STORE"    // config key to set where handled webhook event ids are recorded, ex: memory, database
+	LINE_DEDUP_TTL      config.Key = "LINE_DEDUP_TTL"      // config key to set how long handled webhook event ids are remembered, ex: 24h
+
+	LINE_LOGIN_CHANNEL_ID     config.Key = "LINE_LOGIN_CHANNEL_ID"     // config key for the channel id of LINE Login
+	LINE_LOGIN_CHANNEL_SECRET config.Key = "LINE_LOGIN_CHANNEL_SECRET" // config key for the channel secret of LINE Login
+	LINE_LOGIN_REDIRECT_URI   config.Key = "LINE_LOGIN_REDIRECT_URI"   // config key for the callback url registered in the LINE Login channel
+	LINE_LOGIN_AUTHORIZE_URL  config.Key = "LINE_LOGIN_AUTHORIZE_URL"  // config key to override the LINE Login authorization endpoint
+	LINE_LOGIN_TOKEN_URL      config.Key = "LINE_LOGIN_TOKEN_URL"      // config key to override the LINE Login token endpoint, ex: a mock IdP
+	LINE_LOGIN_ISSUER         config.Key = "LINE_LOGIN_ISSUER"         // config key to override the expected issuer of ID tokens
+
+	LIFF_ID          config.Key = "LIFF_ID"          // config key for the LIFF ID of the web app, the LIFF app should be added to the LINE Login channel
+	LIFF_VERIFY_URL  config.Key = "LIFF_VERIFY_URL"  // config key to override the endpoint to verify LIFF tokens, ex: a stub for testing
+	LIFF_PROFILE_URL config.Key = "LIFF_PROFILE_URL" // config key to override the endpoint to get the profile of a LIFF access token
+
+	LINE_ADMINS     config.Key = "LINE_ADMINS"     // config key for comma separated LINE user ids that are always admins
+	LINE_USER_GROUP config.Key = "LINE_USER_GROUP" // config key to set the group of new LINE users, ex: user, admin, custom
+	LINE_USER_DEPT  config.Key = "LINE_USER_DEPT"  // config key to set the dept of new LINE users
+)
+
+//-------------------------------------------------
+//- Conversation related configs                  -
+//-------------------------------------------------
+
+const (
+	CONVERSATION_CANCEL config.Key = "CONVERSATION_CANCEL" // config key for comma separated keywords to cancel a dialog
+	CONVERSATION_TTL    config.Key = "CONVERSATION_TTL"    // config key to set how long a dialog waits for a reply, ex: 10m
+
+	POSTBACK_TTL config.Key = "POSTBACK_TTL" // config key to set how long the buttons of a message could be tapped, ex: 168h
+)
+
+//-------------------------------------------------
+//- Outbox related configs                        -
+//-------------------------------------------------
+
+const (
+	OUTBOX_MAX_ATTEMPTS  config.Key = "OUTBOX_MAX_ATTEMPTS"  // config key to set how many failed attempts an outbound message is marked dead after
+	OUTBOX_BACKOFF       config.Key = "OUTBOX_BACKOFF"       // config key to set the wait before retrying an outbound message, ex: 30s
+	OUTBOX_FLUSH_TIMEOUT config.Key = "OUTBOX_FLUSH_TIMEOUT" // config key to set how long pending outbound messages are flushed on shutdown, ex: 5s
+)
+
+//-------------------------------------------------
+//- Webhook related configs                       -
+//-------------------------------------------------
+
+const (
+	WEBHOOK_MAX_ATTEMPTS  config.Key = "WEBHOOK_MAX_ATTEMPTS"  // config key to set how many failed attempts a webhook delivery is marked dead after
+	WEBHOOK_BACKOFF       config.Key = "WEBHOOK_BACKOFF"       // config key to set the wait before retrying a webhook delivery, ex: 30s
+	WEBHOOK_TIMEOUT       config.Key = "WEBHOOK_TIMEOUT"       // config key to set how long a webhook delivery waits for the reply, ex: 10s
+	WEBHOOK_RETENTION     config.Key = "WEBHOOK_RETENTION"     // config key to set how long finished webhook deliveries are kept, ex: 720h
+	WEBHOOK_ALLOW_PRIVATE config.Key = "WEBHOOK_ALLOW_PRIVATE" // config key to allow webhooks to post to loopback and private addresses
+)
+
+//-------------------------------------------------
+//- Reminder related configs                      -
+//-------------------------------------------------
+
+const (
+	REMINDER_GRACE    config.Key = "REMINDER_GRACE"    // config key to set how late a missed reminder could still be pushed, ex: 1h
+	REMINDER_SNOOZE   config.Key = "REMINDER_SNOOZE"   // config key to set how long the snooze button delays a reminder, ex: 10m
+	REMINDER_INTERVAL config.Key = "REMINDER_INTERVAL" // config key to set the longest time between checks of due reminders, ex: 1m
+)
+
+//-------------------------------------------------
+//- Digest related configs                        -
+//-------------------------------------------------
+
+const (
+	DIGEST_AT config.Key = "DIGEST_AT" // config key for the default delivery time of the daily digest, ex: 07:00
+	DIGEST_TZ config.Key = "DIGEST_TZ" // config key for the default time zone of users, the local time zone if empty, ex: Asia/Taipei
+)
+
+//-------------------------------------------------
+//- Calendar related configs                      -
+//-------------------------------------------------
+
+const (
+	CALENDAR_REFRESH config.Key = "CALENDAR_REFRESH" // config key to set how often calendar apps refresh the feeds, ex: 1h
+)
+
+//-------------------------------------------------
+//- Note related configs                          -
+//-------------------------------------------------
+
+const (
+	NOTE_AUTO_SAVE config.Key = "NOTE_AUTO_SAVE" // config key to save texts that are not commands in one-on-one chats as notes
+)
+
+//-------------------------------------------------
+//- Expense related configs                       -
+//-------------------------------------------------
+
+const (
+	EXPENSE_CURRENCY   config.Key = "EXPENSE_CURRENCY"   // config key for the currency of amounts given without one, ex: TWD
+	EXPENSE_REPORT_TTL config.Key = "EXPENSE_REPORT_TTL" // config key to set how long an expense report link is valid, ex: 168h
+)
+
+//-------------------------------------------------
+//- Storage related configs                       -
+//-------------------------------------------------
+
+const (
+	STORAGE_BACKEND config.Key = "STORAGE_BACKEND" // config key to set where blobs are stored, ex: local, s3
+	STORAGE_DIR     config.Key = "STORAGE_DIR"     // config key to set the root folder of the local storage
+	S3_ENDPOINT     config.Key = "S3_ENDPOINT"     // config key for the url of the s3 compatible storage, ex: http://localhost:9000
+	S3_REGION       config.Key = "S3_REGION"       // config key for the region of the s3 storage
+	S3_BUCKET       config.Key = "S3_BUCKET"       // config key for the bucket to store blobs in
+	S3_ACCESS_KEY   config.Key = "S3_ACCESS_KEY"   // config key for the access key of the s3 storage
+	S3_SECRET_KEY   config.Key = "S3_SECRET_KEY"   // config key for the secret key of the s3 storage
+	S3_PATH_STYLE   config.Key = "S3_PATH_STYLE"   // config key to address the bucket by path instead of sub domain, as required by MinIO
+
+	MEDIA_URL_TTL config.Key = "MEDIA_URL_TTL" // config key to set how long a media download link is valid, ex: 1h
 )
 
 //-------------------------------------------------
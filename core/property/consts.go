@@ -7,5 +7,9 @@ const DefaultLocale = "zh-tw"
 // It should be a subfolder of the CUSTOM folder.
 const CUST_TMPL = "messages"
 
+// CUST_BRIDGE is the file of the inbound webhook endpoints, see the bridge module.
+// It should be in the CUSTOM folder.
+const CUST_BRIDGE = "bridge.json"
+
 // GIN_LOCALE is the gin context key to retrive locale info
 const GIN_LOCALE = "locale"
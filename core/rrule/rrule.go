@@ -0,0 +1,319 @@
+/*
+	rrule.go
+	Purpose: Recurrence rules (RFC 5545 RRULE) and their text form.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+// Package rrule expands recurrence rules of RFC 5545, e.g. "FREQ=MONTHLY;BYDAY=-1FR" for the last Friday of each month.
+//
+// A [Rule] supports FREQ of DAILY, WEEKLY, MONTHLY and YEARLY with INTERVAL, BYMONTH, BYMONTHDAY, BYDAY,
+// BYSETPOS, COUNT, UNTIL and WKST. A [Set] is a rule starting at a time (DTSTART) with the excluded dates (EXDATE),
+// its occurrences keep the wall clock time of the start in its location across daylight saving changes.
+// [FromText] reads English like "every 2 weeks on tue, thu" into a rule.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrUnsupported is returned for valid parts of RFC 5545 that are not supported, e.g. FREQ=HOURLY or BYWEEKNO.
+	ErrUnsupported = errors.New("rrule: unsupported")
+	// ErrInvalid is returned for malformed rules.
+	ErrInvalid = errors.New("rrule: invalid")
+)
+
+// Freq is the frequency of a rule.
+type Freq int
+
+const (
+	YEARLY Freq = iota + 1
+	MONTHLY
+	WEEKLY
+	DAILY
+)
+
+var freqNames = map[Freq]string{YEARLY: "YEARLY", MONTHLY: "MONTHLY", WEEKLY: "WEEKLY", DAILY: "DAILY"}
+
+func (f Freq) String() string {
+	return freqNames[f]
+}
+
+var dayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Day is a weekday of BYDAY, the Nth of the month or year if N is not zero, e.g. -1 for the last.
+type Day struct {
+	N       int
+	Weekday time.Weekday
+}
+
+func (d Day) String() string {
+	if d.N != 0 {
+		return strconv.Itoa(d.N) + dayNames[d.Weekday]
+	}
+	return dayNames[d.Weekday]
+}
+
+// Rule is a recurrence rule.
+type Rule struct {
+	Freq     Freq
+	Interval int       // 1 if zero
+	Count    int       // the number of occurrences, unlimited if zero
+	Until    time.Time // the last possible occurrence, unlimited if zero
+	// UntilDate writes Until as a DATE, as required if the start of the set is.
+	UntilDate bool
+
+	ByMonth    []int // 1 to 12
+	ByMonthDay []int // 1 to 31, or -1 to -31 from the end of the month
+	ByDay      []Day
+	BySetPos   []int // the Nth occurrences within each period, negative from the end
+	// WeekStart is the first day of the week (WKST), which matters for weekly rules with an interval.
+	// [Parse] and [FromText] default it to Monday as RFC 5545 does.
+	WeekStart time.Weekday
+}
+
+// Parse parses a rule like "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH", with or without the "RRULE:" prefix.
+// An UNTIL without time zone is taken in @loc.
+func Parse(s string, loc *time.Location) (*Rule, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	s = strings.TrimSpace(s)
+	if len(s) > 6 && strings.EqualFold(s[:6], "RRULE:") {
+		s = s[6:]
+	}
+	r := &Rule{WeekStart: time.Monday}
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalid, part)
+		}
+		var err error
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			r.Freq, err = parseFreq(value)
+		case "INTERVAL":
+			r.Interval, err = parseInt(value, 1, 1<<20)
+		case "COUNT":
+			r.Count, err = parseInt(value, 1, 1<<20)
+		case "UNTIL":
+			r.Until, r.UntilDate, err = parseUntil(value, loc)
+		case "BYMONTH":
+			r.ByMonth, err = parseInts(value, 1, 12, false)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseInts(value, 1, 31, true)
+		case "BYSETPOS":
+			r.BySetPos, err = parseInts(value, 1, 366, true)
+		case "BYDAY":
+			r.ByDay, err = parseDays(value)
+		case "WKST":
+			var d []Day
+			if d, err = parseDays(value); err == nil && (len(d) != 1 || d[0].N != 0) {
+				err = fmt.Errorf("%w: WKST=%s", ErrInvalid, value)
+			} else if err == nil {
+				r.WeekStart = d[0].Weekday
+			}
+		case "BYHOUR", "BYMINUTE", "BYSECOND", "BYYEARDAY", "BYWEEKNO":
+			err = fmt.Errorf("%w: %s", ErrUnsupported, name)
+		default:
+			err = fmt.Errorf("%w: %s", ErrInvalid, name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// validate checks the combination of the parts.
+func (r *Rule) validate() error {
+	switch {
+	case r.Freq == 0:
+		return fmt.Errorf("%w: FREQ is required", ErrInvalid)
+	case r.Count > 0 && !r.Until.IsZero():
+		return fmt.Errorf("%w: COUNT and UNTIL are exclusive", ErrInvalid)
+	case len(r.BySetPos) > 0 && len(r.ByMonth)+len(r.ByMonthDay)+len(r.ByDay) == 0:
+		return fmt.Errorf("%w: BYSETPOS requires another BYxxx", ErrInvalid)
+	case r.Freq == WEEKLY && len(r.ByMonthDay) > 0:
+		return fmt.Errorf("%w: BYMONTHDAY with FREQ=WEEKLY", ErrInvalid)
+	}
+	if r.Freq == DAILY || r.Freq == WEEKLY {
+		for _, d := range r.ByDay {
+			if d.N != 0 {
+				return fmt.Errorf("%w: BYDAY=%s with FREQ=%s", ErrInvalid, d, r.Freq)
+			}
+		}
+	}
+	for _, d := range r.ByDay {
+		if d.N < -53 || d.N > 53 || (r.Freq == MONTHLY || len(r.ByMonth) > 0) && (d.N < -5 || d.N > 5) {
+			return fmt.Errorf("%w: BYDAY=%s", ErrInvalid, d)
+		}
+	}
+	return nil
+}
+
+func parseFreq(s string) (Freq, error) {
+	switch strings.ToUpper(s) {
+	case "YEARLY":
+		return YEARLY, nil
+	case "MONTHLY":
+		return MONTHLY, nil
+	case "WEEKLY":
+		return WEEKLY, nil
+	case "DAILY":
+		return DAILY, nil
+	case "HOURLY", "MINUTELY", "SECONDLY":
+		return 0, fmt.Errorf("%w: FREQ=%s", ErrUnsupported, s)
+	}
+	return 0, fmt.Errorf("%w: FREQ=%s", ErrInvalid, s)
+}
+
+func parseInt(s string, min, max int) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("%w: %q", ErrInvalid, s)
+	}
+	return n, nil
+}
+
+// parseInts parses a list like "1,15,-1" of numbers within [@min, @max], or [-@max, -@min] if @negative.
+func parseInts(s string, min, max int, negative bool) ([]int, error) {
+	list := []int{}
+	for _, v := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		abs := n
+		if negative && n < 0 {
+			abs = -n
+		}
+		if err != nil || abs < min || abs > max {
+			return nil, fmt.Errorf("%w: %q", ErrInvalid, s)
+		}
+		list = append(list, n)
+	}
+	return list, nil
+}
+
+// parseDays parses a list like "MO,WE" or "-1FR,2MO".
+func parseDays(s string) ([]Day, error) {
+	list := []Day{}
+	for _, v := range strings.Split(strings.ToUpper(s), ",") {
+		v = strings.TrimSpace(v)
+		if len(v) < 2 {
+			return nil, fmt.Errorf("%w: BYDAY=%s", ErrInvalid, s)
+		}
+		d := Day{Weekday: -1}
+		for i, name := range dayNames {
+			if v[len(v)-2:] == name {
+				d.Weekday = time.Weekday(i)
+			}
+		}
+		if d.Weekday < 0 {
+			return nil, fmt.Errorf("%w: BYDAY=%s", ErrInvalid, s)
+		}
+		if num := strings.TrimPrefix(v[:len(v)-2], "+"); num != "" {
+			n, err := strconv.Atoi(num)
+			if err != nil || n == 0 {
+				return nil, fmt.Errorf("%w: BYDAY=%s", ErrInvalid, s)
+			}
+			d.N = n
+		}
+		list = append(list, d)
+	}
+	return list, nil
+}
+
+// parseUntil parses UNTIL as a DATE, a DATE-TIME in UTC, or a floating DATE-TIME in @loc.
+// A DATE lasts until the end of the day.
+func parseUntil(s string, loc *time.Location) (time.Time, bool, error) {
+	switch {
+	case len(s) == 8:
+		t, err := time.ParseInLocation("20060102", s, loc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%w: UNTIL=%s", ErrInvalid, s)
+		}
+		return t.AddDate(0, 0, 1).Add(-time.Second), true, nil
+	case strings.HasSuffix(s, "Z"):
+		t, err := time.Parse("20060102T150405Z", s)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%w: UNTIL=%s", ErrInvalid, s)
+		}
+		return t, false, nil
+	default:
+		t, err := time.ParseInLocation("20060102T150405", s, loc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%w: UNTIL=%s", ErrInvalid, s)
+		}
+		return t, false, nil
+	}
+}
+
+// String formats the rule without the "RRULE:" prefix.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq.String()}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		if r.UntilDate {
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+		}
+	}
+	join := func(name string, list []int) {
+		if len(list) == 0 {
+			return
+		}
+		s := make([]string, len(list))
+		for i, n := range list {
+			s[i] = strconv.Itoa(n)
+		}
+		parts = append(parts, name+"="+strings.Join(s, ","))
+	}
+	join("BYMONTH", r.ByMonth)
+	join("BYMONTHDAY", r.ByMonthDay)
+	if len(r.ByDay) > 0 {
+		s := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			s[i] = d.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(s, ","))
+	}
+	join("BYSETPOS", r.BySetPos)
+	if r.WeekStart != time.Monday && r.Freq == WEEKLY && r.Interval > 1 {
+		parts = append(parts, "WKST="+dayNames[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+// sortedUnique sorts @days and removes the duplicates.
+func sortedUnique(days []time.Time) []time.Time {
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	out := days[:0]
+	for i, d := range days {
+		if i == 0 || !d.Equal(days[i-1]) {
+			out = append(out, d)
+		}
+	}
+	return out
+}
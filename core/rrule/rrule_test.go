@@ -0,0 +1,273 @@
+package rrule
+
+import (
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func load(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestWall(t *testing.T) {
+	ny, london, taipei := load(t, "America/New_York"), load(t, "Europe/London"), load(t, "Asia/Taipei")
+	tests := []struct {
+		name       string
+		day        string
+		h, mi, sec int
+		loc        *time.Location
+		want       string // RFC 3339 with the offset
+	}{
+		{"plain day", "2026-06-01", 9, 0, 0, ny, "2026-06-01T09:00:00-04:00"},
+		{"no daylight saving", "2026-03-08", 2, 30, 0, taipei, "2026-03-08T02:30:00+08:00"},
+		{"gap takes the offset before", "2026-03-08", 2, 30, 0, ny, "2026-03-08T03:30:00-04:00"},
+		{"gap at its start", "2026-03-08", 2, 0, 0, ny, "2026-03-08T03:00:00-04:00"},
+		{"after the gap", "2026-03-08", 3, 0, 0, ny, "2026-03-08T03:00:00-04:00"},
+		{"before the gap", "2026-03-08", 1, 59, 59, ny, "2026-03-08T01:59:59-05:00"},
+		{"overlap takes the earlier", "2026-11-01", 1, 30, 0, ny, "2026-11-01T01:30:00-04:00"},
+		{"after the overlap", "2026-11-01", 2, 0, 0, ny, "2026-11-01T02:00:00-05:00"},
+		{"gap in london", "2026-03-29", 1, 30, 0, london, "2026-03-29T02:30:00+01:00"},
+		{"overlap in london", "2026-10-25", 1, 30, 0, london, "2026-10-25T01:30:00+01:00"},
+	}
+	for _, tt := range tests {
+		day, _ := time.Parse("2006-01-02", tt.day)
+		got := wall(day, tt.h, tt.mi, tt.sec, tt.loc)
+		if got.Format(time.RFC3339) != tt.want {
+			t.Errorf("%s: wall(%s %02d:%02d) = %s, want %s", tt.name, tt.day, tt.h, tt.mi, got.Format(time.RFC3339), tt.want)
+		}
+		if got.Location() != tt.loc {
+			t.Errorf("%s: location = %s, want %s", tt.name, got.Location(), tt.loc)
+		}
+	}
+}
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		name string
+		set  string
+		// from is the start of the occurrences listed, the start of the set if empty
+		from string
+		want []string // RFC 3339, all the occurrences up to 10
+	}{
+		{
+			name: "last friday by BYSETPOS",
+			set:  "DTSTART;TZID=Asia/Taipei:20261001T090000\nRRULE:FREQ=MONTHLY;BYDAY=FR;BYSETPOS=-1;COUNT=4",
+			want: []string{"2026-10-30T09:00:00+08:00", "2026-11-27T09:00:00+08:00", "2026-12-25T09:00:00+08:00", "2027-01-29T09:00:00+08:00"},
+		},
+		{
+			name: "last friday by BYDAY",
+			set:  "DTSTART;TZID=Asia/Taipei:20261001T090000\nRRULE:FREQ=MONTHLY;BYDAY=-1FR;COUNT=4",
+			want: []string{"2026-10-30T09:00:00+08:00", "2026-11-27T09:00:00+08:00", "2026-12-25T09:00:00+08:00", "2027-01-29T09:00:00+08:00"},
+		},
+		{
+			name: "second to last weekday",
+			set:  "DTSTART;TZID=Asia/Taipei:20261001T090000\nRRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-2;COUNT=3",
+			want: []string{"2026-10-29T09:00:00+08:00", "2026-11-27T09:00:00+08:00", "2026-12-30T09:00:00+08:00"},
+		},
+		{
+			name: "every other tue and thu from a sunday, weeks starting monday",
+			set:  "DTSTART;TZID=Asia/Taipei:20261018T080000\nRRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;WKST=MO;COUNT=4",
+			want: []string{"2026-10-27T08:00:00+08:00", "2026-10-29T08:00:00+08:00", "2026-11-10T08:00:00+08:00", "2026-11-12T08:00:00+08:00"},
+		},
+		{
+			name: "every other tue and thu from a sunday, weeks starting sunday",
+			set:  "DTSTART;TZID=Asia/Taipei:20261018T080000\nRRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;WKST=SU;COUNT=4",
+			want: []string{"2026-10-20T08:00:00+08:00", "2026-10-22T08:00:00+08:00", "2026-11-03T08:00:00+08:00", "2026-11-05T08:00:00+08:00"},
+		},
+		{
+			// the example of WKST in RFC 5545
+			name: "rfc 5545 WKST=MO",
+			set:  "DTSTART;TZID=America/New_York:19970805T090000\nRRULE:FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO",
+			want: []string{"1997-08-05T09:00:00-04:00", "1997-08-10T09:00:00-04:00", "1997-08-19T09:00:00-04:00", "1997-08-24T09:00:00-04:00"},
+		},
+		{
+			name: "rfc 5545 WKST=SU",
+			set:  "DTSTART;TZID=America/New_York:19970805T090000\nRRULE:FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU",
+			want: []string{"1997-08-05T09:00:00-04:00", "1997-08-17T09:00:00-04:00", "1997-08-19T09:00:00-04:00", "1997-08-31T09:00:00-04:00"},
+		},
+		{
+			name: "COUNT",
+			set:  "DTSTART;TZID=Asia/Taipei:20261017T090000\nRRULE:FREQ=DAILY;COUNT=3",
+			want: []string{"2026-10-17T09:00:00+08:00", "2026-10-18T09:00:00+08:00", "2026-10-19T09:00:00+08:00"},
+		},
+		{
+			// excluded dates still count, as RFC 5545 removes them from the occurrences of the rule
+			name: "COUNT with EXDATE",
+			set:  "DTSTART;TZID=Asia/Taipei:20261017T090000\nRRULE:FREQ=DAILY;COUNT=3\nEXDATE;TZID=Asia/Taipei:20261018T090000",
+			want: []string{"2026-10-17T09:00:00+08:00", "2026-10-19T09:00:00+08:00"},
+		},
+		{
+			name: "EXDATE list",
+			set:  "DTSTART;TZID=Asia/Taipei:20261017T090000\nRRULE:FREQ=DAILY;COUNT=5\nEXDATE;TZID=Asia/Taipei:20261017T090000,20261019T090000",
+			want: []string{"2026-10-18T09:00:00+08:00", "2026-10-20T09:00:00+08:00", "2026-10-21T09:00:00+08:00"},
+		},
+		{
+			name: "EXDATE in UTC",
+			set:  "DTSTART;TZID=Asia/Taipei:20261017T090000\nRRULE:FREQ=DAILY;COUNT=2\nEXDATE:20261017T010000Z",
+			want: []string{"2026-10-18T09:00:00+08:00"},
+		},
+		{
+			name: "UNTIL is inclusive",
+			set:  "DTSTART;TZID=Asia/Taipei:20261017T090000\nRRULE:FREQ=DAILY;UNTIL=20261019T010000Z",
+			want: []string{"2026-10-17T09:00:00+08:00", "2026-10-18T09:00:00+08:00", "2026-10-19T09:00:00+08:00"},
+		},
+		{
+			name: "UNTIL before the time of the last day",
+			set:  "DTSTART;TZID=Asia/Taipei:20261017T090000\nRRULE:FREQ=DAILY;UNTIL=20261019T005959Z",
+			want: []string{"2026-10-17T09:00:00+08:00", "2026-10-18T09:00:00+08:00"},
+		},
+		{
+			name: "UNTIL as a date",
+			set:  "DTSTART;VALUE=DATE:20261017\nRRULE:FREQ=WEEKLY;UNTIL=20261031",
+			want: []string{"2026-10-17T00:00:00+08:00", "2026-10-24T00:00:00+08:00", "2026-10-31T00:00:00+08:00"},
+		},
+		{
+			name: "keeps the wall clock across daylight saving",
+			set:  "DTSTART;TZID=America/New_York:20261030T090000\nRRULE:FREQ=DAILY;COUNT=4",
+			want: []string{"2026-10-30T09:00:00-04:00", "2026-10-31T09:00:00-04:00", "2026-11-01T09:00:00-05:00", "2026-11-02T09:00:00-05:00"},
+		},
+		{
+			name: "skipped time moves forward",
+			set:  "DTSTART;TZID=America/New_York:20260307T023000\nRRULE:FREQ=DAILY;COUNT=3",
+			want: []string{"2026-03-07T02:30:00-05:00", "2026-03-08T03:30:00-04:00", "2026-03-09T02:30:00-04:00"},
+		},
+		{
+			name: "start is not an occurrence unless it matches",
+			set:  "DTSTART;TZID=Asia/Taipei:20261017T090000\nRRULE:FREQ=WEEKLY;BYDAY=MO;COUNT=2",
+			want: []string{"2026-10-19T09:00:00+08:00", "2026-10-26T09:00:00+08:00"},
+		},
+		{
+			name: "31st skips short months",
+			set:  "DTSTART;TZID=Asia/Taipei:20261031T090000\nRRULE:FREQ=MONTHLY;BYMONTHDAY=31;COUNT=3",
+			want: []string{"2026-10-31T09:00:00+08:00", "2026-12-31T09:00:00+08:00", "2027-01-31T09:00:00+08:00"},
+		},
+		{
+			name: "leap day",
+			set:  "DTSTART;TZID=Asia/Taipei:20261017T090000\nRRULE:FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29;COUNT=2",
+			want: []string{"2028-02-29T09:00:00+08:00", "2032-02-29T09:00:00+08:00"},
+		},
+		{
+			name: "listed from a later time",
+			set:  "DTSTART;TZID=Asia/Taipei:20261017T090000\nRRULE:FREQ=DAILY;COUNT=5",
+			from: "2026-10-19T09:00:00+08:00",
+			want: []string{"2026-10-19T09:00:00+08:00", "2026-10-20T09:00:00+08:00", "2026-10-21T09:00:00+08:00"},
+		},
+		{
+			name: "never occurs",
+			set:  "DTSTART;TZID=Asia/Taipei:20261017T090000\nRRULE:FREQ=MONTHLY;BYMONTH=2;BYMONTHDAY=31",
+			want: []string{},
+		},
+	}
+	taipei := load(t, "Asia/Taipei")
+	for _, tt := range tests {
+		set, err := ParseSet(tt.set, taipei)
+		if err != nil {
+			t.Errorf("%s: ParseSet = %v", tt.name, err)
+			continue
+		}
+		from := set.Start
+		if tt.from != "" {
+			from, _ = time.Parse(time.RFC3339, tt.from)
+		}
+		got := []string{}
+		for _, o := range set.Between(from, time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC), 10) {
+			got = append(got, o.Format(time.RFC3339))
+		}
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("%s:\n got %v\nwant %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAfter(t *testing.T) {
+	set, err := ParseSet("DTSTART;TZID=Asia/Taipei:20261017T090000\nRRULE:FREQ=DAILY;COUNT=3", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		after string
+		want  string // empty if none
+	}{
+		{"2026-10-01T00:00:00+08:00", "2026-10-17T09:00:00+08:00"},
+		{"2026-10-17T09:00:00+08:00", "2026-10-18T09:00:00+08:00"},
+		{"2026-10-18T08:59:59+08:00", "2026-10-18T09:00:00+08:00"},
+		{"2026-10-19T09:00:00+08:00", ""},
+	}
+	for _, tt := range tests {
+		at, _ := time.Parse(time.RFC3339, tt.after)
+		next, ok := set.After(at)
+		got := ""
+		if ok {
+			got = next.Format(time.RFC3339)
+		}
+		if got != tt.want {
+			t.Errorf("After(%s) = %q, want %q", tt.after, got, tt.want)
+		}
+	}
+}
+
+func TestMaxEmpty(t *testing.T) {
+	defer func(n int) { maxEmpty[YEARLY] = n }(maxEmpty[YEARLY])
+	maxEmpty[YEARLY] = 2
+
+	tests := []struct {
+		name  string
+		start string
+		want  []string
+	}{
+		// 2030 and 2031 have no leap day, which is within the cut-off, while 2033 to 2035 are beyond it
+		{"within the cut-off", "20300101T090000", []string{"2032-02-29T09:00:00+08:00"}},
+		// 2029, 2030 and 2031 have no leap day, so the rule is taken as exhausted before 2032
+		{"beyond the cut-off", "20290101T090000", []string{}},
+	}
+	for _, tt := range tests {
+		set, err := ParseSet("DTSTART;TZID=Asia/Taipei:"+tt.start+"\nRRULE:FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := []string{}
+		for _, o := range set.Between(set.Start, time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC), 2) {
+			got = append(got, o.Format(time.RFC3339))
+		}
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string // the rule written back, or the error
+	}{
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH", "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH"},
+		{"RRULE:FREQ=MONTHLY;BYDAY=FR;BYSETPOS=-1", "FREQ=MONTHLY;BYDAY=FR;BYSETPOS=-1"},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=TU;WKST=SU", "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU;WKST=SU"},
+		// WKST is only written when it changes the occurrences
+		{"FREQ=WEEKLY;BYDAY=TU;WKST=SU", "FREQ=WEEKLY;BYDAY=TU"},
+		{"FREQ=HOURLY", ErrUnsupported.Error()},
+		{"FREQ=DAILY;BYHOUR=9", ErrUnsupported.Error()},
+		{"FREQ=DAILY;BYMONTHDAY=32", ErrInvalid.Error()},
+		{"FREQ=WEEKLY;WKST=1MO", ErrInvalid.Error()},
+		{"INTERVAL=2", ErrInvalid.Error()},
+	}
+	for _, tt := range tests {
+		r, err := Parse(tt.in, time.UTC)
+		got := ""
+		if err != nil {
+			got = err.Error()
+		} else {
+			got = r.String()
+		}
+		if got != tt.want && (err == nil || !strings.HasPrefix(got, tt.want)) {
+			t.Errorf("Parse(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,429 @@
+/*
+	set.go
+	Purpose: Expand the occurrences of a recurrence set.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package rrule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Set is a rule starting at a time with the excluded occurrences, as in an iCalendar event.
+type Set struct {
+	// Start is the first possible occurrence (DTSTART), whose wall clock time and location the occurrences keep.
+	// It is an occurrence only if it matches the rule.
+	Start time.Time
+	// AllDay writes Start and ExDates as DATEs.
+	AllDay  bool
+	Rule    *Rule
+	ExDates []time.Time
+}
+
+// maxEmpty is how many periods in a row could have no occurrence before the rule is taken as exhausted,
+// e.g. "FREQ=MONTHLY;BYMONTHDAY=31;BYMONTH=2" never occurs.
+var maxEmpty = map[Freq]int{DAILY: 3000, WEEKLY: 500, MONTHLY: 120, YEARLY: 30}
+
+// Each calls @f with the occurrences in order until it returns false or the occurrences run out.
+func (s *Set) Each(f func(time.Time) bool) {
+	excluded := make(map[int64]bool, len(s.ExDates))
+	for _, t := range s.ExDates {
+		excluded[t.Unix()] = true
+	}
+	s.Rule.each(s.Start, func(t time.Time) bool {
+		if excluded[t.Unix()] {
+			return true
+		}
+		return f(t)
+	})
+}
+
+// After returns the first occurrence after @t, ok is false if none.
+func (s *Set) After(t time.Time) (next time.Time, ok bool) {
+	s.Each(func(o time.Time) bool {
+		if o.After(t) {
+			next, ok = o, true
+			return false
+		}
+		return true
+	})
+	return next, ok
+}
+
+// Between returns the occurrences in [@from, @to), at most @limit of them if @limit > 0.
+func (s *Set) Between(from, to time.Time, limit int) []time.Time {
+	list := []time.Time{}
+	s.Each(func(o time.Time) bool {
+		if !o.Before(to) {
+			return false
+		}
+		if !o.Before(from) {
+			list = append(list, o)
+		}
+		return limit <= 0 || len(list) < limit
+	})
+	return list
+}
+
+// each calls @f with the occurrences of @r starting at @start, without the excluded dates.
+func (r *Rule) each(start time.Time, f func(time.Time) bool) {
+	loc := start.Location()
+	h, mi, sec := start.Clock()
+	y, m, d := start.Date()
+	base := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	count, empty := 0, 0
+	for k := 0; ; k += interval {
+		days := r.candidates(base, k)
+		if len(days) > 0 && days[0].Year() > 9999 {
+			return
+		}
+		if len(r.BySetPos) > 0 {
+			days = setPos(days, r.BySetPos)
+		}
+		found := false
+		for _, day := range days {
+			t := wall(day, h, mi, sec, loc)
+			if t.Before(start) {
+				continue
+			}
+			if !r.Until.IsZero() && t.After(r.Until) {
+				return
+			}
+			found = true
+			count++
+			if !f(t) || (r.Count > 0 && count >= r.Count) {
+				return
+			}
+		}
+		if found {
+			empty = 0
+		} else if empty++; empty > maxEmpty[r.Freq] {
+			return
+		}
+	}
+}
+
+// candidates returns the days of the @k-th period after the one of @base, which are civil dates in UTC,
+// filtered by the BYxxx parts except BYSETPOS.
+func (r *Rule) candidates(base time.Time, k int) []time.Time {
+	days := []time.Time{}
+	switch r.Freq {
+	case DAILY:
+		day := base.AddDate(0, 0, k)
+		if r.inMonth(day) && r.onMonthDay(day) && r.onWeekday(day) {
+			days = append(days, day)
+		}
+
+	case WEEKLY:
+		first := base.AddDate(0, 0, -((int(base.Weekday())-int(r.WeekStart)+7)%7)+7*k)
+		for i := 0; i < 7; i++ {
+			day := first.AddDate(0, 0, i)
+			if len(r.ByDay) == 0 && day.Weekday() != base.Weekday() {
+				continue
+			}
+			if r.inMonth(day) && r.onWeekday(day) {
+				days = append(days, day)
+			}
+		}
+
+	case MONTHLY:
+		first := time.Date(base.Year(), base.Month()+time.Month(k), 1, 0, 0, 0, 0, time.UTC)
+		if r.inMonth(first) {
+			days = r.month(first, base.Day())
+		}
+
+	case YEARLY:
+		year := base.Year() + k
+		switch {
+		case len(r.ByMonth) > 0:
+			for _, m := range sortedInts(r.ByMonth) {
+				days = append(days, r.month(time.Date(year, time.Month(m), 1, 0, 0, 0, 0, time.UTC), base.Day())...)
+			}
+		case len(r.ByMonthDay) > 0:
+			for m := time.January; m <= time.December; m++ {
+				for _, day := range r.month(time.Date(year, m, 1, 0, 0, 0, 0, time.UTC), base.Day()) {
+					if len(r.ByDay) == 0 || r.onNthWeekday(day, time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC)) {
+						days = append(days, day)
+					}
+				}
+			}
+		case len(r.ByDay) > 0:
+			days = nthWeekdays(r.ByDay, time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC))
+		default:
+			if day := time.Date(year, base.Month(), base.Day(), 0, 0, 0, 0, time.UTC); day.Day() == base.Day() {
+				days = append(days, day)
+			}
+		}
+	}
+	return sortedUnique(days)
+}
+
+// month returns the days of the month starting at @first matching BYMONTHDAY and BYDAY,
+// or the day @dom of it if neither is given. The Nth of BYDAY is within the month.
+func (r *Rule) month(first time.Time, dom int) []time.Time {
+	last := first.AddDate(0, 1, -1)
+	days := []time.Time{}
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, n := range r.ByMonthDay {
+			day, ok := monthDay(first, last, n)
+			if ok && (len(r.ByDay) == 0 || r.onNthWeekday(day, first, last)) {
+				days = append(days, day)
+			}
+		}
+	case len(r.ByDay) > 0:
+		days = nthWeekdays(r.ByDay, first, last)
+	default:
+		if dom <= last.Day() {
+			days = append(days, first.AddDate(0, 0, dom-1))
+		}
+	}
+	return days
+}
+
+func (r *Rule) inMonth(day time.Time) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, m := range r.ByMonth {
+		if time.Month(m) == day.Month() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) onMonthDay(day time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	first := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1)
+	for _, n := range r.ByMonthDay {
+		if d, ok := monthDay(first, last, n); ok && d.Equal(day) {
+			return true
+		}
+	}
+	return false
+}
+
+// onWeekday reports whether @day is on a weekday of BYDAY, ignoring the Nth.
+func (r *Rule) onWeekday(day time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, d := range r.ByDay {
+		if d.Weekday == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// onNthWeekday reports whether @day is on a day of BYDAY within [@first, @last].
+func (r *Rule) onNthWeekday(day, first, last time.Time) bool {
+	for _, d := range nthWeekdays(r.ByDay, first, last) {
+		if d.Equal(day) {
+			return true
+		}
+	}
+	return false
+}
+
+// monthDay returns the @n-th day of the month from @first to @last, counting from the end if negative.
+func monthDay(first, last time.Time, n int) (time.Time, bool) {
+	if n > 0 && n <= last.Day() {
+		return first.AddDate(0, 0, n-1), true
+	}
+	if n < 0 && -n <= last.Day() {
+		return last.AddDate(0, 0, n+1), true
+	}
+	return time.Time{}, false
+}
+
+// nthWeekdays returns the days within [@first, @last] of @byDay, e.g. every Monday, or the last Friday.
+func nthWeekdays(byDay []Day, first, last time.Time) []time.Time {
+	days := []time.Time{}
+	for _, d := range byDay {
+		all := []time.Time{}
+		for day := first.AddDate(0, 0, (int(d.Weekday)-int(first.Weekday())+7)%7); !day.After(last); day = day.AddDate(0, 0, 7) {
+			all = append(all, day)
+		}
+		switch {
+		case d.N == 0:
+			days = append(days, all...)
+		case d.N > 0 && d.N <= len(all):
+			days = append(days, all[d.N-1])
+		case d.N < 0 && -d.N <= len(all):
+			days = append(days, all[len(all)+d.N])
+		}
+	}
+	return days
+}
+
+// setPos picks the @pos-th of @days, counting from the end if negative.
+func setPos(days []time.Time, pos []int) []time.Time {
+	picked := []time.Time{}
+	for _, p := range pos {
+		switch {
+		case p > 0 && p <= len(days):
+			picked = append(picked, days[p-1])
+		case p < 0 && -p <= len(days):
+			picked = append(picked, days[len(days)+p])
+		}
+	}
+	return sortedUnique(picked)
+}
+
+func sortedInts(list []int) []int {
+	out := append([]int{}, list...)
+	sort.Ints(out)
+	return out
+}
+
+// wall returns the time of @h:@mi:@sec on the civil date @day in @loc.
+//
+// As RFC 5545 requires, a time skipped by a forward change, e.g. 02:30 on the day daylight saving starts,
+// takes the offset before the change, i.e. 03:30. A time repeated by a backward change is the earlier one.
+func wall(day time.Time, h, mi, sec int, loc *time.Location) time.Time {
+	naive := time.Date(day.Year(), day.Month(), day.Day(), h, mi, sec, 0, time.UTC)
+	_, before := naive.Add(-24 * time.Hour).In(loc).Zone()
+	_, after := naive.Add(24 * time.Hour).In(loc).Zone()
+	if before == after {
+		return time.Date(day.Year(), day.Month(), day.Day(), h, mi, sec, 0, loc)
+	}
+	candidates := []time.Time{
+		naive.Add(-time.Duration(before) * time.Second).In(loc),
+		naive.Add(-time.Duration(after) * time.Second).In(loc),
+	}
+	var found time.Time
+	for _, t := range candidates {
+		if th, tm, ts := t.Clock(); th == h && tm == mi && ts == sec && t.Day() == day.Day() && (found.IsZero() || t.Before(found)) {
+			found = t
+		}
+	}
+	if found.IsZero() {
+		return candidates[0]
+	}
+	return found
+}
+
+// ParseSet parses the recurrence of an iCalendar event, the lines of DTSTART, RRULE and EXDATE, e.g.
+//
+//	DTSTART;TZID=Asia/Taipei:20261019T080000
+//	RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR
+//	EXDATE;TZID=Asia/Taipei:20261020T080000
+//
+// A DTSTART without time zone is in @loc.
+func ParseSet(s string, loc *time.Location) (*Set, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	set := &Set{}
+	var rule string
+	for _, line := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		head, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalid, line)
+		}
+		name, params, _ := strings.Cut(head, ";")
+		switch strings.ToUpper(name) {
+		case "DTSTART":
+			t, allDay, err := parseTime(value, params, loc)
+			if err != nil {
+				return nil, err
+			}
+			set.Start, set.AllDay = t, allDay
+		case "RRULE":
+			rule = value
+		case "EXDATE":
+			for _, v := range strings.Split(value, ",") {
+				t, _, err := parseTime(strings.TrimSpace(v), params, loc)
+				if err != nil {
+					return nil, err
+				}
+				set.ExDates = append(set.ExDates, t)
+			}
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupported, name)
+		}
+	}
+	if set.Start.IsZero() || rule == "" {
+		return nil, fmt.Errorf("%w: DTSTART and RRULE are required", ErrInvalid)
+	}
+	r, err := Parse(rule, set.Start.Location())
+	if err != nil {
+		return nil, err
+	}
+	set.Rule = r
+	return set, nil
+}
+
+// parseTime parses a DATE or DATE-TIME with the parameters like "TZID=Asia/Taipei" or "VALUE=DATE".
+func parseTime(v, params string, loc *time.Location) (time.Time, bool, error) {
+	for _, p := range strings.Split(params, ";") {
+		if name, value, _ := strings.Cut(p, "="); strings.EqualFold(name, "TZID") {
+			l, err := time.LoadLocation(strings.Trim(value, `"`))
+			if err != nil {
+				return time.Time{}, false, fmt.Errorf("%w: TZID=%s", ErrInvalid, value)
+			}
+			loc = l
+		}
+	}
+	var (
+		t   time.Time
+		err error
+	)
+	switch {
+	case len(v) == 8:
+		t, err = time.ParseInLocation("20060102", v, loc)
+	case strings.HasSuffix(v, "Z"):
+		t, err = time.Parse("20060102T150405Z", v)
+	default:
+		t, err = time.ParseInLocation("20060102T150405", v, loc)
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%w: %q", ErrInvalid, v)
+	}
+	return t, len(v) == 8, nil
+}
+
+// String formats the set as lines of DTSTART, RRULE and EXDATE, see [ParseSet].
+func (s *Set) String() string {
+	var b strings.Builder
+	b.WriteString("DTSTART" + format(s.Start, s.AllDay) + "\nRRULE:" + s.Rule.String())
+	for _, t := range s.ExDates {
+		b.WriteString("\nEXDATE" + format(t.In(s.Start.Location()), s.AllDay))
+	}
+	return b.String()
+}
+
+// format formats the parameters and value of a DATE or DATE-TIME.
+func format(t time.Time, allDay bool) string {
+	switch {
+	case allDay:
+		return ";VALUE=DATE:" + t.Format("20060102")
+	case t.Location() == time.UTC:
+		return ":" + t.Format("20060102T150405Z")
+	default:
+		return ";TZID=" + t.Location().String() + ":" + t.Format("20060102T150405")
+	}
+}
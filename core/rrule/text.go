@@ -0,0 +1,164 @@
+/*
+	text.go
+	Purpose: Read English phrases like "every 2 weeks on tue, thu" into rules.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package rrule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var textWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "tues": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+var textOrdinals = map[string]int{
+	"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5, "last": -1,
+}
+
+// fillers are the words without meaning in a phrase.
+var fillers = map[string]bool{
+	"every": true, "each": true, "on": true, "the": true, "of": true, "and": true, "in": true, "a": true, "repeat": true, "repeats": true,
+}
+
+// weekday reads "monday", "mondays" or "mon".
+func weekday(w string) (time.Weekday, bool) {
+	w = strings.TrimSuffix(w, "s")
+	if wd, ok := textWeekdays[w]; ok {
+		return wd, true
+	}
+	if strings.HasSuffix(w, "day") {
+		wd, ok := textWeekdays[w[:3]]
+		return wd, ok && strings.EqualFold(wd.String(), w)
+	}
+	return 0, false
+}
+
+// ordinal reads "first", "last", "1st" or "15th".
+func ordinal(w string) (int, bool) {
+	if n, ok := textOrdinals[w]; ok {
+		return n, true
+	}
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		if strings.HasSuffix(w, suffix) {
+			if n, err := strconv.Atoi(strings.TrimSuffix(w, suffix)); err == nil && n >= 1 && n <= 31 {
+				return n, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// FromText reads an English phrase into a rule, for example
+//
+//   - "daily", "every day", "every other day", "every 3 days"
+//   - "every weekday", "every weekend", "every monday", "every 2 weeks on tue/thu"
+//   - "monthly", "every month on the 15th", "every month on the last day", "the last friday of each month"
+//   - "yearly", "every year"
+//
+// The phrase has no time of day, which is taken from the start of the [Set].
+func FromText(s string) (*Rule, error) {
+	s = strings.NewReplacer(",", " ", "/", " ", "&", " ", "-", " ").Replace(strings.ToLower(s))
+	r := &Rule{WeekStart: time.Monday}
+	pending, hasPending := 0, false // an ordinal waiting for the weekday or "day" after it
+	for i, words := 0, strings.Fields(s); i < len(words); i++ {
+		w := words[i]
+		if fillers[w] {
+			continue
+		}
+		if n, ok := ordinal(w); ok {
+			if hasPending {
+				r.ByMonthDay = append(r.ByMonthDay, pending)
+			}
+			pending, hasPending = n, true
+			continue
+		}
+		if wd, ok := weekday(w); ok {
+			r.ByDay = append(r.ByDay, Day{N: pending, Weekday: wd})
+			pending, hasPending = 0, false
+			continue
+		}
+		if hasPending && (w == "day" || w == "days") {
+			r.ByMonthDay = append(r.ByMonthDay, pending)
+			pending, hasPending = 0, false
+			continue
+		}
+		// "every 2 weeks", "every other day" or "every 2weeks"
+		if w == "other" {
+			r.Interval = 2
+			continue
+		}
+		if n, err := strconv.Atoi(w); err == nil && n > 0 && i+1 < len(words) {
+			r.Interval = n
+			continue
+		}
+		if j := strings.IndexFunc(w, func(c rune) bool { return c < '0' || c > '9' }); j > 0 {
+			if n, err := strconv.Atoi(w[:j]); err == nil && n > 0 {
+				r.Interval, w = n, w[j:]
+			}
+		}
+		var f Freq
+		switch w {
+		case "day", "days", "daily", "d":
+			f = DAILY
+		case "week", "weeks", "weekly", "w":
+			f = WEEKLY
+		case "month", "months", "monthly", "m":
+			f = MONTHLY
+		case "year", "years", "yearly", "annually", "y":
+			f = YEARLY
+		case "weekday", "weekdays":
+			r.ByDay = append(r.ByDay, Day{Weekday: time.Monday}, Day{Weekday: time.Tuesday}, Day{Weekday: time.Wednesday},
+				Day{Weekday: time.Thursday}, Day{Weekday: time.Friday})
+			continue
+		case "weekend", "weekends":
+			r.ByDay = append(r.ByDay, Day{Weekday: time.Saturday}, Day{Weekday: time.Sunday})
+			continue
+		default:
+			return nil, fmt.Errorf("%w: unknown word %q", ErrInvalid, w)
+		}
+		if r.Freq != 0 && r.Freq != f {
+			return nil, fmt.Errorf("%w: %q", ErrInvalid, s)
+		}
+		r.Freq = f
+	}
+	if hasPending {
+		r.ByMonthDay = append(r.ByMonthDay, pending)
+	}
+
+	nth := false
+	for _, d := range r.ByDay {
+		nth = nth || d.N != 0
+	}
+	switch {
+	case r.Freq == 0 && (nth || len(r.ByMonthDay) > 0):
+		r.Freq = MONTHLY
+	case r.Freq == 0 && len(r.ByDay) > 0:
+		r.Freq = WEEKLY
+	case r.Freq == 0:
+		return nil, fmt.Errorf("%w: no frequency in %q", ErrInvalid, s)
+	case r.Freq == DAILY && len(r.ByDay) > 0 && r.Interval <= 1:
+		r.Freq = WEEKLY
+	case r.Freq != MONTHLY && r.Freq != YEARLY && (nth || len(r.ByMonthDay) > 0):
+		return nil, fmt.Errorf("%w: days of month in %q", ErrInvalid, s)
+	}
+	if r.Interval == 1 {
+		r.Interval = 0
+	}
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,76 @@
+/*
+	local.go
+	Purpose: Blob store on the local filesystem.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package storage
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"app/core/errors"
+)
+
+// NewLocal returns a [Store] which keeps blobs as files under @dir.
+func NewLocal(dir string) Store {
+	return &local{dir: dir}
+}
+
+type local struct {
+	dir string
+}
+
+func (l *local) file(key string) string {
+	return filepath.Join(l.dir, filepath.FromSlash(key))
+}
+
+// Put writes to a temporary file first, so a failed upload never leaves a partial blob.
+func (l *local) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	name := l.file(key)
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	n, err := io.Copy(tmp, r)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if size >= 0 && n != size {
+		return errors.ErrBadRequest.SetInfo("size mismatch")
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+func (l *local) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	f, err := os.Open(l.file(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errors.ErrNotFound
+	}
+	return f, err
+}
+
+func (l *local) Delete(ctx context.Context, key string) error {
+	err := os.Remove(l.file(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,227 @@
+/*
+	s3.go
+	Purpose: Blob store on an S3 compatible object storage, e.g. AWS S3 or MinIO.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"app/core/errors"
+)
+
+// S3Option configures the [S3] backend.
+type S3Option struct {
+	// Endpoint is the url of the service, e.g. https://s3.ap-northeast-1.amazonaws.com or http://localhost:9000.
+	Endpoint string
+	// Region is the region to sign requests for, defaults to us-east-1 which MinIO accepts.
+	Region string
+	// Bucket is where the blobs are kept, it should already exist.
+	Bucket string
+	// AccessKey and SecretKey are the credentials to sign requests with.
+	AccessKey string
+	SecretKey string
+	// PathStyle addresses the bucket as http://endpoint/bucket instead of http://bucket.endpoint,
+	// which is required by most self-hosted services.
+	PathStyle bool
+	// HTTPClient sends the requests, defaults to [http.DefaultClient].
+	HTTPClient *http.Client
+}
+
+// emptyHash is the sha256 of an empty payload.
+const emptyHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// NewS3 returns a [Store] which keeps blobs as objects of a bucket,
+// requests are signed with AWS signature version 4.
+func NewS3(o *S3Option) (Store, error) {
+	u, err := url.Parse(strings.TrimSuffix(o.Endpoint, "/"))
+	if err != nil || u.Host == "" {
+		return nil, errors.ErrBadRequest.SetInfo("invalid s3 endpoint: " + o.Endpoint)
+	}
+	if o.Bucket == "" {
+		return nil, errors.ErrBadRequest.SetInfo("s3 bucket is required")
+	}
+	s := &s3{opt: *o, endpoint: u}
+	if s.opt.Region == "" {
+		s.opt.Region = "us-east-1"
+	}
+	if s.opt.HTTPClient == nil {
+		s.opt.HTTPClient = http.DefaultClient
+	}
+	return s, nil
+}
+
+type s3 struct {
+	opt      S3Option
+	endpoint *url.URL
+}
+
+// Put uploads with an unsigned payload so the content is streamed without being hashed first.
+func (s *s3) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	req, err := s.request(ctx, http.MethodPut, key, r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	res, err := s.do(req, "UNSIGNED-PAYLOAD")
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	return nil
+}
+
+func (s *s3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.request(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.do(req, emptyHash)
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+func (s *s3) Delete(ctx context.Context, key string) error {
+	req, err := s.request(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	res, err := s.do(req, emptyHash)
+	if errors.Is(err, errors.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	return nil
+}
+
+// request builds the request to the object @key.
+func (s *s3) request(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	u := *s.endpoint
+	if s.opt.PathStyle {
+		u.Path = u.Path + "/" + s.opt.Bucket + "/" + key
+	} else {
+		u.Host = s.opt.Bucket + "." + u.Host
+		u.Path = u.Path + "/" + key
+	}
+	u.RawPath = escapePath(u.Path)
+	return http.NewRequestWithContext(ctx, method, u.String(), body)
+}
+
+// do signs and sends @req, non 2xx responses are returned as errors.
+func (s *s3) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	s.sign(req, payloadHash, time.Now().UTC())
+	res, err := s.opt.HTTPClient.Do(req)
+	if err != nil {
+		return nil, errors.ErrServiceUnavailable.SetInfo(err.Error())
+	}
+	if res.StatusCode/100 == 2 {
+		return res, nil
+	}
+	defer res.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return nil, errors.ErrNotFound
+	case res.StatusCode == http.StatusForbidden:
+		return nil, errors.ErrForbidden.SetInfo(string(body))
+	case res.StatusCode >= 500:
+		return nil, errors.ErrServiceUnavailable.SetInfo(string(body))
+	default:
+		return nil, errors.ErrInternal.SetInfo(fmt.Sprintf("s3 %s: %d %s", req.Method, res.StatusCode, body))
+	}
+}
+
+// sign adds the authorization header of AWS signature version 4.
+//
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func (s *s3) sign(req *http.Request, payloadHash string, now time.Time) {
+	stamp, day := now.Format("20060102T150405Z"), now.Format("20060102")
+	req.Header.Set("X-Amz-Date", stamp)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-date":           stamp,
+		"x-amz-content-sha256": payloadHash,
+	}
+	if ct := req.Header.Get("Content-Type"); ct != "" {
+		headers["content-type"] = ct
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	canonical := &strings.Builder{}
+	for _, k := range names {
+		canonical.WriteString(k + ":" + strings.TrimSpace(headers[k]) + "\n")
+	}
+	signed := strings.Join(names, ";")
+
+	creq := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonical.String(),
+		signed,
+		payloadHash,
+	}, "\n")
+	scope := day + "/" + s.opt.Region + "/s3/aws4_request"
+	sum := sha256.Sum256([]byte(creq))
+	toSign := "AWS4-HMAC-SHA256\n" + stamp + "\n" + scope + "\n" + hex.EncodeToString(sum[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.opt.SecretKey), day)
+	key = hmacSHA256(key, s.opt.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	sig := hex.EncodeToString(hmacSHA256(key, toSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.opt.AccessKey+"/"+scope+
+		", SignedHeaders="+signed+", Signature="+sig)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// escapePath escapes every byte of @p except the unreserved characters and slashes, as required by S3.
+func escapePath(p string) string {
+	b := &strings.Builder{}
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || strings.IndexByte("-_.~/", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"app/core/errors"
+	"app/core/storage/fakes3"
+)
+
+var fakeOpt = &fakes3.Option{Region: "us-east-1", AccessKey: "minio", SecretKey: "minio123", Buckets: []string{"media"}}
+
+// newS3 starts a fake S3 and returns a store on it with @o, the endpoint is filled if empty.
+func newS3(t *testing.T, o S3Option) (*fakes3.Server, Store) {
+	t.Helper()
+	fake := fakes3.New(fakeOpt)
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	if o.Endpoint == "" {
+		o.Endpoint = srv.URL
+	}
+	if !o.PathStyle {
+		// bucket.127.0.0.1 does not resolve, so every host is dialed to the fake
+		addr := srv.Listener.Addr().String()
+		o.HTTPClient = &http.Client{Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, network, addr)
+			},
+		}}
+	}
+	s, err := NewS3(&o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fake, s
+}
+
+func TestS3PutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	for _, pathStyle := range []bool{true, false} {
+		fake, s := newS3(t, S3Option{Bucket: "media", AccessKey: "minio", SecretKey: "minio123", PathStyle: pathStyle})
+		tests := []struct {
+			key         string
+			data        string
+			contentType string
+		}{
+			{"media/2026/10/a.jpg", "jpeg bytes", "image/jpeg"},
+			{"media/2026/10/with space+plus.txt", "spaces", "text/plain"},
+			{"media/2026/10/照片.png", "unicode", ""},
+			{"media/empty", "", "application/octet-stream"},
+		}
+		for _, tt := range tests {
+			if err := s.Put(ctx, tt.key, strings.NewReader(tt.data), int64(len(tt.data)), tt.contentType); err != nil {
+				t.Fatalf("path style %v: Put(%q) = %v", pathStyle, tt.key, err)
+			}
+			obj, ok := fake.Object("media", tt.key)
+			if !ok || string(obj.Data) != tt.data || obj.ContentType != tt.contentType {
+				t.Errorf("path style %v: stored %q = %+v, %v", pathStyle, tt.key, obj, ok)
+			}
+
+			r, err := s.Get(ctx, tt.key)
+			if err != nil {
+				t.Fatalf("path style %v: Get(%q) = %v", pathStyle, tt.key, err)
+			}
+			got, _ := io.ReadAll(r)
+			r.Close()
+			if string(got) != tt.data {
+				t.Errorf("path style %v: Get(%q) = %q, want %q", pathStyle, tt.key, got, tt.data)
+			}
+
+			if err := s.Delete(ctx, tt.key); err != nil {
+				t.Fatalf("path style %v: Delete(%q) = %v", pathStyle, tt.key, err)
+			}
+			if _, err := s.Get(ctx, tt.key); !errors.Is(err, errors.ErrNotFound) {
+				t.Errorf("path style %v: Get(%q) after delete = %v, want not found", pathStyle, tt.key, err)
+			}
+			// deleting again is not an error
+			if err := s.Delete(ctx, tt.key); err != nil {
+				t.Errorf("path style %v: Delete(%q) again = %v", pathStyle, tt.key, err)
+			}
+		}
+	}
+}
+
+func TestS3Replace(t *testing.T) {
+	ctx := context.Background()
+	fake, s := newS3(t, S3Option{Bucket: "media", AccessKey: "minio", SecretKey: "minio123", PathStyle: true})
+	for _, data := range []string{"first", "second"} {
+		if err := s.Put(ctx, "k", bytes.NewReader([]byte(data)), int64(len(data)), "text/plain"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if obj, _ := fake.Object("media", "k"); string(obj.Data) != "second" {
+		t.Errorf("replaced object = %q, want second", obj.Data)
+	}
+}
+
+func TestS3Errors(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		opt  S3Option
+		want *errors.Error
+	}{
+		{"wrong secret", S3Option{Bucket: "media", AccessKey: "minio", SecretKey: "wrong"}, errors.ErrForbidden},
+		{"unknown access key", S3Option{Bucket: "media", AccessKey: "nobody", SecretKey: "minio123"}, errors.ErrForbidden},
+		{"wrong region", S3Option{Bucket: "media", Region: "ap-northeast-1", AccessKey: "minio", SecretKey: "minio123"}, errors.ErrForbidden},
+		{"unknown bucket", S3Option{Bucket: "none", AccessKey: "minio", SecretKey: "minio123"}, errors.ErrNotFound},
+	}
+	for _, tt := range tests {
+		tt.opt.PathStyle = true
+		_, s := newS3(t, tt.opt)
+		err := s.Put(ctx, "k", strings.NewReader("x"), 1, "")
+		if err == nil || errors.Convert(err).Code != tt.want.Code {
+			t.Errorf("%s: Put = %v, want %s", tt.name, err, tt.want.Code)
+		}
+	}
+}
+
+func TestS3Sign(t *testing.T) {
+	fake, st := newS3(t, S3Option{Bucket: "media", AccessKey: "minio", SecretKey: "minio123", PathStyle: true})
+	s := st.(*s3)
+	ctx := context.Background()
+	if err := s.Put(ctx, "signed/a b", strings.NewReader("data"), 4, "text/plain"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		at      time.Time
+		payload string
+		tamper  func(r *http.Request)
+		want    int
+	}{
+		{"valid", time.Now().UTC(), emptyHash, nil, http.StatusOK},
+		{"skewed clock", time.Now().UTC().Add(-time.Hour), emptyHash, nil, http.StatusForbidden},
+		{"payload hash mismatch", time.Now().UTC(), strings.Repeat("0", 64), nil, http.StatusForbidden},
+		{"header changed after signing", time.Now().UTC(), emptyHash, func(r *http.Request) {
+			r.Header.Set("X-Amz-Date", time.Now().UTC().Add(time.Minute).Format("20060102T150405Z"))
+		}, http.StatusForbidden},
+		{"path changed after signing", time.Now().UTC(), emptyHash, func(r *http.Request) {
+			r.URL.RawPath = "/media/signed/a%20c"
+			r.URL.Path = "/media/signed/a c"
+		}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		req, err := s.request(ctx, http.MethodGet, "signed/a b", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.sign(req, tt.payload, tt.at)
+		if tt.tamper != nil {
+			tt.tamper(req)
+		}
+		res, err := s.opt.HTTPClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, res.StatusCode, tt.want)
+		}
+	}
+	if fake.Requests() != len(tests)+1 {
+		t.Errorf("requests = %d, want %d", fake.Requests(), len(tests)+1)
+	}
+}
+
+func TestEscapePath(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"/media/a.jpg", "/media/a.jpg"},
+		{"/media/a b+c", "/media/a%20b%2Bc"},
+		{"/media/照", "/media/%E7%85%A7"},
+		{"/media/~-_.", "/media/~-_."},
+	}
+	for _, tt := range tests {
+		if got := escapePath(tt.in); got != tt.want {
+			t.Errorf("escapePath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
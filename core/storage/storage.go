@@ -0,0 +1,111 @@
+/*
+	storage.go
+	Purpose: Pluggable blob store for binary contents like media files.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+// Package storage stores blobs by key in a backend, either the local filesystem or an S3 compatible object storage.
+//
+// Keys are slash separated paths like "media/2026/10/xxx", the backend is set by [Setup]
+// and used through the package level functions.
+package storage
+
+import (
+	"context"
+	"io"
+	"path"
+	"strings"
+
+	"app/core/errors"
+)
+
+// Backends that could be set by name with [Option.Backend].
+const (
+	LOCAL = "local"
+	S3    = "s3"
+)
+
+// Store is a backend of blobs.
+type Store interface {
+	// Put stores @size bytes of @r as @key, an existing blob of @key is replaced.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the blob of @key, [errors.ErrNotFound] if not exists.
+	// The caller is responsible for closing the returned reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob of @key, it is not an error if not exists.
+	Delete(ctx context.Context, key string) error
+}
+
+// Option configures the blob store.
+type Option struct {
+	// Backend is either [LOCAL] or [S3], defaults to [LOCAL].
+	Backend string
+	// Dir is the root folder of the [LOCAL] backend.
+	Dir string
+	// S3 configures the [S3] backend.
+	S3 S3Option
+}
+
+var std Store = NewLocal("data")
+
+// Setup sets the backend of the package level functions.
+func Setup(o *Option) error {
+	switch strings.ToLower(strings.TrimSpace(o.Backend)) {
+	case "", LOCAL:
+		dir := o.Dir
+		if dir == "" {
+			dir = "data"
+		}
+		std = NewLocal(dir)
+	case S3:
+		s, err := NewS3(&o.S3)
+		if err != nil {
+			return err
+		}
+		std = s
+	default:
+		return errors.ErrBadRequest.SetInfo("unknown storage backend: " + o.Backend)
+	}
+	return nil
+}
+
+// Default returns the backend set by [Setup].
+func Default() Store { return std }
+
+// Put stores @size bytes of @r as @key with the default backend.
+func Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if err := check(key); err != nil {
+		return err
+	}
+	return std.Put(ctx, key, r, size, contentType)
+}
+
+// Get opens the blob of @key from the default backend.
+func Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := check(key); err != nil {
+		return nil, err
+	}
+	return std.Get(ctx, key)
+}
+
+// Delete removes the blob of @key from the default backend.
+func Delete(ctx context.Context, key string) error {
+	if err := check(key); err != nil {
+		return err
+	}
+	return std.Delete(ctx, key)
+}
+
+// check rejects keys that could escape the root, e.g. "../x" or "/x".
+func check(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return errors.ErrBadRequest.SetInfo("invalid storage key: " + key)
+	}
+	return nil
+}
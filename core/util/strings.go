@@ -8,6 +8,7 @@
 	   Date        Ver    Name     Description
 	---------- ------- ----------- -------------------------------------------
 	2023/03/02  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Add SplitTrim
 */
 
 package util
@@ -15,6 +16,7 @@ package util
 import (
 	"bytes"
 	"strconv"
+	"strings"
 	"text/template"
 )
 
@@ -42,6 +44,17 @@ func StripAfterChar(str string, sep byte) string {
 	return str
 }
 
+// SplitTrim splits @str by @sep, trims spaces of each part and drops the empty ones.
+func SplitTrim(str, sep string) []string {
+	parts := []string{}
+	for _, p := range strings.Split(str, sep) {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return parts
+}
+
 // ToInt64X parses string to int64 and panics if error
 func ToInt64X(str string) int64 {
 	p, err := strconv.ParseInt(str, 10, 64)
@@ -0,0 +1,198 @@
+/*
+	en.go
+	Purpose: Rules of English expressions.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package when
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+var enNumbers = map[string]int{
+	"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+}
+
+var enMonths = []string{"january", "february", "march", "april", "may", "june", "july", "august",
+	"september", "october", "november", "december"}
+
+var enWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+var enPeriods = map[string]*period{
+	"morning":   {hour: 9, meridiem: am},
+	"afternoon": {hour: 15, meridiem: pm},
+	"evening":   {hour: 19, meridiem: pm, late: true},
+	"night":     {hour: 21, meridiem: pm, late: true},
+	"tonight":   {hour: 20, meridiem: pm, late: true},
+}
+
+// month returns the month of an English name or its abbreviation, 0 if unknown.
+func month(s string) int {
+	s = strings.TrimSuffix(strings.ToLower(s), ".")
+	for i, name := range enMonths {
+		if len(s) >= 3 && strings.HasPrefix(name, s) || s == "sept" && i == 8 {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func init() {
+	// durations: "in 2 hours", "in half an hour", "10 minutes later", "30m", "in 3 days"
+	add(`(?i)\b(?:(in|after)\s+)?(\d+|an?|one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve|half\s+an?)(\s*)`+
+		`(minutes?|mins?|m|hours?|hrs?|h|days?|d|weeks?|wks?|w|months?)\b(\s+(?:later|from\s+now))?`,
+		func(m []string, ref time.Time) (*part, bool) {
+			compact := m[3] == "" && m[2][0] >= '0' && m[2][0] <= '9'
+			if m[1] == "" && m[5] == "" && !compact {
+				return nil, false
+			}
+			lower := strings.ToLower(m[2])
+			n, ok := enNumbers[lower]
+			if !ok {
+				n = atoi(m[2])
+			}
+			half := strings.HasPrefix(lower, "half")
+			unit := strings.ToLower(m[4])
+			switch {
+			case half && unit[0] != 'h':
+				return nil, false
+			case half:
+				return &part{kind: kindDuration, dur: 30 * time.Minute, conf: 1}, true
+			case n <= 0:
+				return nil, false
+			case unit[0] == 'm' && strings.HasPrefix(unit, "mo"):
+				return &part{kind: kindDate, day: today(ref).AddDate(0, n, 0), conf: 1}, true
+			case unit[0] == 'm':
+				return &part{kind: kindDuration, dur: time.Duration(n) * time.Minute, conf: 1}, true
+			case unit[0] == 'h':
+				return &part{kind: kindDuration, dur: time.Duration(n) * time.Hour, conf: 1}, true
+			case unit[0] == 'd':
+				return &part{kind: kindDate, day: today(ref).AddDate(0, 0, n), conf: 1}, true
+			}
+			return &part{kind: kindDate, day: today(ref).AddDate(0, 0, 7*n), conf: 1}, true
+		})
+
+	// days relative to today
+	add(`(?i)\b(?:the\s+)?(day\s+after\s+tomorrow|tomorrow|tmrw?|today|tonight)\b`,
+		func(m []string, ref time.Time) (*part, bool) {
+			switch w := strings.ToLower(m[1]); {
+			case w == "today":
+				return &part{kind: kindDate, day: today(ref), conf: 1}, true
+			case w == "tonight":
+				return &part{kind: kindDate | kindPeriod, day: today(ref), period: enPeriods["tonight"], conf: 1}, true
+			case strings.HasPrefix(w, "day"):
+				return &part{kind: kindDate, day: today(ref).AddDate(0, 0, 2), conf: 1}, true
+			}
+			return &part{kind: kindDate, day: today(ref).AddDate(0, 0, 1), conf: 1}, true
+		})
+
+	// weekdays: "friday", "on fri", "this sunday", "next monday"
+	add(`(?i)\b(?:(next|this|coming|on)\s+)?(monday|mon|tuesday|tues|tue|wednesday|wed|thursday|thurs|thur|thu|`+
+		`friday|fri|saturday|sat|sunday|sun)\b`,
+		func(m []string, ref time.Time) (*part, bool) {
+			wd := enWeekdays[strings.ToLower(m[2])[:3]]
+			switch strings.ToLower(m[1]) {
+			case "next":
+				// some mean the coming one by "next", which is confirmed
+				return &part{kind: kindDate, day: weekOf(ref, 1, wd), conf: 0.8}, true
+			case "this":
+				day, conf := weekOf(ref, 0, wd), 1.0
+				if day.Before(today(ref)) {
+					day, conf = day.AddDate(0, 0, 7), 0.6
+				}
+				return &part{kind: kindDate, day: day, conf: conf}, true
+			}
+			day, conf := upcoming(ref, wd, 0.6)
+			return &part{kind: kindDate, day: day, conf: conf}, true
+		})
+
+	// "next week" and "next month" are the start of them, which is vague
+	add(`(?i)\bnext\s+(week|month)\b`, func(m []string, ref time.Time) (*part, bool) {
+		if strings.EqualFold(m[1], "week") {
+			return &part{kind: kindDate, day: weekOf(ref, 1, time.Monday), conf: 0.5}, true
+		}
+		day, ok := monthDay(ref, 1, 1)
+		return &part{kind: kindDate, day: day, conf: 0.5}, ok
+	})
+
+	// dates: "2026-10-20", "2026/10/20", "10/20"
+	add(`\b(\d{4})[-/.](\d{1,2})[-/.](\d{1,2})\b`, func(m []string, ref time.Time) (*part, bool) {
+		day, conf, ok := date(ref, atoi(m[1]), atoi(m[2]), atoi(m[3]))
+		return &part{kind: kindDate, day: day, conf: conf}, ok
+	})
+	add(`\b(\d{1,2})/(\d{1,2})\b`, func(m []string, ref time.Time) (*part, bool) {
+		day, conf, ok := date(ref, 0, atoi(m[1]), atoi(m[2]))
+		return &part{kind: kindDate, day: day, conf: conf}, ok
+	})
+	// "oct 20", "October 20th, 2027", "20 oct", "the 20th of october"
+	add(`(?i)\b(?:on\s+)?([a-z]{3,9}\.?)\s+(\d{1,2})(?:st|nd|rd|th)?\b(?:,?\s*(\d{4})\b)?`,
+		func(m []string, ref time.Time) (*part, bool) {
+			mon := month(m[1])
+			day, conf, ok := date(ref, atoi(m[3]), mon, atoi(m[2]))
+			return &part{kind: kindDate, day: day, conf: conf}, ok && mon > 0
+		})
+	add(`(?i)\b(?:on\s+)?(?:the\s+)?(\d{1,2})(?:st|nd|rd|th)?\s+(?:of\s+)?([a-z]{3,9}\.?)(?:,?\s*(\d{4})\b)?`,
+		func(m []string, ref time.Time) (*part, bool) {
+			mon := month(m[2])
+			day, conf, ok := date(ref, atoi(m[3]), mon, atoi(m[1]))
+			return &part{kind: kindDate, day: day, conf: conf}, ok && mon > 0
+		})
+	// a day of this month: "the 15th"
+	add(`(?i)\b(?:on\s+)?the\s+(\d{1,2})(?:st|nd|rd|th)\b`, func(m []string, ref time.Time) (*part, bool) {
+		day, ok := monthDay(ref, 0, atoi(m[1]))
+		return &part{kind: kindDate, day: day, conf: 0.9}, ok
+	})
+
+	// clocks: "9am", "at 9:30 p.m.", "21:00", "at 3", "3 o'clock", "noon"
+	add(`(?i)\b(?:at\s+)?(\d{1,2})(?::(\d{2}))?\s*(?:(am|pm)\b|(a\.m\.|p\.m\.))`,
+		func(m []string, ref time.Time) (*part, bool) {
+			hour, min := atoi(m[1]), atoi(m[2])
+			meridiem := am
+			if strings.HasPrefix(strings.ToLower(m[3]+m[4]), "p") {
+				meridiem = pm
+			}
+			return &part{kind: kindClock, hour: hour, min: min, meridiem: meridiem, conf: 1},
+				hour >= 1 && hour <= 12 && min < 60
+		})
+	add(`(?i)\b(?:at\s+)?(\d{1,2}):(\d{2})\b`, func(m []string, ref time.Time) (*part, bool) {
+		hour, min := atoi(m[1]), atoi(m[2])
+		return &part{kind: kindClock, hour: hour, min: min, conf: 1}, hour <= 24 && min < 60
+	})
+	add(`(?i)\b(?:at\s+)?(\d{1,2})\s*o'?clock\b`, func(m []string, ref time.Time) (*part, bool) {
+		hour := atoi(m[1])
+		return &part{kind: kindClock, hour: hour, vague: true, conf: 1}, hour <= 12
+	})
+	add(`(?i)\bat\s+(\d{1,2})\b`, func(m []string, ref time.Time) (*part, bool) {
+		hour := atoi(m[1])
+		return &part{kind: kindClock, hour: hour, vague: true, conf: 1}, hour <= 24
+	})
+	add(`(?i)\b(?:at\s+)?(noon|midday|midnight)\b`, func(m []string, ref time.Time) (*part, bool) {
+		if strings.EqualFold(m[1], "midnight") {
+			return &part{kind: kindClock, hour: 0, conf: 0.8}, true
+		}
+		return &part{kind: kindClock, hour: 12, conf: 1}, true
+	})
+
+	// periods: "morning", "this evening", "in the afternoon"
+	add(`(?i)\b(?:in\s+the\s+|this\s+)?(morning|afternoon|evening|night)\b`, func(m []string, ref time.Time) (*part, bool) {
+		return &part{kind: kindPeriod, period: enPeriods[strings.ToLower(m[1])], conf: 1}, true
+	})
+}
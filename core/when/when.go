@@ -0,0 +1,310 @@
+/*
+	when.go
+	Purpose: Find and resolve date and time expressions in texts.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+// Package when reads dates and times written by people, in English and Traditional Chinese,
+// e.g. "明天下午三點", "下週五", "in 2 hours" or "next Monday 9am".
+//
+// An expression is made of parts like a day, a time of day and a period of the day ("下午", "morning"),
+// which are resolved against a reference time in its location, i.e. the time zone of the user.
+// The [Result] tells where the expression is in the text and how confident the resolution is,
+// so an ambiguous one like "3點" (3 am or 3 pm?) could be confirmed with the user.
+package when
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned if there is no date or time in the text.
+var ErrNotFound = errors.New("when: no date or time")
+
+// Result is a resolved expression.
+type Result struct {
+	Time time.Time
+	// Start and End are the byte offsets of the expression in the text, which is Text.
+	Start, End int
+	Text       string
+	// Confidence is from 0 to 1, lower for ambiguous expressions, e.g. 0.6 for "at 3" without am or pm.
+	Confidence float64
+	// HasDate and HasTime tell whether the day and the time of day are given,
+	// the time of a day without time of day is its midnight.
+	HasDate, HasTime bool
+}
+
+// kinds of the parts of an expression, an expression has at most one part of each kind.
+const (
+	kindDate = 1 << iota
+	kindClock
+	kindPeriod
+	kindDuration
+)
+
+// meridiems of a clock.
+const (
+	anyHalf = iota
+	am
+	pm
+)
+
+// period is a period of the day like "下午", @hour is its time if no clock is given.
+type period struct {
+	hour     int
+	meridiem int
+	noon     bool // "中午1點" is 13:00 while "中午11點" is 11:00
+	late     bool // "晚上12點" is the midnight after
+}
+
+// part is a part of an expression.
+type part struct {
+	kind     int
+	day      time.Time // midnight of the day
+	hour     int
+	min      int
+	meridiem int
+	vague    bool // the hour could be either half of the day
+	period   *period
+	dur      time.Duration
+	conf     float64
+}
+
+// rule finds parts by its regular expression, @apply returns false if the match is not a valid part.
+type rule struct {
+	re    *regexp.Regexp
+	apply func(m []string, ref time.Time) (*part, bool)
+}
+
+var rules []*rule
+
+// add adds a rule of @expr.
+func add(expr string, apply func(m []string, ref time.Time) (*part, bool)) {
+	rules = append(rules, &rule{re: regexp.MustCompile(expr), apply: apply})
+}
+
+// match is a part found in the text.
+type match struct {
+	start, end int
+	part       *part
+}
+
+// connector matches the text allowed between the parts of an expression.
+var connector = regexp.MustCompile(`(?i)^[\s,，、]*(?:at|on|in the|the|的|在|於)?[\s,，、]*$`)
+
+// Parse finds the date or time expression in @s and resolves it against @ref, in the location of @ref.
+// If there are several, the one with the most parts is taken, or the first of them.
+func Parse(s string, ref time.Time) (*Result, error) {
+	found := []match{}
+	for _, r := range rules {
+		for _, idx := range r.re.FindAllStringSubmatchIndex(s, -1) {
+			m := make([]string, len(idx)/2)
+			for i := range m {
+				if idx[2*i] >= 0 {
+					m[i] = s[idx[2*i]:idx[2*i+1]]
+				}
+			}
+			if p, ok := r.apply(m, ref); ok {
+				found = append(found, match{start: idx[0], end: idx[1], part: p})
+			}
+		}
+	}
+	// the longest of the overlapping matches is kept
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].start != found[j].start {
+			return found[i].start < found[j].start
+		}
+		return found[i].end > found[j].end
+	})
+	kept := []match{}
+	for _, m := range found {
+		if len(kept) == 0 || m.start >= kept[len(kept)-1].end {
+			kept = append(kept, m)
+		}
+	}
+	if len(kept) == 0 {
+		return nil, ErrNotFound
+	}
+
+	// adjacent parts of different kinds make up an expression
+	var best, cur []match
+	kinds := 0
+	for i, m := range kept {
+		if i > 0 && (m.part.kind&kinds != 0 || (m.part.kind|kinds)&kindDuration != 0 ||
+			!connector.MatchString(s[kept[i-1].end:m.start])) {
+			if len(cur) > len(best) {
+				best = cur
+			}
+			cur, kinds = nil, 0
+		}
+		cur, kinds = append(cur, m), kinds|m.part.kind
+	}
+	if len(cur) > len(best) {
+		best = cur
+	}
+
+	start, end := best[0].start, best[len(best)-1].end
+	parts := make([]*part, len(best))
+	for i, m := range best {
+		parts[i] = m.part
+	}
+	res := resolve(parts, ref)
+	res.Start, res.End, res.Text = start, end, s[start:end]
+	return res, nil
+}
+
+// Exact is like [Parse] but the expression should be the whole of @s except the surrounding spaces.
+func Exact(s string, ref time.Time) (*Result, error) {
+	res, err := Parse(s, ref)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(s[:res.Start]) != "" || strings.TrimSpace(s[res.End:]) != "" {
+		return nil, ErrNotFound
+	}
+	return res, nil
+}
+
+// resolve resolves the parts of an expression against @ref.
+func resolve(parts []*part, ref time.Time) *Result {
+	res := &Result{Confidence: 1}
+	var clock, per, day *part
+	for _, p := range parts {
+		res.Confidence *= p.conf
+		if p.kind&kindDuration != 0 {
+			res.Time, res.HasDate, res.HasTime = ref.Add(p.dur).Truncate(time.Minute), true, true
+			return res
+		}
+		if p.kind&kindDate != 0 {
+			day = p
+		}
+		if p.kind&kindClock != 0 {
+			clock = p
+		}
+		if p.period != nil {
+			per = p
+		}
+	}
+
+	base := today(ref)
+	if day != nil {
+		base, res.HasDate = day.day, true
+	}
+	hour, min := 0, 0
+	switch {
+	case clock != nil:
+		res.HasTime = true
+		hour, min = clock.hour, clock.min
+		switch {
+		case clock.meridiem == am && hour == 12:
+			hour = 0
+		case clock.meridiem == pm && hour < 12:
+			hour += 12
+		case clock.meridiem != anyHalf:
+		case per != nil:
+			hour = per.period.apply(hour)
+		case clock.vague && hour >= 1 && hour <= 12:
+			res.Confidence *= 0.6
+			hour = half(base, hour, min, day == nil, ref)
+		}
+	case per != nil:
+		res.HasTime = true
+		hour = per.period.hour
+		res.Confidence *= 0.8
+	}
+
+	t := time.Date(base.Year(), base.Month(), base.Day(), hour, min, 0, 0, ref.Location())
+	if day == nil && res.HasTime && !t.After(ref) {
+		t = t.AddDate(0, 0, 1)
+	}
+	res.Time = t
+	return res
+}
+
+// apply returns the hour of a clock at @hour within the period.
+func (p *period) apply(hour int) int {
+	switch {
+	case p.noon && hour <= 2:
+		return hour + 12
+	case p.late && hour == 12:
+		return 24
+	case p.meridiem == pm && hour < 12:
+		return hour + 12
+	case p.meridiem == am && hour == 12:
+		return 0
+	}
+	return hour
+}
+
+// half decides the half of the day of a vague @hour on @base. On an unspecified day it is the next one after @ref,
+// otherwise 1 to 6 are taken as the afternoon.
+func half(base time.Time, hour, min int, unspecified bool, ref time.Time) int {
+	if unspecified {
+		for _, h := range []int{hour % 12, hour%12 + 12} {
+			if time.Date(base.Year(), base.Month(), base.Day(), h, min, 0, 0, ref.Location()).After(ref) {
+				return h
+			}
+		}
+		return hour % 12
+	}
+	if hour <= 6 {
+		return hour + 12
+	}
+	return hour
+}
+
+// today returns the midnight of the day of @t.
+func today(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// weekOf returns @wd in the week of @ref plus @weeks, weeks start on Monday.
+func weekOf(ref time.Time, weeks int, wd time.Weekday) time.Time {
+	since := (int(ref.Weekday()) + 6) % 7
+	return today(ref).AddDate(0, 0, (int(wd)+6)%7-since+7*weeks)
+}
+
+// upcoming returns the next @wd after today, and the confidence which is lower if today is @wd,
+// since it is unclear whether today or a week later is meant.
+func upcoming(ref time.Time, wd time.Weekday, conf float64) (time.Time, float64) {
+	days := (int(wd) - int(ref.Weekday()) + 7) % 7
+	if days == 0 {
+		return today(ref).AddDate(0, 0, 7), conf
+	}
+	return today(ref).AddDate(0, 0, days), 1
+}
+
+// date returns the midnight of a month and day, in the year of @ref or the next year if passed.
+// ok is false if there is no such day.
+func date(ref time.Time, year, month, day int) (t time.Time, conf float64, ok bool) {
+	conf = 1
+	if year == 0 {
+		year = ref.Year()
+		if t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, ref.Location()); t.Before(today(ref)) {
+			year++
+		}
+		conf = 0.9
+	}
+	t = time.Date(year, time.Month(month), day, 0, 0, 0, 0, ref.Location())
+	return t, conf, month >= 1 && month <= 12 && t.Day() == day
+}
+
+// monthDay returns the midnight of @day in the month of @ref plus @months, or the month after if passed.
+func monthDay(ref time.Time, months, day int) (time.Time, bool) {
+	first := time.Date(ref.Year(), ref.Month(), 1, 0, 0, 0, 0, ref.Location()).AddDate(0, months, 0)
+	t := first.AddDate(0, 0, day-1)
+	if months == 0 && t.Before(today(ref)) {
+		first = first.AddDate(0, 1, 0)
+		t = first.AddDate(0, 0, day-1)
+	}
+	return t, day >= 1 && t.Month() == first.Month()
+}
@@ -0,0 +1,159 @@
+package when
+
+import (
+	"errors"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+// test is a case resolved against a fixed reference time.
+type test struct {
+	in   string
+	ref  time.Time
+	want string // RFC 3339 in the location of ref
+	text string // the expression found in @in
+	conf float64
+	// date and clock are whether the day and the time of day are given
+	date, clock bool
+}
+
+func load(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func run(t *testing.T, tests []test) {
+	t.Helper()
+	for _, tt := range tests {
+		res, err := Parse(tt.in, tt.ref)
+		if err != nil {
+			t.Errorf("Parse(%q) at %s = %v", tt.in, tt.ref.Format(time.RFC3339), err)
+			continue
+		}
+		if got := res.Time.Format(time.RFC3339); got != tt.want {
+			t.Errorf("Parse(%q) at %s = %s, want %s", tt.in, tt.ref.Format(time.RFC3339), got, tt.want)
+		}
+		if res.Text != tt.text || tt.in[res.Start:res.End] != tt.text {
+			t.Errorf("Parse(%q) found %q at [%d:%d], want %q", tt.in, res.Text, res.Start, res.End, tt.text)
+		}
+		if diff := res.Confidence - tt.conf; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("Parse(%q) confidence = %v, want %v", tt.in, res.Confidence, tt.conf)
+		}
+		if res.HasDate != tt.date || res.HasTime != tt.clock {
+			t.Errorf("Parse(%q) has date, time = %v, %v, want %v, %v", tt.in, res.HasDate, res.HasTime, tt.date, tt.clock)
+		}
+	}
+}
+
+func TestZH(t *testing.T) {
+	taipei := load(t, "Asia/Taipei")
+	// a Saturday
+	sat := time.Date(2026, 10, 17, 10, 30, 0, 0, taipei)
+	early := time.Date(2026, 10, 17, 2, 0, 0, 0, taipei)
+	run(t, []test{
+		{"明天下午三點開會", sat, "2026-10-18T15:00:00+08:00", "明天下午三點", 1, true, true},
+		{"明天下午3點半", sat, "2026-10-18T15:30:00+08:00", "明天下午3點半", 1, true, true},
+		{"明天", sat, "2026-10-18T00:00:00+08:00", "明天", 1, true, false},
+		{"明晚", sat, "2026-10-18T20:00:00+08:00", "明晚", 0.8, true, true},
+		{"後天早上九點", sat, "2026-10-19T09:00:00+08:00", "後天早上九點", 1, true, true},
+		{"晚上12點", sat, "2026-10-18T00:00:00+08:00", "晚上12點", 1, false, true},
+		{"中午1點", sat, "2026-10-17T13:00:00+08:00", "中午1點", 1, false, true},
+
+		{"下週一交報告", sat, "2026-10-19T00:00:00+08:00", "下週一", 1, true, false},
+		{"下星期一早上10點", sat, "2026-10-19T10:00:00+08:00", "下星期一早上10點", 1, true, true},
+		{"下下週三", sat, "2026-10-28T00:00:00+08:00", "下下週三", 1, true, false},
+		{"週一", sat, "2026-10-19T00:00:00+08:00", "週一", 1, true, false},
+		// today is Saturday, so it could be today or a week later
+		{"週六", sat, "2026-10-24T00:00:00+08:00", "週六", 0.6, true, false},
+		// "下週" is the start of next week, which is vague
+		{"下週", sat, "2026-10-19T00:00:00+08:00", "下週", 0.5, true, false},
+
+		// 3點 could be 3 am or 3 pm: the next one after now on an unspecified day
+		{"3點", sat, "2026-10-17T15:00:00+08:00", "3點", 0.6, false, true},
+		{"3點", early, "2026-10-17T03:00:00+08:00", "3點", 0.6, false, true},
+		// and the afternoon on a given day
+		{"明天3點", sat, "2026-10-18T15:00:00+08:00", "明天3點", 0.6, true, true},
+		{"明天8點", sat, "2026-10-18T08:00:00+08:00", "明天8點", 0.6, true, true},
+		// a period or a 24 hour clock is not ambiguous
+		{"下午3點", sat, "2026-10-17T15:00:00+08:00", "下午3點", 1, false, true},
+		{"凌晨3點", sat, "2026-10-18T03:00:00+08:00", "凌晨3點", 1, false, true},
+		{"15點", sat, "2026-10-17T15:00:00+08:00", "15點", 1, false, true},
+		// a period alone is a guess of its time
+		{"下午", sat, "2026-10-17T15:00:00+08:00", "下午", 0.8, false, true},
+
+		{"2小時後提醒我", sat, "2026-10-17T12:30:00+08:00", "2小時後", 1, true, true},
+		{"半小時後", sat, "2026-10-17T11:00:00+08:00", "半小時後", 1, true, true},
+		{"10月20日", sat, "2026-10-20T00:00:00+08:00", "10月20日", 0.9, true, false},
+		{"10月1日", sat, "2027-10-01T00:00:00+08:00", "10月1日", 0.9, true, false},
+	})
+}
+
+func TestEN(t *testing.T) {
+	taipei, ny := load(t, "Asia/Taipei"), load(t, "America/New_York")
+	// a Saturday
+	sat := time.Date(2026, 10, 17, 10, 30, 0, 0, taipei)
+	// a Wednesday
+	wed := time.Date(2026, 10, 21, 10, 30, 0, 0, taipei)
+	run(t, []test{
+		{"tomorrow 9am", sat, "2026-10-18T09:00:00+08:00", "tomorrow 9am", 1, true, true},
+		{"call mom tomorrow at 9:30 pm", sat, "2026-10-18T21:30:00+08:00", "tomorrow at 9:30 pm", 1, true, true},
+		{"Tomorrow 9AM", sat, "2026-10-18T09:00:00+08:00", "Tomorrow 9AM", 1, true, true},
+		// in the time zone of the reference, across the end of daylight saving on 2026-11-01
+		{"tomorrow 9am", time.Date(2026, 10, 31, 12, 0, 0, 0, ny), "2026-11-01T09:00:00-05:00", "tomorrow 9am", 1, true, true},
+
+		// "next" is taken as the week after, which some do not mean
+		{"next fri", sat, "2026-10-23T00:00:00+08:00", "next fri", 0.8, true, false},
+		{"next fri", wed, "2026-10-30T00:00:00+08:00", "next fri", 0.8, true, false},
+		{"fri", wed, "2026-10-23T00:00:00+08:00", "fri", 1, true, false},
+		{"this friday", wed, "2026-10-23T00:00:00+08:00", "this friday", 1, true, false},
+		{"on wed", wed, "2026-10-28T00:00:00+08:00", "on wed", 0.6, true, false},
+		{"next friday at 3pm", wed, "2026-10-30T15:00:00+08:00", "next friday at 3pm", 0.8, true, true},
+		{"next week", wed, "2026-10-26T00:00:00+08:00", "next week", 0.5, true, false},
+
+		{"in 2 hours", sat, "2026-10-17T12:30:00+08:00", "in 2 hours", 1, true, true},
+		{"remind me in 2 hours to stretch", sat, "2026-10-17T12:30:00+08:00", "in 2 hours", 1, true, true},
+		{"in half an hour", sat, "2026-10-17T11:00:00+08:00", "in half an hour", 1, true, true},
+		{"30m", sat, "2026-10-17T11:00:00+08:00", "30m", 1, true, true},
+		{"in 3 days", sat, "2026-10-20T00:00:00+08:00", "in 3 days", 1, true, false},
+
+		// "at 3" could be 3 am or 3 pm
+		{"at 3", sat, "2026-10-17T15:00:00+08:00", "at 3", 0.6, false, true},
+		{"at 3 in the morning", sat, "2026-10-18T03:00:00+08:00", "at 3 in the morning", 1, false, true},
+		{"noon", sat, "2026-10-17T12:00:00+08:00", "noon", 1, false, true},
+		{"2026-10-20 21:00", sat, "2026-10-20T21:00:00+08:00", "2026-10-20 21:00", 1, true, true},
+		{"oct 20", sat, "2026-10-20T00:00:00+08:00", "oct 20", 0.9, true, false},
+	})
+}
+
+func TestNotFound(t *testing.T) {
+	ref := time.Date(2026, 10, 17, 10, 30, 0, 0, load(t, "Asia/Taipei"))
+	for _, in := range []string{"", "buy milk", "買牛奶", "2 apples"} {
+		if _, err := Parse(in, ref); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Parse(%q) = %v, want not found", in, err)
+		}
+	}
+}
+
+func TestExact(t *testing.T) {
+	ref := time.Date(2026, 10, 17, 10, 30, 0, 0, load(t, "Asia/Taipei"))
+	tests := []struct {
+		in string
+		ok bool
+	}{
+		{" 明天下午三點 ", true},
+		{"tomorrow 9am", true},
+		{"明天下午三點開會", false},
+		{"call mom tomorrow", false},
+	}
+	for _, tt := range tests {
+		_, err := Exact(tt.in, ref)
+		if (err == nil) != tt.ok {
+			t.Errorf("Exact(%q) = %v, want ok %v", tt.in, err, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+/*
+	zh.go
+	Purpose: Rules of Traditional Chinese expressions.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package when
+
+import (
+	"strings"
+	"time"
+)
+
+// zhNumber matches a number in arabic, full-width or Chinese numerals, zhNum captures it.
+const (
+	zhNumber = `[0-9０-９]+|[零〇一二兩两三四五六七八九十]+`
+	zhNum    = `(` + zhNumber + `)`
+)
+
+var zhDigits = map[rune]int{
+	'零': 0, '〇': 0, '一': 1, '二': 2, '兩': 2, '两': 2, '三': 3, '四': 4, '五': 5, '六': 6, '七': 7, '八': 8, '九': 9,
+}
+
+// number converts "15", "１５", "十五" or "二〇二六" to a number.
+func number(s string) int {
+	n, total := 0, 0
+	digits := false
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			n = n*10 + int(r-'0')
+		case r >= '０' && r <= '９':
+			n = n*10 + int(r-'０')
+		case r == '十':
+			if !digits {
+				n = 1
+			}
+			total, n, digits = total+n*10, 0, false
+			continue
+		default:
+			n = n*10 + zhDigits[r]
+		}
+		digits = true
+	}
+	return total + n
+}
+
+var zhWeekdays = map[string]time.Weekday{
+	"日": time.Sunday, "天": time.Sunday, "7": time.Sunday, "一": time.Monday, "1": time.Monday, "二": time.Tuesday,
+	"2": time.Tuesday, "三": time.Wednesday, "3": time.Wednesday, "四": time.Thursday, "4": time.Thursday,
+	"五": time.Friday, "5": time.Friday, "六": time.Saturday, "6": time.Saturday,
+}
+
+var zhPeriods = map[string]*period{
+	"凌晨": {hour: 1, meridiem: am},
+	"清晨": {hour: 6, meridiem: am},
+	"早上": {hour: 9, meridiem: am},
+	"上午": {hour: 9, meridiem: am},
+	"中午": {hour: 12, noon: true},
+	"下午": {hour: 15, meridiem: pm},
+	"傍晚": {hour: 17, meridiem: pm},
+	"晚上": {hour: 20, meridiem: pm, late: true},
+	"晚間": {hour: 20, meridiem: pm, late: true},
+	"今晚": {hour: 20, meridiem: pm, late: true},
+	"明晚": {hour: 20, meridiem: pm, late: true},
+	"深夜": {hour: 23, meridiem: pm, late: true},
+	"半夜": {hour: 0, meridiem: am},
+	"今早": {hour: 9, meridiem: am},
+	"明早": {hour: 9, meridiem: am},
+}
+
+func init() {
+	// durations: "2小時後", "半小時後", "一個半小時後", "過10分鐘", "3天後", "兩週後", "一個月後"
+	add(`(過|再)?\s*(`+zhNumber+`|半)\s*(個)?(半)?\s*(分鐘|分|小時|鐘頭|天|日|週|周|星期|禮拜|個月)(半)?\s*(以後|之後|後)?`,
+		func(m []string, ref time.Time) (*part, bool) {
+			if m[1] == "" && m[7] == "" {
+				return nil, false
+			}
+			n, half := float64(number(m[2])), m[4] != "" || m[6] != ""
+			if m[2] == "半" {
+				n, half = 0, true
+			}
+			if half {
+				n += 0.5
+			}
+			if n <= 0 {
+				return nil, false
+			}
+			switch m[5] {
+			case "分鐘", "分":
+				return &part{kind: kindDuration, dur: time.Duration(n * float64(time.Minute)), conf: 1}, true
+			case "小時", "鐘頭":
+				return &part{kind: kindDuration, dur: time.Duration(n * float64(time.Hour)), conf: 1}, true
+			case "天", "日":
+				return &part{kind: kindDate, day: today(ref).AddDate(0, 0, int(n)), conf: 1}, !half
+			case "個月":
+				return &part{kind: kindDate, day: today(ref).AddDate(0, int(n), 0), conf: 1}, !half
+			}
+			return &part{kind: kindDate, day: today(ref).AddDate(0, 0, 7*int(n)), conf: 1}, !half
+		})
+
+	// days relative to today, "今晚" and "明早" also give the period
+	add(`(大後天|後天|明天|明日|今天|今日|今晚|明晚|今早|明早)`, func(m []string, ref time.Time) (*part, bool) {
+		p := &part{kind: kindDate, day: today(ref), conf: 1}
+		switch {
+		case m[1] == "大後天":
+			p.day = p.day.AddDate(0, 0, 3)
+		case m[1] == "後天":
+			p.day = p.day.AddDate(0, 0, 2)
+		case strings.HasPrefix(m[1], "明"):
+			p.day = p.day.AddDate(0, 0, 1)
+		}
+		if per, ok := zhPeriods[m[1]]; ok {
+			p.kind, p.period = kindDate|kindPeriod, per
+		}
+		return p, true
+	})
+
+	// weekdays: "週五", "下星期一", "這禮拜天", "下下週三"
+	add(`(下下|下|這|本)?\s*(?:個)?\s*(?:週|周|星期|禮拜|礼拜)([一二三四五六日天1-7])`, func(m []string, ref time.Time) (*part, bool) {
+		wd := zhWeekdays[m[2]]
+		switch m[1] {
+		case "下下":
+			return &part{kind: kindDate, day: weekOf(ref, 2, wd), conf: 1}, true
+		case "下":
+			return &part{kind: kindDate, day: weekOf(ref, 1, wd), conf: 1}, true
+		case "這", "本":
+			day, conf := weekOf(ref, 0, wd), 1.0
+			if day.Before(today(ref)) {
+				day, conf = day.AddDate(0, 0, 7), 0.6
+			}
+			return &part{kind: kindDate, day: day, conf: conf}, true
+		}
+		day, conf := upcoming(ref, wd, 0.6)
+		return &part{kind: kindDate, day: day, conf: conf}, true
+	})
+
+	// "下週" and "下個月" are the start of them, which is vague
+	add(`(下週|下周|下個?星期|下個?禮拜|下個?月)`, func(m []string, ref time.Time) (*part, bool) {
+		if strings.HasSuffix(m[1], "月") {
+			day, ok := monthDay(ref, 1, 1)
+			return &part{kind: kindDate, day: day, conf: 0.5}, ok
+		}
+		return &part{kind: kindDate, day: weekOf(ref, 1, time.Monday), conf: 0.5}, true
+	})
+
+	// dates: "10月20日", "2027年1月5號", "下個月5號", "15號"
+	add(`(?:`+zhNum+`\s*年\s*)?`+zhNum+`\s*月\s*`+zhNum+`\s*(?:日|號)`, func(m []string, ref time.Time) (*part, bool) {
+		day, conf, ok := date(ref, number(m[1]), number(m[2]), number(m[3]))
+		return &part{kind: kindDate, day: day, conf: conf}, ok
+	})
+	add(`(下個?月|這個?月|本月)?\s*`+zhNum+`\s*號`, func(m []string, ref time.Time) (*part, bool) {
+		months, conf := 0, 0.9
+		if m[1] != "" {
+			conf = 1
+		}
+		if strings.HasPrefix(m[1], "下") {
+			months = 1
+		}
+		day, ok := monthDay(ref, months, number(m[2]))
+		return &part{kind: kindDate, day: day, conf: conf}, ok
+	})
+
+	// clocks: "3點", "三點半", "十點二十分", "8點15", "15時", "9點一刻"
+	add(zhNum+`\s*(?:點鐘|點|时|時)(?:(半)|(一刻)|(三刻)|`+zhNum+`\s*分?)?`, func(m []string, ref time.Time) (*part, bool) {
+		hour, min := number(m[1]), number(m[5])
+		switch {
+		case m[2] != "":
+			min = 30
+		case m[3] != "":
+			min = 15
+		case m[4] != "":
+			min = 45
+		}
+		return &part{kind: kindClock, hour: hour, min: min, vague: true, conf: 1}, hour <= 24 && min < 60
+	})
+
+	// periods: "下午", "晚上"
+	add(`(凌晨|清晨|早上|上午|中午|下午|傍晚|晚上|晚間|深夜|半夜)`, func(m []string, ref time.Time) (*part, bool) {
+		return &part{kind: kindPeriod, period: zhPeriods[m[1]], conf: 1}, true
+	})
+}
@@ -0,0 +1,193 @@
+/*
+	bridge.go
+	Purpose: Endpoints of inbound webhooks and their presets.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+// Package bridge pushes the alerts of CI, monitoring or home automation to LINE.
+//
+// Each endpoint receives JSON payloads at [Path], verifies them with its own secret,
+// renders them with its template and pushes the message to its users or groups through the outbox,
+// so failed pushes are retried. Endpoints are read from a file by [LoadFile], for example
+//
+//	{
+//	  "endpoints": [
+//	    { "name": "alerts", "preset": "alertmanager", "secret": "${ALERT_TOKEN}", "to": ["Cxxx"] },
+//	    { "name": "ci", "preset": "github", "secret": "${GITHUB_SECRET}", "to": ["Uxxx"] },
+//	    { "name": "door", "auth": "hmac", "secret": "s3cret", "to": ["Uxxx"], "tmpl": "🚪 {{.Body.door}} is {{.Body.state}}" }
+//	  ]
+//	}
+//
+// The template data is [Data], a template rendered to blank text skips the payload, e.g. events not of interest.
+package bridge
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+
+	"app/core/msg"
+
+	"golang.org/x/exp/slog"
+)
+
+// Path is the path of the endpoints in the gateway mux.
+const Path = "/api/bridge/{name}"
+
+// Ways to authenticate a payload.
+const (
+	// HMAC is the hex HMAC-SHA256 of the body with the secret in [Endpoint.Header], optionally prefixed with "sha256=".
+	HMAC = "hmac"
+	// BEARER is the secret in the Authorization header, e.g. "Authorization: Bearer s3cret".
+	BEARER = "bearer"
+)
+
+// DefaultHeader is the header of the signature of [HMAC] endpoints.
+const DefaultHeader = "X-Signature-256"
+
+// Endpoint is an inbound webhook.
+type Endpoint struct {
+	// Name is the last segment of the url, e.g. "alerts" is received at /api/bridge/alerts.
+	Name string `json:"name"`
+	// Preset is a built-in setting for the payloads of a service, e.g. "alertmanager" or "github".
+	Preset string `json:"preset,omitempty"`
+	// Auth is either [HMAC] or [BEARER], it defaults to the one of the preset or [HMAC].
+	Auth string `json:"auth,omitempty"`
+	// Header is the header of the signature of [HMAC], defaults to the one of the preset or [DefaultHeader].
+	Header string `json:"header,omitempty"`
+	// Secret is the key of [HMAC] or the token of [BEARER], environment variables like ${TOKEN} are expanded.
+	Secret string `json:"secret"`
+	// To are the users or groups to push to.
+	To []string `json:"to"`
+	// Tmpl is the text template of the message, or the alt text of Flex, it overrides the preset.
+	Tmpl string `json:"tmpl,omitempty"`
+	// Flex is the flex template of the message, as in message packs.
+	Flex json.RawMessage `json:"flex,omitempty"`
+
+	key  string // message key of the template
+	flex bool
+}
+
+// Data is the data of the templates.
+type Data struct {
+	// Endpoint is the name of the endpoint.
+	Endpoint string
+	// Header is the header of the request, e.g. {{.Header.Get "X-GitHub-Event"}}.
+	Header http.Header
+	// Body is the decoded JSON payload, e.g. {{.Body.status}}.
+	Body any
+}
+
+// preset is the built-in setting of a service.
+type preset struct {
+	auth   string
+	header string
+	key    string
+	flex   bool
+}
+
+var presets = map[string]*preset{
+	// https://prometheus.io/docs/alerting/latest/configuration/#webhook_config
+	"alertmanager": {auth: BEARER, key: "bridge.alertmanager", flex: true},
+	// https://docs.github.com/en/webhooks/webhook-events-and-payloads
+	"github": {auth: HMAC, header: "X-Hub-Signature-256", key: "bridge.github"},
+}
+
+var validName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+var endpoints = map[string]*Endpoint{}
+
+// LoadFile loads the endpoints from the JSON file at @path, replacing the loaded ones.
+// A missing file means no endpoints.
+func LoadFile(path string) error {
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		endpoints = map[string]*Endpoint{}
+		return nil
+	} else if err != nil {
+		return err
+	}
+	file := &struct {
+		Endpoints []*Endpoint `json:"endpoints"`
+	}{}
+	if err := json.Unmarshal(b, file); err != nil {
+		return fmt.Errorf("bridge: %s: %w", path, err)
+	}
+	return Load(file.Endpoints...)
+}
+
+// Load validates the endpoints and replaces the loaded ones, their templates are added to the message packs.
+func Load(eps ...*Endpoint) error {
+	loaded := map[string]*Endpoint{}
+	pack := &msg.Pack{}
+	for _, e := range eps {
+		if err := e.init(); err != nil {
+			return err
+		}
+		if _, ok := loaded[e.Name]; ok {
+			return fmt.Errorf("bridge: duplicated endpoint %q", e.Name)
+		}
+		loaded[e.Name] = e
+		if e.Tmpl != "" {
+			pack.Add(e.key, e.Tmpl, e.Flex)
+		}
+	}
+	if err := msg.Load(pack); err != nil {
+		return fmt.Errorf("bridge: %w", err)
+	}
+	endpoints = loaded
+	slog.Info("endpoints loaded", slog.Int("count", len(loaded)), slog.String("mod", "bridge"), slog.String("act", "load"))
+	return nil
+}
+
+// init applies the preset and checks the endpoint.
+func (e *Endpoint) init() error {
+	if !validName.MatchString(e.Name) {
+		return fmt.Errorf("bridge: invalid endpoint name %q", e.Name)
+	}
+	if e.Preset != "" {
+		p, ok := presets[e.Preset]
+		if !ok {
+			return fmt.Errorf("bridge: %s: unknown preset %q", e.Name, e.Preset)
+		}
+		e.key, e.flex = p.key, p.flex
+		if e.Auth == "" {
+			e.Auth = p.auth
+		}
+		if e.Header == "" {
+			e.Header = p.header
+		}
+	}
+	if e.Tmpl != "" {
+		e.key, e.flex = "bridge.endpoint."+e.Name, len(e.Flex) > 0
+	}
+	if e.Auth == "" {
+		e.Auth = HMAC
+	}
+	if e.Header == "" {
+		e.Header = DefaultHeader
+	}
+	e.Secret = os.ExpandEnv(e.Secret)
+
+	switch {
+	case e.Auth != HMAC && e.Auth != BEARER:
+		return fmt.Errorf("bridge: %s: unknown auth %q", e.Name, e.Auth)
+	case e.Secret == "":
+		return fmt.Errorf("bridge: %s: secret is required", e.Name)
+	case len(e.To) == 0:
+		return fmt.Errorf("bridge: %s: no one to push to", e.Name)
+	case e.key == "":
+		return fmt.Errorf("bridge: %s: preset or tmpl is required", e.Name)
+	case len(e.Flex) > 0 && e.Tmpl == "":
+		return fmt.Errorf("bridge: %s: tmpl is required as the alt text of flex", e.Name)
+	}
+	return nil
+}
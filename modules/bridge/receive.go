@@ -0,0 +1,144 @@
+/*
+	receive.go
+	Purpose: Receive inbound webhooks and push them to LINE.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Queue the pushes to every recipient or none of them
+*/
+
+package bridge
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"app/core/database"
+	"app/core/errors"
+	"app/core/group"
+	"app/core/line"
+	"app/core/msg"
+	"app/core/outbox"
+	"app/core/server"
+	"app/core/util"
+
+	"golang.org/x/exp/slog"
+)
+
+// maxBody is the largest payload accepted.
+const maxBody = 1 << 20
+
+// maxText is the max characters of a text message.
+const maxText = 5000
+
+// Receive verifies the payload of endpoint {name}, renders it and pushes it to the recipients through the outbox.
+// It replies 202 once the messages are queued, or 204 if the template skips the payload.
+// The pushes to every recipient are queued in a transaction, so on an error none is queued and the sender could retry.
+// It is a handler of the gateway mux, see [runtime.ServeMux.HandlePath].
+func Receive(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	e, ok := endpoints[params["name"]]
+	if !ok {
+		server.HttpAbort(w, r, errors.ErrNotFound)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBody))
+	if err != nil {
+		server.HttpAbort(w, r, errors.ErrBadRequest.SetInfo(err.Error()))
+		return
+	}
+	if !e.verify(r, body) {
+		slog.Warn("invalid signature", slog.String("mod", "bridge"), slog.String("act", "receive"), slog.String("endpoint", e.Name))
+		server.HttpAbort(w, r, errors.ErrUnauthorized.SetInfo("invalid signature"))
+		return
+	}
+	data := &Data{Endpoint: e.Name, Header: r.Header}
+	if err := json.Unmarshal(body, &data.Body); err != nil {
+		server.HttpAbort(w, r, errors.ErrBadRequest.SetInfo(err.Error()))
+		return
+	}
+
+	// every message is rendered before any is queued, as a failure should not leave the recipients before it queued
+	type push struct {
+		to string
+		m  line.Message
+	}
+	pushes := []push{}
+	for _, to := range e.To {
+		m, err := e.render(group.Locale(r.Context(), to), data)
+		if err != nil {
+			slog.Error("render payload failed", util.ErrAtrr(err),
+				slog.String("mod", "bridge"), slog.String("act", "receive"), slog.String("endpoint", e.Name), slog.String("to", to))
+			server.HttpAbort(w, r, errors.ErrInternal.SetInfo(err.Error()))
+			return
+		}
+		if m != nil {
+			pushes = append(pushes, push{to, m})
+		}
+	}
+	err = database.Tx(r.Context(), func(tx *sql.Tx) error {
+		for _, p := range pushes {
+			if _, err := outbox.Enqueue(r.Context(), tx, p.to, p.m); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		slog.Error("queue push failed", util.ErrAtrr(err),
+			slog.String("mod", "bridge"), slog.String("act", "receive"), slog.String("endpoint", e.Name))
+		server.HttpAbort(w, r, errors.Convert(err))
+		return
+	}
+	queued := len(pushes)
+	if queued > 0 {
+		outbox.Notify()
+	}
+	slog.Info("payload received", slog.String("mod", "bridge"), slog.String("act", "receive"),
+		slog.String("endpoint", e.Name), slog.Int("queued", queued))
+
+	if queued == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]int{"queued": queued})
+}
+
+// verify checks the signature or token of the request.
+func (e *Endpoint) verify(r *http.Request, body []byte) bool {
+	if e.Auth == BEARER {
+		tok := r.Header.Get("Authorization")
+		return strings.HasPrefix(tok, "Bearer ") && hmac.Equal([]byte(strings.TrimPrefix(tok, "Bearer ")), []byte(e.Secret))
+	}
+	sig := strings.ToLower(strings.TrimPrefix(r.Header.Get(e.Header), "sha256="))
+	h := hmac.New(sha256.New, []byte(e.Secret))
+	h.Write(body)
+	return hmac.Equal([]byte(sig), []byte(hex.EncodeToString(h.Sum(nil))))
+}
+
+// render renders the message in @locale, nil is returned if the text is blank.
+func (e *Endpoint) render(locale string, data *Data) (line.Message, error) {
+	text := strings.TrimSpace(msg.T(e.key, locale, data))
+	if text == "" {
+		return nil, nil
+	}
+	if e.flex {
+		return msg.Flex(e.key, locale, data)
+	}
+	if utf8.RuneCountInString(text) > maxText {
+		text = string([]rune(text)[:maxText-1]) + "…"
+	}
+	return line.NewText(text), nil
+}
@@ -0,0 +1,188 @@
+/*
+	calendar.go
+	Purpose: Chat commands of the calendar feed and import of .ics files sent to the bot.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Read floating times of imports in the time zone of the user
+	2026/10/17  v1.0.2 Evan Chen   Note that imported events are in the digest as reminders
+*/
+
+// Package calendar connects the bot with calendar apps through iCalendar (.ics) files.
+//
+// Every user gets a secret feed url by "/calendar", which calendar apps subscribe to for the pending
+// reminders and the dated todos of the user, the url is replaced by "/calendar reset" when leaked.
+// The other way round, the events of an .ics file sent to the bot or uploaded to [ImportPath]
+// are imported as reminders, which is also how they are in the daily digest, so there is no
+// digest section of the calendar.
+package calendar
+
+import (
+	"context"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"app/core/command"
+	"app/core/dedup"
+	"app/core/digest"
+	"app/core/ical"
+	"app/core/line"
+	"app/core/msg"
+	"app/modules/reminder"
+
+	"golang.org/x/exp/slog"
+)
+
+// Option configures the calendar.
+type Option struct {
+	// BaseURL is the external url of the server, which feed urls are made of.
+	BaseURL string
+	// Refresh is how often calendar apps are asked to refresh the feed.
+	Refresh time.Duration
+}
+
+var opt = Option{Refresh: time.Hour}
+
+// Setup sets the options, empty fields are left unchanged.
+func Setup(o *Option) {
+	if o.BaseURL != "" {
+		opt.BaseURL = o.BaseURL
+	}
+	if o.Refresh > 0 {
+		opt.Refresh = o.Refresh
+	}
+}
+
+const (
+	// maxSize is the largest .ics file to import.
+	maxSize = 1 << 20
+	// skill is the skill required to import files, since they become reminders.
+	skill = "reminder"
+)
+
+func init() {
+	command.Register(
+		&command.Command{
+			Name:    "calendar",
+			Aliases: []string{"行事曆"},
+			Desc:    "calendar.desc",
+			Handler: feed,
+		},
+		&command.Command{
+			Name:    "calendar reset",
+			Desc:    "calendar.reset.desc",
+			Handler: reset,
+		},
+	)
+}
+
+// Service is the [service.Service] which imports the .ics files sent to the bot.
+var Service = &calendar{}
+
+type calendar struct {
+	close func()
+}
+
+func (s *calendar) Init() error {
+	s.close = dedup.Handle("calendar", line.TopicFile, dedup.ExactlyOnce, file)
+	return nil
+}
+
+func (s *calendar) Load() {}
+
+func (s *calendar) Del() {
+	if s.close != nil {
+		s.close()
+	}
+}
+
+// personal returns the sender of a one-on-one chat, ok is false if replied that the feed is personal.
+func personal(c *command.Context) (userID string, ok bool, err error) {
+	if c.InGroup() || c.Event.Source == nil || c.Event.Source.UserID == "" {
+		return "", false, c.ReplyText("calendar.personal", nil)
+	}
+	return c.Event.Source.UserID, true, nil
+}
+
+// feed replies the feed url of the sender.
+func feed(c *command.Context) error {
+	userID, ok, err := personal(c)
+	if !ok {
+		return err
+	}
+	token, err := Token(c, userID)
+	if err != nil {
+		return err
+	}
+	return c.ReplyText("calendar.feed", map[string]any{"URL": URL(token)})
+}
+
+// reset replaces the feed url of the sender.
+func reset(c *command.Context) error {
+	userID, ok, err := personal(c)
+	if !ok {
+		return err
+	}
+	token, err := Reset(c, userID)
+	if err != nil {
+		return err
+	}
+	return c.ReplyText("calendar.reset", map[string]any{"URL": URL(token)})
+}
+
+// file imports an .ics file sent to the chat as reminders of the sender.
+func file(ctx context.Context, evt *line.Event) error {
+	m := evt.Message
+	if m == nil || evt.Source == nil || !strings.EqualFold(path.Ext(m.FileName), ".ics") {
+		return nil
+	}
+	c := command.NewContext(ctx, evt)
+	if c.Chat != nil && !c.Chat.Enabled(skill) {
+		return nil
+	}
+	if m.FileSize > maxSize {
+		return c.ReplyText("calendar.too_large", msg.Plain(m.FileName))
+	}
+	body, _, err := line.Content(ctx, m.ID)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	chatID, userID := evt.Source.ID(), evt.Source.UserID
+	if userID == "" {
+		userID = chatID
+	}
+	cal, err := ical.Parse(io.LimitReader(body, maxSize), digest.Location(ctx, userID))
+	if err != nil {
+		return c.ReplyText("calendar.bad_file", msg.Plain(m.FileName))
+	}
+	res, err := reminder.Import(c, chatID, userID, cal, time.Now())
+	if err != nil {
+		return err
+	}
+	slog.Info("calendar imported", slog.String("mod", "calendar"), slog.String("act", "file"), slog.String("chat", chatID),
+		slog.String("file", m.FileName), slog.Int("created", len(res.Created)))
+	return c.ReplyText("calendar.imported", result(m.FileName, cal, res))
+}
+
+// result is the template data of an import.
+func result(name string, cal *ical.Calendar, res *reminder.Imported) map[string]any {
+	views := make([]map[string]any, len(res.Created))
+	for i, r := range res.Created {
+		views[i] = map[string]any{"Text": r.Text, "Due": r.DueAt.Local().Format("2006/01/02 15:04")}
+	}
+	return map[string]any{
+		"File":      name,
+		"Events":    len(cal.Events),
+		"Reminders": views,
+		"Past":      res.Past,
+		"Duplicate": res.Duplicate,
+		"Cancelled": res.Cancelled,
+	}
+}
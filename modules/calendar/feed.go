@@ -0,0 +1,157 @@
+/*
+	feed.go
+	Purpose: Serve the .ics feeds of users and import .ics files uploaded over http.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Feed recurring reminders and todos as recurring events
+	2026/10/17  v1.0.2 Evan Chen   Use the time zone of the user for todos and imports
+*/
+
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"app/core/auth"
+	"app/core/digest"
+	"app/core/errors"
+	"app/core/group"
+	"app/core/ical"
+	"app/core/msg"
+	"app/core/server"
+	"app/core/util"
+	"app/modules/reminder"
+	"app/modules/todo"
+
+	"golang.org/x/exp/slog"
+)
+
+// Prefix is the path the feeds are served under, requests of it should be routed to [Feed].
+const Prefix = "/calendar/"
+
+// ImportPath is the path to upload .ics files to, which should be handled by [Upload].
+const ImportPath = "/api/calendar/import"
+
+// URL returns the feed url of @token.
+func URL(token string) string {
+	return strings.TrimSuffix(opt.BaseURL, "/") + Prefix + token + ".ics"
+}
+
+// Build builds the calendar of @userID, with the pending reminders in every chat and the open personal todos
+// with a due date, the latter are all-day events on the days of the time zone set for the digest of the user.
+func Build(ctx context.Context, userID, locale string) (*ical.Calendar, error) {
+	now := time.Now()
+	cal := &ical.Calendar{Name: msg.T("calendar.name", locale, nil), Refresh: opt.Refresh, Events: []*ical.Event{}}
+	rs, err := reminder.Between(ctx, userID, time.Unix(0, 0), now.AddDate(100, 0, 0))
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rs {
+		e := &ical.Event{
+			UID:     "reminder-" + r.ID + "@lineasst",
+			Summary: r.Text,
+			Start:   r.DueAt,
+			End:     r.DueAt,
+		}
+		if set := r.Recurrence; set != nil {
+			e.Start, e.End, e.RRule, e.ExDates = set.Start, set.Start, set.Rule.String(), set.ExDates
+		}
+		cal.Events = append(cal.Events, e)
+	}
+	ts, err := todo.Due(ctx, userID, now.AddDate(100, 0, 0))
+	if err != nil {
+		return nil, err
+	}
+	loc := digest.Location(ctx, userID)
+	for _, t := range ts {
+		due := t.DueAt.In(loc)
+		e := &ical.Event{
+			UID:         "todo-" + t.ID + "@lineasst",
+			Summary:     msg.T("calendar.todo", locale, map[string]any{"Title": t.Title}),
+			Description: strings.Join(t.Tags, " "),
+			Start:       time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, loc),
+			AllDay:      true,
+			Alarm:       -1,
+			Stamp:       t.UpdatedAt,
+		}
+		if set := t.Recurrence; set != nil {
+			// the series is shown from the open todo on, the completed ones are not in the feed
+			e.RRule, e.ExDates = set.Rule.String(), set.ExDates
+		}
+		cal.Events = append(cal.Events, e)
+	}
+	return cal, nil
+}
+
+// Feed serves the calendar of the owner of the token in the path, e.g. /calendar/<token>.ics.
+func Feed(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, Prefix), ".ics")
+	if token == "" || strings.Contains(token, "/") {
+		server.HttpAbort(w, r, errors.ErrNotFound)
+		return
+	}
+	userID, err := owner(r.Context(), token)
+	if err != nil {
+		server.HttpAbort(w, r, errors.Convert(err))
+		return
+	}
+	cal, err := Build(r.Context(), userID, group.Locale(r.Context(), userID))
+	if err != nil {
+		slog.Error("build feed failed", util.ErrAtrr(err), slog.String("mod", "calendar"), slog.String("act", "feed"),
+			slog.String("usr", userID))
+		server.HttpAbort(w, r, errors.Convert(err))
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	if _, err := cal.WriteTo(w); err != nil {
+		slog.Error("write feed failed", util.ErrAtrr(err), slog.String("mod", "calendar"), slog.String("act", "feed"),
+			slog.String("usr", userID))
+	}
+}
+
+// Upload imports an .ics file as reminders of the signed in user, pushed to the one-on-one chat.
+// The file is either the body of the request, or the "file" field of a multipart form.
+func Upload(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	u, ok := auth.GetUserFromToken(server.GetHttpAuthToken(r))
+	if !ok {
+		server.HttpAbort(w, r, errors.ErrUnauthorized)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+	var body io.Reader = r.Body
+	name := "upload.ics"
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		f, h, err := r.FormFile("file")
+		if err != nil {
+			server.HttpAbort(w, r, errors.ErrBadRequest.SetInfo(err.Error()))
+			return
+		}
+		defer f.Close()
+		body, name = f, h.Filename
+	}
+	cal, err := ical.Parse(body, digest.Location(r.Context(), u.Username))
+	if err != nil {
+		server.HttpAbort(w, r, errors.ErrBadRequest.SetInfo(err.Error()))
+		return
+	}
+	res, err := reminder.Import(r.Context(), u.Username, u.Username, cal, time.Now())
+	if err != nil {
+		server.HttpAbort(w, r, errors.Convert(err))
+		return
+	}
+	slog.Info("calendar imported", slog.String("mod", "calendar"), slog.String("act", "upload"), slog.String("usr", u.Username),
+		slog.String("file", name), slog.Int("created", len(res.Created)))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result(name, cal, res))
+}
@@ -0,0 +1,75 @@
+/*
+	store.go
+	Purpose: Persist the feed tokens of users in the database.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package calendar
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"time"
+
+	"app/core/database"
+)
+
+func init() {
+	database.Register(&database.Migration{
+		ID: "20261017_calendar",
+		Stmts: []string{
+			`CREATE TABLE calendar_feeds (
+				user_id TEXT PRIMARY KEY,
+				token TEXT NOT NULL UNIQUE,
+				created_at BIGINT NOT NULL
+			)`,
+		},
+	})
+}
+
+// newToken generates a random feed token.
+func newToken() string {
+	b := make([]byte, 24)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Token gets the feed token of @userID, which is generated on the first call.
+func Token(ctx context.Context, userID string) (string, error) {
+	var token string
+	err := database.DB().QueryRowContext(ctx, "SELECT token FROM calendar_feeds WHERE user_id = $1", userID).Scan(&token)
+	if err == nil {
+		return token, nil
+	}
+	if err != sql.ErrNoRows {
+		return "", database.Err(err)
+	}
+	token = newToken()
+	_, err = database.DB().ExecContext(ctx, "INSERT INTO calendar_feeds (user_id, token, created_at) VALUES ($1, $2, $3)",
+		userID, token, time.Now().Unix())
+	return token, database.Err(err)
+}
+
+// Reset replaces the feed token of @userID, so that the old feed url stops working.
+func Reset(ctx context.Context, userID string) (string, error) {
+	token := newToken()
+	_, err := database.DB().ExecContext(ctx, `INSERT INTO calendar_feeds (user_id, token, created_at) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET token = $2, created_at = $3`,
+		userID, token, time.Now().Unix())
+	return token, database.Err(err)
+}
+
+// owner gets the user of the feed @token.
+func owner(ctx context.Context, token string) (string, error) {
+	var userID string
+	err := database.DB().QueryRowContext(ctx, "SELECT user_id FROM calendar_feeds WHERE token = $1", token).Scan(&userID)
+	return userID, database.Err(err)
+}
@@ -0,0 +1,402 @@
+/*
+	expense.go
+	Purpose: Chat commands, budget warnings and monthly reports of expenses.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Remove the interceptor on Del
+*/
+
+// Package expense logs spendings, like "/spend 120 food" or simply "午餐 120" in a one-on-one chat.
+//
+// Expenses logged in a group or room are shared by the chat, and a photo sent shortly after
+// logging an expense is attached as its receipt. Each category could have a monthly budget,
+// which is warned by a push once 80% of it is spent. The monthly summary is replied as a flex message,
+// exported as an xlsx report downloaded from [ReportPath], or pushed on the first day of every month
+// to the chats that subscribed with "/spend monthly on".
+package expense
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"app/core/command"
+	"app/core/cron"
+	"app/core/dedup"
+	"app/core/errors"
+	"app/core/group"
+	"app/core/line"
+	"app/core/msg"
+	"app/core/outbox"
+	"app/core/util"
+
+	"golang.org/x/exp/slog"
+)
+
+// Option configures the expenses.
+type Option struct {
+	// Currency is the currency of amounts given without one, e.g. TWD.
+	Currency string
+	// BaseURL is the external url of the server, which report links are made of.
+	BaseURL string
+	// TTL is how long a report link is valid.
+	TTL time.Duration
+}
+
+var opt = Option{Currency: "TWD", TTL: 7 * 24 * time.Hour}
+
+// Setup sets the options, empty fields are left unchanged.
+func Setup(o *Option) {
+	if o.Currency != "" {
+		opt.Currency = strings.ToUpper(o.Currency)
+	}
+	if o.BaseURL != "" {
+		opt.BaseURL = o.BaseURL
+	}
+	if o.TTL > 0 {
+		opt.TTL = o.TTL
+	}
+}
+
+const (
+	// skill is the skill of the expense commands.
+	skill = "expense"
+	// receiptWindow is how long after logging an expense a photo is taken as its receipt.
+	receiptWindow = 10 * time.Minute
+	// warnAt is the percentage of a budget to warn at.
+	warnAt = 80
+)
+
+func init() {
+	command.Register(
+		&command.Command{
+			Name:    "spend",
+			Aliases: []string{"記帳"},
+			Args:    []command.Arg{{Name: "amount category note", Rest: true}},
+			Desc:    "expense.desc",
+			Skill:   skill,
+			Handler: add,
+		},
+		&command.Command{
+			Name:    "spend summary",
+			Args:    []command.Arg{{Name: "month"}},
+			Desc:    "expense.summary.desc",
+			Skill:   skill,
+			Handler: summary,
+		},
+		&command.Command{
+			Name:    "spend export",
+			Args:    []command.Arg{{Name: "month"}},
+			Desc:    "expense.export.desc",
+			Skill:   skill,
+			Handler: export,
+		},
+		&command.Command{
+			Name:    "spend budget",
+			Args:    []command.Arg{{Name: "category"}, {Name: "amount"}},
+			Desc:    "expense.budget.desc",
+			Skill:   skill,
+			Handler: setBudget,
+		},
+		&command.Command{
+			Name:    "spend monthly",
+			Args:    []command.Arg{{Name: "on|off", Required: true}},
+			Desc:    "expense.monthly.desc",
+			Skill:   skill,
+			Handler: monthly,
+		},
+		&command.Command{
+			Name:    "spend undo",
+			Desc:    "expense.undo.desc",
+			Skill:   skill,
+			Handler: undo,
+		},
+	)
+
+	cron.Register(&cron.Job{
+		Name:   "expense.report",
+		Spec:   "0 9 1 * *",
+		Jitter: time.Minute,
+		Run:    pushReports,
+	})
+}
+
+// Service is the [service.Service] which logs casual expenses and attaches receipts,
+// it should be registered before the notes, so that "午餐 120" is not saved as a note.
+var Service = &expense{}
+
+type expense struct {
+	closes []func()
+}
+
+func (s *expense) Init() error {
+	s.closes = []func(){
+		command.Intercept(casually),
+		dedup.Handle("expense", line.TopicImage, dedup.ExactlyOnce, receipt),
+	}
+	return nil
+}
+
+func (s *expense) Load() {}
+
+func (s *expense) Del() {
+	for _, off := range s.closes {
+		off()
+	}
+	s.closes = nil
+}
+
+// casually logs a text like "午餐 120" in one-on-one chats.
+func casually(c *command.Context) (bool, error) {
+	if c.InGroup() || command.IsCommand(c.Text) {
+		return false, nil
+	}
+	e, ok := casual(strings.TrimSpace(c.Text), opt.Currency)
+	if !ok {
+		return false, nil
+	}
+	return true, record(c, e)
+}
+
+// add logs an expense, or replies the summary of this month if nothing is given.
+func add(c *command.Context) error {
+	text := c.Args.Get("amount category note")
+	if text == "" {
+		return summary(c)
+	}
+	e, ok := parse(text, opt.Currency)
+	if !ok {
+		return c.ReplyText("command.usage", msg.Plain(c.Command.Usage()))
+	}
+	return record(c, e)
+}
+
+// record saves @e to the chat and warns if the budget of its category is nearly used up.
+func record(c *command.Context, e *Expense) error {
+	e.ChatID, e.UserID = c.ChatID(), sender(c.Event.Source)
+	if err := Create(c, e); err != nil {
+		return err
+	}
+	if err := c.ReplyText("expense.logged", view(e, c.Locale)); err != nil {
+		return err
+	}
+	if err := check(c, e); err != nil {
+		slog.Error("check budget failed", util.ErrAtrr(err), slog.String("mod", "expense"), slog.String("act", "budget"),
+			slog.String("chat", e.ChatID), slog.String("category", e.Category))
+	}
+	return nil
+}
+
+// check pushes a warning once a month if the budget of the category of @e has reached [warnAt] percent.
+func check(ctx context.Context, e *Expense) error {
+	b, err := budget(ctx, e.ChatID, e.Category)
+	if err != nil || b == nil || b.Currency != e.Currency {
+		return err
+	}
+	from, to, month := span(time.Date(e.SpentAt.Year(), e.SpentAt.Month(), 1, 0, 0, 0, 0, e.SpentAt.Location()))
+	sum, err := spent(ctx, e.ChatID, e.Category, e.Currency, from, to)
+	if err != nil || sum*100 < b.Amount*warnAt {
+		return err
+	}
+	if ok, err := warn(ctx, e.ChatID, e.Category, month); err != nil || !ok {
+		return err
+	}
+	locale := group.Locale(ctx, e.ChatID)
+	return outbox.Push(ctx, e.ChatID, line.NewText(msg.T("expense.budget.warn", locale, map[string]any{
+		"Category": label(e.Category, locale),
+		"Currency": e.Currency,
+		"Spent":    format(sum),
+		"Budget":   format(b.Amount),
+		"Used":     sum * 100 / b.Amount,
+	})))
+}
+
+// summary replies the summary of a month as a flex message.
+func summary(c *command.Context) error {
+	from, ok := month(c.Args.Get("month"), time.Now())
+	if !ok {
+		return c.ReplyText("expense.bad_month", nil)
+	}
+	_, data, err := summarize(c, c.ChatID(), from, c.Locale)
+	if err != nil {
+		return err
+	}
+	if data["Count"] == 0 {
+		return c.ReplyText("expense.empty", data)
+	}
+	return c.ReplyFlex("expense.summary", data)
+}
+
+// export replies the download link of the report of a month.
+func export(c *command.Context) error {
+	from, ok := month(c.Args.Get("month"), time.Now())
+	if !ok {
+		return c.ReplyText("expense.bad_month", nil)
+	}
+	return c.ReplyText("expense.export", map[string]any{
+		"Month": from.Format("2006-01"),
+		"URL":   URL(c.ChatID(), from),
+		"TTL":   opt.TTL.String(),
+	})
+}
+
+// setBudget sets the budget of a category, removes it with 0, or lists the budgets if nothing is given.
+func setBudget(c *command.Context) error {
+	cat, arg := c.Args.Get("category"), c.Args.Get("amount")
+	if cat == "" {
+		return budgets(c)
+	}
+	if category(cat) != "" {
+		cat = category(cat)
+	} else {
+		cat = strings.ToLower(cat)
+	}
+	cents, cur, ok := amount(arg)
+	if arg == "0" {
+		ok = true
+	}
+	if !ok {
+		return c.ReplyText("command.usage", msg.Plain(c.Command.Usage()))
+	}
+	if cur == "" {
+		cur = opt.Currency
+	}
+	if err := SetBudget(c, c.ChatID(), cat, cur, cents); err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return c.ReplyText("expense.budget.none", msg.Plain(label(cat, c.Locale)))
+		}
+		return err
+	}
+	return budgets(c)
+}
+
+// budgets replies the budgets of the chat with how much is spent this month.
+func budgets(c *command.Context) error {
+	list, err := Budgets(c, c.ChatID())
+	if err != nil {
+		return err
+	}
+	from, to, _ := span(time.Date(time.Now().Year(), time.Now().Month(), 1, 0, 0, 0, 0, time.Local))
+	views := make([]map[string]any, len(list))
+	for i, b := range list {
+		sum, err := spent(c, b.ChatID, b.Category, b.Currency, from, to)
+		if err != nil {
+			return err
+		}
+		views[i] = map[string]any{
+			"Category": label(b.Category, c.Locale),
+			"Currency": b.Currency,
+			"Spent":    format(sum),
+			"Budget":   format(b.Amount),
+			"Used":     sum * 100 / b.Amount,
+		}
+	}
+	return c.ReplyText("expense.budgets", map[string]any{"Budgets": views})
+}
+
+// monthly turns the monthly report push of the chat on or off.
+func monthly(c *command.Context) error {
+	var on bool
+	switch strings.ToLower(c.Args.Get("on|off")) {
+	case "on":
+		on = true
+	case "off":
+	default:
+		return c.ReplyText("command.usage", msg.Plain(c.Command.Usage()))
+	}
+	if err := Subscribe(c, c.ChatID(), sender(c.Event.Source), on); err != nil {
+		return err
+	}
+	return c.ReplyText("expense.monthly", map[string]any{"On": on})
+}
+
+// undo deletes the last expense logged by the sender in the chat.
+func undo(c *command.Context) error {
+	e, err := Latest(c, c.ChatID(), sender(c.Event.Source))
+	if errors.Is(err, errors.ErrNotFound) {
+		return c.ReplyText("expense.undo.none", nil)
+	}
+	if err != nil {
+		return err
+	}
+	if err := Delete(c, e.ID); err != nil {
+		return err
+	}
+	return c.ReplyText("expense.undone", view(e, c.Locale))
+}
+
+// receipt attaches a photo to the expense the sender has just logged in the chat.
+func receipt(ctx context.Context, evt *line.Event) error {
+	if evt.Message == nil || evt.Source == nil || evt.Source.UserID == "" {
+		return nil
+	}
+	c := command.NewContext(ctx, evt)
+	if c.Chat != nil && !c.Chat.Enabled(skill) {
+		return nil
+	}
+	e, err := Latest(c, c.ChatID(), evt.Source.UserID)
+	if errors.Is(err, errors.ErrNotFound) {
+		return nil
+	}
+	if err != nil || e.Receipt != "" || time.Since(e.CreatedAt) > receiptWindow {
+		return err
+	}
+	if ok, err := attach(c, e.ID, evt.Message.ID); err != nil || !ok {
+		return err
+	}
+	return c.ReplyText("expense.receipt", view(e, c.Locale))
+}
+
+// pushReports pushes the summary of last month to the subscribed chats.
+func pushReports(ctx context.Context) error {
+	chats, err := subscribers(ctx)
+	if err != nil {
+		return err
+	}
+	from, _ := month("last", time.Now())
+	for _, chatID := range chats {
+		locale := group.Locale(ctx, chatID)
+		_, data, err := summarize(ctx, chatID, from, locale)
+		if err != nil {
+			return err
+		}
+		if data["Count"] == 0 {
+			continue
+		}
+		m, err := msg.Flex("expense.summary", locale, data)
+		if err != nil {
+			return err
+		}
+		if err := outbox.Push(ctx, chatID, m); err != nil {
+			return err
+		}
+		slog.Info("report pushed", slog.String("mod", "expense"), slog.String("act", "report"),
+			slog.String("chat", chatID), slog.String("month", data["Month"].(string)))
+	}
+	return nil
+}
+
+// sender returns the user who sent the event, or the chat if the user is unknown.
+func sender(src *line.Source) string {
+	if src.UserID != "" {
+		return src.UserID
+	}
+	return src.ID()
+}
+
+// view is the template data of an expense.
+func view(e *Expense, locale string) map[string]any {
+	return map[string]any{
+		"ID":       e.ID,
+		"Amount":   format(e.Amount),
+		"Currency": e.Currency,
+		"Category": label(e.Category, locale),
+		"Note":     e.Note,
+		"Time":     e.SpentAt.Format("01/02 15:04"),
+	}
+}
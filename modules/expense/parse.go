@@ -0,0 +1,256 @@
+/*
+	parse.go
+	Purpose: Parse amounts, currencies, categories and months from the text of the user.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package expense
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// currencies maps the symbols and words of a currency to its code, codes themselves are matched too.
+// "$" and "元" are left to the default currency.
+var currencies = map[string]string{
+	"nt$": "TWD", "台幣": "TWD", "新台幣": "TWD",
+	"us$": "USD", "美金": "USD", "美元": "USD",
+	"¥": "JPY", "円": "JPY", "日圓": "JPY", "日幣": "JPY", "日元": "JPY",
+	"€": "EUR", "歐元": "EUR",
+	"£": "GBP", "英鎊": "GBP",
+	"hk$": "HKD", "港幣": "HKD", "港元": "HKD",
+	"rmb": "CNY", "人民幣": "CNY",
+	"₩": "KRW", "韓元": "KRW", "韓幣": "KRW",
+}
+
+// codes are the currency codes known by [currency].
+var codes = map[string]bool{
+	"TWD": true, "USD": true, "JPY": true, "EUR": true, "GBP": true, "HKD": true, "CNY": true, "KRW": true,
+	"SGD": true, "AUD": true, "CAD": true, "THB": true, "VND": true, "MYR": true, "PHP": true, "IDR": true,
+}
+
+// currency returns the code of the currency word @s, empty if unknown.
+func currency(s string) string {
+	if c, ok := currencies[strings.ToLower(s)]; ok {
+		return c
+	}
+	if c := strings.ToUpper(s); codes[c] {
+		return c
+	}
+	return ""
+}
+
+var amountRe = regexp.MustCompile(`^(\D*?)(\d{1,3}(?:,\d{3})+|\d+)(?:\.(\d{1,2}))?(\D*)$`)
+
+// amount parses "120", "1,200.5", "$30", "NT$120", "120元" or "12.5usd" into hundredths and the currency code,
+// which is empty if not given. ok is false if @s is not an amount.
+func amount(s string) (cents int64, cur string, ok bool) {
+	m := amountRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0, "", false
+	}
+	for _, affix := range []string{m[1], m[4]} {
+		switch affix {
+		case "", "$", "元", "塊":
+		default:
+			if cur != "" {
+				return 0, "", false
+			}
+			if cur = currency(affix); cur == "" {
+				return 0, "", false
+			}
+		}
+	}
+	n, err := strconv.ParseInt(strings.ReplaceAll(m[2], ",", ""), 10, 64)
+	if err != nil || n > 1e12 {
+		return 0, "", false
+	}
+	frac := m[3]
+	if len(frac) == 1 {
+		frac += "0"
+	}
+	f, _ := strconv.ParseInt("0"+frac, 10, 64)
+	cents = n*100 + f
+	return cents, cur, cents > 0
+}
+
+// format formats @cents for humans, e.g. "1,200" or "12.50".
+func format(cents int64) string {
+	s := strconv.FormatInt(cents/100, 10)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	if f := cents % 100; f != 0 {
+		s += fmt.Sprintf(".%02d", f)
+	}
+	return s
+}
+
+// Categories of expenses, other categories could be given with #name.
+const (
+	FOOD      = "food"
+	TRANSPORT = "transport"
+	SHOPPING  = "shopping"
+	FUN       = "fun"
+	BILLS     = "bills"
+	HEALTH    = "health"
+	OTHER     = "other"
+)
+
+// keywords guess the category of an expense from its note.
+var keywords = []struct {
+	category string
+	words    []string
+}{
+	{FOOD, []string{"餐", "飯", "麵", "食", "吃", "咖啡", "飲料", "茶", "宵夜", "點心", "lunch", "dinner", "breakfast", "coffee", "meal", "snack"}},
+	{TRANSPORT, []string{"車", "捷運", "高鐵", "台鐵", "火車", "機票", "加油", "停車", "悠遊卡", "uber", "taxi", "bus", "mrt", "train", "flight", "parking"}},
+	{BILLS, []string{"房租", "租金", "水費", "電費", "瓦斯", "網路", "電話費", "保險", "rent", "bill", "insurance"}},
+	{HEALTH, []string{"醫", "藥", "診", "牙", "健身", "doctor", "medicine", "clinic", "gym"}},
+	{FUN, []string{"電影", "遊戲", "唱歌", "ktv", "門票", "旅遊", "movie", "game", "ticket", "travel"}},
+	{SHOPPING, []string{"買", "衣", "鞋", "超市", "日用品", "全聯", "shopping", "clothes", "grocery"}},
+}
+
+// categories are the built-in categories, which have localized names.
+var categories = []string{FOOD, TRANSPORT, SHOPPING, FUN, BILLS, HEALTH, OTHER}
+
+// category returns the category named @w, given as "food" or "#food", empty if @w is not one.
+func category(w string) string {
+	if tag := strings.TrimLeft(w, "#＃"); tag != w {
+		return strings.ToLower(strings.ReplaceAll(tag, ",", ""))
+	}
+	w = strings.ToLower(w)
+	for _, c := range categories {
+		if w == c {
+			return c
+		}
+	}
+	return ""
+}
+
+// guess guesses the category of @note by keywords, [OTHER] if none matches.
+func guess(note string) string {
+	note = strings.ToLower(note)
+	for _, k := range keywords {
+		for _, w := range k.words {
+			if strings.Contains(note, w) {
+				return k.category
+			}
+		}
+	}
+	return OTHER
+}
+
+// parse parses "120 food lunch with Amy", "午餐 120" or "12.5 usd #travel taxi" into an expense:
+// the amount, an optional currency word after it, a category given by name or #name,
+// and the rest as the note. The category is guessed from the note if not given,
+// and the currency is @def if not given.
+func parse(s, def string) (*Expense, bool) {
+	e := &Expense{}
+	note := []string{}
+	words := strings.Fields(s)
+	for i := 0; i < len(words); i++ {
+		w := words[i]
+		if e.Amount == 0 {
+			if cents, cur, ok := amount(w); ok {
+				e.Amount, e.Currency = cents, cur
+				if i+1 < len(words) && cur == "" {
+					if cur = currency(words[i+1]); cur != "" {
+						e.Currency = cur
+						i++
+					}
+				}
+				continue
+			}
+		}
+		if c := category(w); c != "" && e.Category == "" {
+			e.Category = c
+			continue
+		}
+		note = append(note, w)
+	}
+	if e.Amount == 0 {
+		return nil, false
+	}
+	e.Note = strings.Join(note, " ")
+	if e.Category == "" {
+		e.Category = guess(e.Note)
+	}
+	if e.Currency == "" {
+		e.Currency = def
+	}
+	return e, true
+}
+
+// casual parses a short text like "午餐 120" or "coffee 4.5 usd" sent without a command,
+// which should be a few words without digits and an amount at either end,
+// so that chatting like "明天 10 點" is not taken as an expense.
+func casual(s, def string) (*Expense, bool) {
+	words := strings.Fields(s)
+	if len(words) < 2 || len(words) > 4 || len([]rune(s)) > 30 {
+		return nil, false
+	}
+	at, last := -1, len(words)-1
+	if currency(words[last]) != "" {
+		last--
+	}
+	if _, _, ok := amount(words[0]); ok {
+		at = 0
+	} else if _, _, ok := amount(words[last]); ok {
+		at = last
+	}
+	if at < 0 {
+		return nil, false
+	}
+	for i, w := range words {
+		if i == at || (i > last && at == last) {
+			continue
+		}
+		for _, r := range w {
+			if unicode.IsDigit(r) {
+				return nil, false
+			}
+		}
+	}
+	e, ok := parse(s, def)
+	if !ok || e.Note == "" {
+		return nil, false
+	}
+	return e, true
+}
+
+// month parses "2026-10", "10", "this" or "last" relative to @now into the start of the month,
+// the current month if @s is empty.
+func month(s string, now time.Time) (time.Time, bool) {
+	this := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	switch strings.ToLower(s) {
+	case "", "this", "本月", "這個月":
+		return this, true
+	case "last", "上月", "上個月":
+		return this.AddDate(0, -1, 0), true
+	}
+	if t, err := time.ParseInLocation("2006-01", s, now.Location()); err == nil {
+		return t, true
+	}
+	if t, err := time.ParseInLocation("2006/01", s, now.Location()); err == nil {
+		return t, true
+	}
+	if m, err := strconv.Atoi(strings.TrimSuffix(s, "月")); err == nil && m >= 1 && m <= 12 {
+		t := time.Date(now.Year(), time.Month(m), 1, 0, 0, 0, 0, now.Location())
+		if t.After(this) {
+			t = t.AddDate(-1, 0, 0)
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
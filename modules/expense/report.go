@@ -0,0 +1,304 @@
+/*
+	report.go
+	Purpose: Monthly summaries and xlsx reports of expenses.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package expense
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"app/core/account"
+	"app/core/auth"
+	"app/core/errors"
+	"app/core/group"
+	"app/core/msg"
+	"app/core/server"
+	"app/core/util"
+	"app/modules/media"
+
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/exp/slog"
+)
+
+// ReportPath is the gateway path the xlsx reports are served under, see [Download].
+const ReportPath = "/api/expenses/report"
+
+// span returns the month starting at @from as [from, to) and its name, e.g. 2026-10.
+func span(from time.Time) (time.Time, time.Time, string) {
+	return from, from.AddDate(0, 1, 0), from.Format("2006-01")
+}
+
+// label returns the localized name of @cat, custom categories are shown as #name.
+func label(cat, locale string) string {
+	for _, c := range categories {
+		if c == cat {
+			return msg.T("expense.cat."+cat, locale, nil)
+		}
+	}
+	return "#" + cat
+}
+
+// tally is the sum of a category in a currency.
+type tally struct {
+	category, currency string
+	amount             int64
+	count              int
+	budget             int64
+}
+
+// summarize sums up the expenses of @chatID in the month starting at @from,
+// which are returned along with the template data of the summary.
+func summarize(ctx context.Context, chatID string, from time.Time, locale string) ([]*Expense, map[string]any, error) {
+	from, to, name := span(from)
+	list, err := List(ctx, chatID, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	budgets, err := Budgets(ctx, chatID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tallies, totals := map[string]*tally{}, map[string]int64{}
+	add := func(category, currency string) *tally {
+		l, ok := tallies[category+" "+currency]
+		if !ok {
+			l = &tally{category: category, currency: currency}
+			tallies[category+" "+currency] = l
+		}
+		return l
+	}
+	for _, e := range list {
+		l := add(e.Category, e.Currency)
+		l.amount += e.Amount
+		l.count++
+		totals[e.Currency] += e.Amount
+	}
+	for _, b := range budgets {
+		add(b.Category, b.Currency).budget = b.Amount
+	}
+
+	sorted := make([]*tally, 0, len(tallies))
+	for _, l := range tallies {
+		sorted = append(sorted, l)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].currency != sorted[j].currency {
+			return totals[sorted[i].currency] > totals[sorted[j].currency]
+		}
+		if sorted[i].amount != sorted[j].amount {
+			return sorted[i].amount > sorted[j].amount
+		}
+		return sorted[i].category < sorted[j].category
+	})
+	rows := make([]map[string]any, len(sorted))
+	for i, l := range sorted {
+		row := map[string]any{
+			"Category": label(l.category, locale),
+			"Currency": l.currency,
+			"Amount":   format(l.amount),
+			"Value":    float64(l.amount) / 100,
+			"Count":    l.count,
+			"Budget":   "",
+			"Used":     0,
+			"Bar":      0,
+		}
+		if total := totals[l.currency]; total > 0 {
+			row["Bar"] = int(l.amount * 100 / total)
+		}
+		if l.budget > 0 {
+			used := int(l.amount * 100 / l.budget)
+			row["Budget"], row["Limit"], row["Used"] = format(l.budget), float64(l.budget)/100, used
+			row["Bar"] = used
+			if used > 100 {
+				row["Bar"] = 100
+			}
+		}
+		rows[i] = row
+	}
+	currencies := make([]string, 0, len(totals))
+	for c := range totals {
+		currencies = append(currencies, c)
+	}
+	sort.Slice(currencies, func(i, j int) bool { return totals[currencies[i]] > totals[currencies[j]] })
+	sums := make([]map[string]any, len(currencies))
+	for i, c := range currencies {
+		sums[i] = map[string]any{"Currency": c, "Amount": format(totals[c])}
+	}
+
+	return list, map[string]any{
+		"Month":      name,
+		"Count":      len(list),
+		"Totals":     sums,
+		"Categories": rows,
+		"URL":        URL(chatID, from),
+	}, nil
+}
+
+// Report builds the xlsx report of @chatID for the month starting at @from,
+// with a sheet of the expenses and a sheet of the sums by category.
+func Report(ctx context.Context, chatID string, from time.Time, locale string) (*excelize.File, error) {
+	list, data, err := summarize(ctx, chatID, from, locale)
+	if err != nil {
+		return nil, err
+	}
+	f := excelize.NewFile()
+	details, sums := msg.T("expense.xlsx.details", locale, nil), msg.T("expense.xlsx.summary", locale, nil)
+	if err := f.SetSheetName("Sheet1", details); err != nil {
+		return nil, err
+	}
+	if _, err := f.NewSheet(sums); err != nil {
+		return nil, err
+	}
+	header := func(sheet string, keys ...string) error {
+		for i, k := range keys {
+			if err := f.SetCellValue(sheet, util.Cellname(i+1, 1), msg.T("expense.xlsx."+k, locale, nil)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := header(details, "date", "category", "note", "amount", "currency", "by", "receipt"); err != nil {
+		return nil, err
+	}
+	names := map[string]string{}
+	for i, e := range list {
+		if _, ok := names[e.UserID]; !ok {
+			names[e.UserID] = account.Name(ctx, e.UserID)
+		}
+		receipt := ""
+		if e.Receipt != "" {
+			if m, err := media.ByMessage(ctx, e.Receipt); err == nil {
+				receipt = media.URL(m.ID)
+			}
+		}
+		values := []any{
+			e.SpentAt.Format("2006-01-02 15:04"), label(e.Category, locale), e.Note,
+			float64(e.Amount) / 100, e.Currency, names[e.UserID], receipt,
+		}
+		if err := f.SetSheetRow(details, util.Cellname(1, i+2), &values); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := header(sums, "category", "currency", "amount", "count", "budget", "used"); err != nil {
+		return nil, err
+	}
+	for i, row := range data["Categories"].([]map[string]any) {
+		budget, used := any(""), ""
+		if row["Budget"] != "" {
+			budget, used = row["Limit"], strconv.Itoa(row["Used"].(int))+"%"
+		}
+		values := []any{row["Category"], row["Currency"], row["Value"], row["Count"], budget, used}
+		if err := f.SetSheetRow(sums, util.Cellname(1, i+2), &values); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, sheet := range []string{details, sums} {
+		if err := util.AutoFitColWidth(f, sheet, "A:G"); err != nil {
+			return nil, err
+		}
+		if err := util.FreezeHeader(f, sheet); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// URL returns the download link of the report of @chatID for the month starting at @from,
+// which expires after [Option.TTL].
+func URL(chatID string, from time.Time) string {
+	month := from.Format("2006-01")
+	exp := strconv.FormatInt(time.Now().Add(opt.TTL).Unix(), 10)
+	q := url.Values{"chat": {chatID}, "month": {month}, "exp": {exp}, "sig": {sign(chatID, month, exp)}}
+	return strings.TrimSuffix(opt.BaseURL, "/") + ReportPath + "?" + q.Encode()
+}
+
+// sign signs the report of @chatID in @month and expiry @exp with [auth.Secret].
+func sign(chatID, month, exp string) string {
+	h := hmac.New(sha256.New, auth.Secret)
+	h.Write([]byte("expense." + chatID + "." + month + "." + exp))
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// allowed checks if the request is signed by [URL], or made by a signed in user of the chat.
+func allowed(r *http.Request, chatID, month string) *errors.Error {
+	q := r.URL.Query()
+	if sig := q.Get("sig"); sig != "" {
+		t, err := strconv.ParseInt(q.Get("exp"), 10, 64)
+		if err != nil || !hmac.Equal([]byte(sig), []byte(sign(chatID, month, q.Get("exp")))) {
+			return errors.ErrUnauthorized.SetInfo("invalid signature")
+		}
+		if time.Now().Unix() > t {
+			return errors.ErrUnauthorized.SetInfo("link expired")
+		}
+		return nil
+	}
+	u, ok := auth.GetUserFromToken(server.GetHttpAuthToken(r))
+	if !ok {
+		return errors.ErrUnauthorized
+	}
+	if chatID == u.Username || u.Group >= auth.ADMIN {
+		return nil
+	}
+	member, err := group.IsMember(r.Context(), chatID, u.Username)
+	if err != nil {
+		return errors.Convert(err)
+	}
+	if !member {
+		return errors.ErrForbidden
+	}
+	return nil
+}
+
+// Download serves the xlsx report of ?chat= in ?month=, e.g. 2026-10,
+// from a link made by [URL] or for a signed in user of the chat.
+// It is a handler of the gateway mux, see [runtime.ServeMux.HandlePath].
+func Download(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	q := r.URL.Query()
+	chatID, month := q.Get("chat"), q.Get("month")
+	from, err := time.ParseInLocation("2006-01", month, time.Local)
+	if chatID == "" || err != nil {
+		server.HttpAbort(w, r, errors.ErrBadRequest.SetInfo("chat and month are required"))
+		return
+	}
+	if err := allowed(r, chatID, month); err != nil {
+		server.HttpAbort(w, r, err)
+		return
+	}
+	f, err := Report(r.Context(), chatID, from, group.Locale(r.Context(), chatID))
+	if err != nil {
+		slog.Error("build report failed", util.ErrAtrr(err), slog.String("mod", "expense"), slog.String("act", "report"),
+			slog.String("chat", chatID), slog.String("month", month))
+		server.HttpAbort(w, r, errors.Convert(err))
+		return
+	}
+	defer f.Close()
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": "expenses-" + month + ".xlsx"}))
+	w.Header().Set("Cache-Control", "private, no-store")
+	if _, err := f.WriteTo(w); err != nil {
+		slog.Error("write report failed", util.ErrAtrr(err), slog.String("mod", "expense"), slog.String("act", "report"),
+			slog.String("chat", chatID), slog.String("month", month))
+	}
+}
@@ -0,0 +1,259 @@
+/*
+	store.go
+	Purpose: Persist expenses, budgets and report subscriptions in the database.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package expense
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"app/core/database"
+	"app/core/errors"
+
+	"github.com/rs/xid"
+)
+
+func init() {
+	database.Register(&database.Migration{
+		ID: "20261017_expense",
+		Stmts: []string{
+			`CREATE TABLE expenses (
+				id TEXT PRIMARY KEY,
+				chat_id TEXT NOT NULL,
+				user_id TEXT NOT NULL,
+				amount BIGINT NOT NULL,
+				currency TEXT NOT NULL,
+				category TEXT NOT NULL,
+				note TEXT NOT NULL,
+				receipt TEXT NOT NULL,
+				spent_at BIGINT NOT NULL,
+				created_at BIGINT NOT NULL
+			)`,
+			`CREATE INDEX idx_expenses_chat ON expenses (chat_id, spent_at)`,
+			`CREATE TABLE expense_budgets (
+				chat_id TEXT NOT NULL,
+				category TEXT NOT NULL,
+				currency TEXT NOT NULL,
+				amount BIGINT NOT NULL,
+				warned TEXT NOT NULL,
+				PRIMARY KEY (chat_id, category)
+			)`,
+			`CREATE TABLE expense_reports (
+				chat_id TEXT PRIMARY KEY,
+				created_by TEXT NOT NULL,
+				created_at BIGINT NOT NULL
+			)`,
+		},
+	})
+}
+
+// Expense is a spending logged in a chat, which is shared by the members of a group chat.
+type Expense struct {
+	ID        string
+	ChatID    string
+	UserID    string
+	Amount    int64  // in hundredths of the currency
+	Currency  string // ISO 4217 code, e.g. TWD
+	Category  string
+	Note      string
+	Receipt   string // message ID of the receipt photo, see [media.ByMessage]
+	SpentAt   time.Time
+	CreatedAt time.Time
+}
+
+const columns = "id, chat_id, user_id, amount, currency, category, note, receipt, spent_at, created_at"
+
+func scan(row interface{ Scan(...any) error }) (*Expense, error) {
+	e := &Expense{}
+	var spent, created int64
+	if err := row.Scan(&e.ID, &e.ChatID, &e.UserID, &e.Amount, &e.Currency, &e.Category, &e.Note, &e.Receipt,
+		&spent, &created); err != nil {
+		return nil, err
+	}
+	e.SpentAt, e.CreatedAt = time.Unix(spent, 0), time.Unix(created, 0)
+	return e, nil
+}
+
+// Create saves the expense @e, which is spent now if its time is not set.
+func Create(ctx context.Context, e *Expense) error {
+	e.ID, e.CreatedAt = xid.New().String(), time.Now()
+	if e.SpentAt.IsZero() {
+		e.SpentAt = e.CreatedAt
+	}
+	_, err := database.DB().ExecContext(ctx, "INSERT INTO expenses ("+columns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+		e.ID, e.ChatID, e.UserID, e.Amount, e.Currency, e.Category, e.Note, e.Receipt, e.SpentAt.Unix(), e.CreatedAt.Unix())
+	return database.Err(err)
+}
+
+// Latest gets the last expense logged by @userID in @chatID.
+func Latest(ctx context.Context, chatID, userID string) (*Expense, error) {
+	e, err := scan(database.DB().QueryRowContext(ctx,
+		"SELECT "+columns+" FROM expenses WHERE chat_id = $1 AND user_id = $2 ORDER BY created_at DESC, id DESC LIMIT 1",
+		chatID, userID))
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	return e, nil
+}
+
+// List lists the expenses of @chatID spent in [@from, @to), earliest first.
+func List(ctx context.Context, chatID string, from, to time.Time) ([]*Expense, error) {
+	rows, err := database.DB().QueryContext(ctx,
+		"SELECT "+columns+" FROM expenses WHERE chat_id = $1 AND spent_at >= $2 AND spent_at < $3 ORDER BY spent_at, id",
+		chatID, from.Unix(), to.Unix())
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	defer rows.Close()
+	list := []*Expense{}
+	for rows.Next() {
+		e, err := scan(rows)
+		if err != nil {
+			return nil, database.Err(err)
+		}
+		list = append(list, e)
+	}
+	return list, database.Err(rows.Err())
+}
+
+// Delete deletes the expense @id.
+func Delete(ctx context.Context, id string) error {
+	return affected(database.DB().ExecContext(ctx, "DELETE FROM expenses WHERE id = $1", id))
+}
+
+// attach sets the receipt of the expense @id if it has none, ok is false if it has.
+func attach(ctx context.Context, id, messageID string) (bool, error) {
+	res, err := database.DB().ExecContext(ctx, "UPDATE expenses SET receipt = $1 WHERE id = $2 AND receipt = ''", messageID, id)
+	if err != nil {
+		return false, database.Err(err)
+	}
+	n, err := res.RowsAffected()
+	return n > 0, database.Err(err)
+}
+
+// spent sums the expenses of @chatID in @category and @currency spent in [@from, @to).
+func spent(ctx context.Context, chatID, category, currency string, from, to time.Time) (int64, error) {
+	var sum int64
+	err := database.DB().QueryRowContext(ctx, `SELECT COALESCE(SUM(amount), 0) FROM expenses
+		WHERE chat_id = $1 AND category = $2 AND currency = $3 AND spent_at >= $4 AND spent_at < $5`,
+		chatID, category, currency, from.Unix(), to.Unix()).Scan(&sum)
+	return sum, database.Err(err)
+}
+
+// Budget is the monthly limit of a category in a chat.
+type Budget struct {
+	ChatID   string
+	Category string
+	Currency string
+	Amount   int64  // in hundredths of the currency
+	Warned   string // the month the budget has been warned, e.g. 2026-10
+}
+
+// SetBudget sets the budget of @category in @chatID, a zero @amount removes it.
+func SetBudget(ctx context.Context, chatID, category, currency string, amount int64) error {
+	if amount <= 0 {
+		return affected(database.DB().ExecContext(ctx,
+			"DELETE FROM expense_budgets WHERE chat_id = $1 AND category = $2", chatID, category))
+	}
+	_, err := database.DB().ExecContext(ctx, `INSERT INTO expense_budgets (chat_id, category, currency, amount, warned)
+		VALUES ($1, $2, $3, $4, '') ON CONFLICT (chat_id, category) DO UPDATE SET currency = $3, amount = $4, warned = ''`,
+		chatID, category, currency, amount)
+	return database.Err(err)
+}
+
+// Budgets lists the budgets of @chatID by category.
+func Budgets(ctx context.Context, chatID string) ([]*Budget, error) {
+	rows, err := database.DB().QueryContext(ctx,
+		"SELECT chat_id, category, currency, amount, warned FROM expense_budgets WHERE chat_id = $1 ORDER BY category", chatID)
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	defer rows.Close()
+	list := []*Budget{}
+	for rows.Next() {
+		b := &Budget{}
+		if err := rows.Scan(&b.ChatID, &b.Category, &b.Currency, &b.Amount, &b.Warned); err != nil {
+			return nil, database.Err(err)
+		}
+		list = append(list, b)
+	}
+	return list, database.Err(rows.Err())
+}
+
+// budget gets the budget of @category in @chatID, nil if not set.
+func budget(ctx context.Context, chatID, category string) (*Budget, error) {
+	b := &Budget{}
+	err := database.DB().QueryRowContext(ctx,
+		"SELECT chat_id, category, currency, amount, warned FROM expense_budgets WHERE chat_id = $1 AND category = $2",
+		chatID, category).Scan(&b.ChatID, &b.Category, &b.Currency, &b.Amount, &b.Warned)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	return b, nil
+}
+
+// warn marks the budget of @category in @chatID warned for @month, ok is false if it has been warned.
+func warn(ctx context.Context, chatID, category, month string) (bool, error) {
+	res, err := database.DB().ExecContext(ctx,
+		"UPDATE expense_budgets SET warned = $1 WHERE chat_id = $2 AND category = $3 AND warned <> $1", month, chatID, category)
+	if err != nil {
+		return false, database.Err(err)
+	}
+	n, err := res.RowsAffected()
+	return n > 0, database.Err(err)
+}
+
+// Subscribe sets if the monthly report is pushed to @chatID.
+func Subscribe(ctx context.Context, chatID, userID string, on bool) error {
+	var err error
+	if on {
+		_, err = database.DB().ExecContext(ctx, `INSERT INTO expense_reports (chat_id, created_by, created_at)
+			VALUES ($1, $2, $3) ON CONFLICT (chat_id) DO NOTHING`, chatID, userID, time.Now().Unix())
+	} else {
+		_, err = database.DB().ExecContext(ctx, "DELETE FROM expense_reports WHERE chat_id = $1", chatID)
+	}
+	return database.Err(err)
+}
+
+// subscribers lists the chats the monthly report is pushed to.
+func subscribers(ctx context.Context) ([]string, error) {
+	rows, err := database.DB().QueryContext(ctx, "SELECT chat_id FROM expense_reports ORDER BY created_at")
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	defer rows.Close()
+	list := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, database.Err(err)
+		}
+		list = append(list, id)
+	}
+	return list, database.Err(rows.Err())
+}
+
+func affected(res sql.Result, err error) error {
+	if err != nil {
+		return database.Err(err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return database.Err(err)
+	} else if n == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
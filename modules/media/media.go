@@ -0,0 +1,201 @@
+/*
+	media.go
+	Purpose: Ingest media messages into the blob store.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+// Package media keeps the images, videos, audios and files sent to the bot.
+//
+// Contents are downloaded from LINE as they arrive, since LINE only keeps them for a while,
+// and put into the [storage] backend with their metadata in the database.
+// Stored media are downloaded through signed links that expire after [Option.TTL], see [URL].
+package media
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"app/core/command"
+	"app/core/dedup"
+	"app/core/line"
+	"app/core/storage"
+	"app/core/util"
+
+	"github.com/rs/xid"
+	"golang.org/x/exp/slog"
+)
+
+// Option configures the media.
+type Option struct {
+	// BaseURL is the external url of the server, which download links are made of.
+	BaseURL string
+	// TTL is how long a download link is valid.
+	TTL time.Duration
+}
+
+var opt = Option{TTL: time.Hour}
+
+// Setup sets the options, empty fields are left unchanged.
+func Setup(o *Option) {
+	if o.BaseURL != "" {
+		opt.BaseURL = o.BaseURL
+	}
+	if o.TTL > 0 {
+		opt.TTL = o.TTL
+	}
+}
+
+// latest is how many media are listed by the media command.
+const latest = 5
+
+func init() {
+	command.Register(&command.Command{
+		Name:    "media",
+		Aliases: []string{"檔案"},
+		Desc:    "media.desc",
+		Skill:   "media",
+		Handler: list,
+	})
+}
+
+// Service is the [service.Service] which stores the media messages.
+var Service = &ingester{}
+
+type ingester struct {
+	closers []func()
+}
+
+func (s *ingester) Init() error {
+	for _, topic := range []string{line.TopicImage, line.TopicVideo, line.TopicAudio, line.TopicFile} {
+		s.closers = append(s.closers, dedup.Handle("media", topic, dedup.ExactlyOnce, ingest))
+	}
+	return nil
+}
+
+func (s *ingester) Load() {}
+
+func (s *ingester) Del() {
+	for _, close := range s.closers {
+		close()
+	}
+	s.closers = nil
+}
+
+// ingest downloads the content of a media message and stores it,
+// an error releases the event so a redelivery could try again.
+func ingest(ctx context.Context, evt *line.Event) error {
+	m := evt.Message
+	if m == nil || (m.ContentProvider != nil && m.ContentProvider.Type == "external") {
+		// contents provided by external urls are not kept by LINE
+		return nil
+	}
+	if ok, err := exists(ctx, m.ID); err != nil || ok {
+		return err
+	}
+	media := &Media{
+		ID:        xid.New().String(),
+		MessageID: m.ID,
+		Type:      m.Type,
+		FileName:  m.FileName,
+		CreatedAt: time.Now(),
+	}
+	if evt.Source != nil {
+		media.ChatID, media.UserID = evt.Source.ID(), evt.Source.UserID
+	}
+	media.Key = "media/" + media.CreatedAt.Format("2006/01") + "/" + media.ID
+
+	err := fetch(ctx, media)
+	if err != nil {
+		slog.Error("store media failed", util.ErrAtrr(err), slog.String("mod", "media"), slog.String("act", "ingest"),
+			slog.String("message", m.ID))
+		return err
+	}
+	ok, err := create(ctx, media)
+	if err != nil || !ok {
+		// drop the blob of a failed or concurrent ingestion
+		if derr := storage.Delete(ctx, media.Key); derr != nil {
+			slog.Warn("delete media failed", util.ErrAtrr(derr), slog.String("mod", "media"), slog.String("act", "ingest"),
+				slog.String("key", media.Key))
+		}
+		return err
+	}
+	slog.Info("media stored", slog.String("mod", "media"), slog.String("act", "ingest"), slog.String("id", media.ID),
+		slog.String("type", media.Type), slog.String("mime", media.MimeType), slog.Int64("size", media.Size))
+	return nil
+}
+
+// fetch downloads the content of @m into the blob store, filling its mime type, size and checksum.
+//
+// The content is buffered in a temporary file, since the size and checksum are only known after the download.
+func fetch(ctx context.Context, m *Media) error {
+	body, contentType, err := line.Content(ctx, m.MessageID)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	tmp, err := os.CreateTemp("", "media-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), body)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	m.MimeType, m.Size, m.Checksum = contentType, size, hex.EncodeToString(hash.Sum(nil))
+	return storage.Put(ctx, m.Key, tmp, size, contentType)
+}
+
+func list(c *command.Context) error {
+	media, err := List(c, c.ChatID(), latest)
+	if err != nil {
+		return err
+	}
+	views := make([]map[string]any, len(media))
+	for i, m := range media {
+		views[i] = map[string]any{
+			"Name": m.Name(),
+			"Type": m.Type,
+			"Size": size(m.Size),
+			"Time": m.CreatedAt.Format("2006/01/02 15:04"),
+			"URL":  URL(m.ID),
+		}
+	}
+	return c.ReplyText("media.list", map[string]any{"Media": views, "TTL": opt.TTL.String()})
+}
+
+// size formats @n bytes for humans, e.g. 1.2 MB.
+func size(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
@@ -0,0 +1,128 @@
+/*
+	store.go
+	Purpose: Persist the metadata of stored media in the database.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Add ByMessage to find media of a message
+*/
+
+package media
+
+import (
+	"context"
+	"time"
+
+	"app/core/database"
+)
+
+func init() {
+	database.Register(&database.Migration{
+		ID: "20261017_media",
+		Stmts: []string{
+			`CREATE TABLE media (
+				id TEXT PRIMARY KEY,
+				message_id TEXT NOT NULL UNIQUE,
+				chat_id TEXT NOT NULL,
+				user_id TEXT NOT NULL,
+				type TEXT NOT NULL,
+				file_name TEXT NOT NULL,
+				mime_type TEXT NOT NULL,
+				size BIGINT NOT NULL,
+				checksum TEXT NOT NULL,
+				storage_key TEXT NOT NULL,
+				created_at BIGINT NOT NULL
+			)`,
+			`CREATE INDEX idx_media_chat ON media (chat_id, created_at)`,
+		},
+	})
+}
+
+// Media is the metadata of a stored image, video, audio or file.
+type Media struct {
+	ID        string
+	MessageID string
+	ChatID    string
+	UserID    string
+	Type      string
+	FileName  string
+	MimeType  string
+	Size      int64
+	Checksum  string // hex encoded sha256 of the content
+	Key       string // key in the blob store
+	CreatedAt time.Time
+}
+
+const columns = "id, message_id, chat_id, user_id, type, file_name, mime_type, size, checksum, storage_key, created_at"
+
+func scan(row interface{ Scan(...any) error }) (*Media, error) {
+	m := &Media{}
+	var created int64
+	if err := row.Scan(&m.ID, &m.MessageID, &m.ChatID, &m.UserID, &m.Type, &m.FileName,
+		&m.MimeType, &m.Size, &m.Checksum, &m.Key, &created); err != nil {
+		return nil, err
+	}
+	m.CreatedAt = time.Unix(created, 0)
+	return m, nil
+}
+
+// create stores the metadata @m, ok is false if the message is already stored.
+func create(ctx context.Context, m *Media) (bool, error) {
+	res, err := database.DB().ExecContext(ctx, `INSERT INTO media
+		(id, message_id, chat_id, user_id, type, file_name, mime_type, size, checksum, storage_key, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) ON CONFLICT (message_id) DO NOTHING`,
+		m.ID, m.MessageID, m.ChatID, m.UserID, m.Type, m.FileName, m.MimeType, m.Size, m.Checksum, m.Key, m.CreatedAt.Unix())
+	if err != nil {
+		return false, database.Err(err)
+	}
+	n, err := res.RowsAffected()
+	return n > 0, database.Err(err)
+}
+
+// Get gets the metadata of the media @id.
+func Get(ctx context.Context, id string) (*Media, error) {
+	m, err := scan(database.DB().QueryRowContext(ctx, "SELECT "+columns+" FROM media WHERE id = $1", id))
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	return m, nil
+}
+
+// ByMessage gets the metadata of the media of the message @messageID.
+func ByMessage(ctx context.Context, messageID string) (*Media, error) {
+	m, err := scan(database.DB().QueryRowContext(ctx, "SELECT "+columns+" FROM media WHERE message_id = $1", messageID))
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	return m, nil
+}
+
+// exists reports whether the message @messageID is already stored.
+func exists(ctx context.Context, messageID string) (bool, error) {
+	var n int
+	err := database.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM media WHERE message_id = $1", messageID).Scan(&n)
+	return n > 0, database.Err(err)
+}
+
+// List lists the latest @limit media of @chatID.
+func List(ctx context.Context, chatID string, limit int) ([]*Media, error) {
+	rows, err := database.DB().QueryContext(ctx,
+		"SELECT "+columns+" FROM media WHERE chat_id = $1 ORDER BY created_at DESC, id DESC LIMIT $2", chatID, limit)
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	defer rows.Close()
+	list := []*Media{}
+	for rows.Next() {
+		m, err := scan(rows)
+		if err != nil {
+			return nil, database.Err(err)
+		}
+		list = append(list, m)
+	}
+	return list, database.Err(rows.Err())
+}
@@ -0,0 +1,171 @@
+/*
+	note.go
+	Purpose: Chat commands to save and find notes.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Remove the interceptor on Del, skip active conversations
+*/
+
+// Package note saves free-form notes of users, like "/note wifi password is 1234",
+// and finds them later with "/find wifi".
+//
+// Notes are searched with the full-text index of the database, FTS5 on SQLite
+// or tsvector on PostgreSQL when built with the postgres tag, see [Segment] for how CJK text is tokenized.
+// In one-on-one chats, texts that are not commands are saved as notes too if [Option.AutoSave] is set,
+// so users could forward messages from other chats to the bot to keep them.
+package note
+
+import (
+	"strings"
+
+	"app/core/command"
+	"app/core/conversation"
+	"app/core/msg"
+)
+
+// Option configures the notes.
+type Option struct {
+	// AutoSave saves texts that are not commands in one-on-one chats as notes.
+	AutoSave bool
+}
+
+var opt = Option{AutoSave: true}
+
+// Setup sets the options.
+func Setup(o *Option) {
+	opt.AutoSave = o.AutoSave
+}
+
+const (
+	// found is how many notes are replied for a search.
+	found = 5
+	// snippet is how many characters of a note are shown in a reply.
+	snippet = 60
+)
+
+func init() {
+	command.Register(
+		&command.Command{
+			Name:    "note",
+			Aliases: []string{"筆記"},
+			Args:    []command.Arg{{Name: "text", Rest: true}},
+			Desc:    "note.desc",
+			Skill:   "note",
+			Handler: save,
+		},
+		&command.Command{
+			Name:    "note del",
+			Args:    []command.Arg{{Name: "id", Required: true}},
+			Desc:    "note.del.desc",
+			Skill:   "note",
+			Handler: del,
+		},
+		&command.Command{
+			Name:    "find",
+			Aliases: []string{"找"},
+			Args:    []command.Arg{{Name: "keywords", Required: true, Rest: true}},
+			Desc:    "note.find.desc",
+			Skill:   "note",
+			Handler: find,
+		},
+	)
+}
+
+// Service is the [service.Service] which saves texts as notes, it should be registered after the conversations,
+// so that the answers of dialogs are not taken as notes.
+var Service = &note{}
+
+type note struct {
+	close func()
+}
+
+func (s *note) Init() error {
+	s.close = command.Intercept(autosave)
+	return nil
+}
+
+func (*note) Load() {}
+
+func (s *note) Del() {
+	if s.close != nil {
+		s.close()
+	}
+}
+
+// autosave saves texts that are not commands in one-on-one chats without an active conversation.
+func autosave(c *command.Context) (bool, error) {
+	if !opt.AutoSave || c.InGroup() || command.IsCommand(c.Text) || strings.TrimSpace(c.Text) == "" {
+		return false, nil
+	}
+	if c.Event.Source == nil || c.Event.Source.UserID == "" {
+		return false, nil
+	}
+	// the answers of dialogs are not notes, even if the conversations intercept after the notes
+	if s, err := conversation.Active(c); err != nil || s != nil {
+		return false, err
+	}
+	return true, create(c, c.Text)
+}
+
+// save saves the text as a note, or lists the latest notes if nothing is given.
+func save(c *command.Context) error {
+	text := strings.TrimSpace(c.Args.Get("text"))
+	if text == "" {
+		return recent(c)
+	}
+	return create(c, text)
+}
+
+func create(c *command.Context, text string) error {
+	n := &Note{UserID: c.Event.Source.UserID, ChatID: c.ChatID(), Text: text}
+	if err := Create(c, n); err != nil {
+		return err
+	}
+	return c.ReplyText("note.saved", map[string]any{"ID": n.ID, "Text": Highlight(n.Text, "", "", "", snippet)})
+}
+
+func recent(c *command.Context) error {
+	notes, total, err := Recent(c, c.Event.Source.UserID, 0, found)
+	if err != nil {
+		return err
+	}
+	return c.ReplyText("note.recent", map[string]any{"Notes": views(notes, ""), "Total": total})
+}
+
+func find(c *command.Context) error {
+	query := c.Args.Get("keywords")
+	if len(Terms(query)) == 0 {
+		return c.ReplyText("command.usage", msg.Plain(c.Command.Usage()))
+	}
+	notes, total, err := Search(c, c.Event.Source.UserID, query, 0, found)
+	if err != nil {
+		return err
+	}
+	return c.ReplyText("note.found", map[string]any{"Notes": views(notes, query), "Total": total, "Query": query})
+}
+
+func del(c *command.Context) error {
+	id := c.Args.Get("id")
+	if err := Delete(c, c.Event.Source.UserID, id); err != nil {
+		return err
+	}
+	return c.ReplyText("note.deleted", msg.Plain(id))
+}
+
+// views are the template data of @notes, with the words of @query highlighted.
+func views(notes []*Note, query string) []map[string]any {
+	list := make([]map[string]any, len(notes))
+	for i, n := range notes {
+		list[i] = map[string]any{
+			"ID":   n.ID,
+			"Text": Highlight(n.Text, query, "【", "】", snippet),
+			"Time": n.CreatedAt.Format("2006/01/02 15:04"),
+		}
+	}
+	return list
+}
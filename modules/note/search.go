@@ -0,0 +1,185 @@
+/*
+	search.go
+	Purpose: Tokenize, index and highlight notes for full-text search.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package note
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"app/core/database"
+)
+
+// indexer is the full-text index of a database dialect.
+type indexer interface {
+	// add indexes the note @n within the transaction of @ex.
+	add(ctx context.Context, ex database.Executor, n *Note) error
+	// remove removes the note @id from the index within the transaction of @ex.
+	remove(ctx context.Context, ex database.Executor, id string) error
+	// search finds the notes of @userID matching all @terms, ranked by relevance.
+	search(ctx context.Context, userID string, terms []string, offset, limit int) ([]*Note, int, error)
+}
+
+// indexers are registered by dialect in files built for the database.
+var indexers = map[string]indexer{}
+
+// index returns the indexer of the current database, or a LIKE scan if the database has none.
+func index() indexer {
+	if idx, ok := indexers[database.Dialect()]; ok {
+		return idx
+	}
+	return like{}
+}
+
+// cjk checks if @r is written without spaces between words, e.g. Chinese and Japanese.
+func cjk(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul, unicode.Bopomofo)
+}
+
+// Segment splits @s into lower case tokens separated by spaces, ready to be indexed.
+//
+// Latin words are kept whole while every CJK character becomes a token on its own,
+// since Chinese has no spaces between words. A word like 牛奶 is then searched as the phrase "牛 奶",
+// which matches the characters next to each other whatever the length of the keyword.
+func Segment(s string) string {
+	b := &strings.Builder{}
+	space := true
+	sep := func() {
+		if !space {
+			b.WriteByte(' ')
+			space = true
+		}
+	}
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case cjk(r):
+			sep()
+			b.WriteRune(r)
+			space = false
+			sep()
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+			space = false
+		default:
+			sep()
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// Terms splits a query into its words, each segmented by [Segment], empty words are dropped.
+func Terms(query string) []string {
+	terms := []string{}
+	for _, w := range strings.Fields(query) {
+		if seg := Segment(w); seg != "" {
+			terms = append(terms, seg)
+		}
+	}
+	return terms
+}
+
+// phrases builds a query of quoted phrases, e.g. `"牛 奶" "milk"`,
+// which both FTS5 and websearch_to_tsquery read as all phrases must match.
+func phrases(terms []string) string {
+	quoted := make([]string, len(terms))
+	for i, t := range terms {
+		quoted[i] = `"` + t + `"`
+	}
+	return strings.Join(quoted, " ")
+}
+
+// Highlight wraps the words of @query found in @text with @open and @close,
+// and cuts @text around the first match if it is longer than @max runes.
+func Highlight(text, query, open, close string, max int) string {
+	src := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	if len(lower) != len(src) {
+		// case mapping changed the length, match on the text as is
+		lower = src
+	}
+	marked := make([]bool, len(src))
+	first := -1
+	for _, w := range strings.Fields(strings.ToLower(query)) {
+		word := []rune(w)
+		for i := 0; i+len(word) <= len(lower); i++ {
+			if string(lower[i:i+len(word)]) != w {
+				continue
+			}
+			for j := i; j < i+len(word); j++ {
+				marked[j] = true
+			}
+			if first < 0 || i < first {
+				first = i
+			}
+		}
+	}
+
+	start, end := 0, len(src)
+	if max > 0 && len(src) > max {
+		if first > max/4 {
+			start = first - max/4
+		}
+		if end = start + max; end > len(src) {
+			end, start = len(src), len(src)-max
+		}
+	}
+
+	b := &strings.Builder{}
+	if start > 0 {
+		b.WriteString("…")
+	}
+	for i := start; i < end; i++ {
+		if marked[i] && (i == start || !marked[i-1]) {
+			b.WriteString(open)
+		}
+		b.WriteRune(src[i])
+		if marked[i] && (i == end-1 || !marked[i+1]) {
+			b.WriteString(close)
+		}
+	}
+	if end < len(src) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+// like searches by scanning the notes, for databases without a full-text index.
+type like struct{}
+
+func (like) add(ctx context.Context, ex database.Executor, n *Note) error      { return nil }
+func (like) remove(ctx context.Context, ex database.Executor, id string) error { return nil }
+
+func (like) search(ctx context.Context, userID string, terms []string, offset, limit int) ([]*Note, int, error) {
+	list, _, err := Recent(ctx, userID, 0, -1)
+	if err != nil {
+		return nil, 0, err
+	}
+	found := []*Note{}
+	for _, n := range list {
+		seg, ok := " "+Segment(n.Text)+" ", true
+		for _, t := range terms {
+			ok = ok && strings.Contains(seg, " "+t+" ")
+		}
+		if ok {
+			found = append(found, n)
+		}
+	}
+	total := len(found)
+	if offset >= total {
+		return []*Note{}, total, nil
+	}
+	if end := offset + limit; limit >= 0 && end < total {
+		return found[offset:end], total, nil
+	}
+	return found[offset:], total, nil
+}
@@ -0,0 +1,76 @@
+//go:build postgres
+
+/*
+	search_postgres.go
+	Purpose: Full-text index of notes on PostgreSQL tsvector.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package note
+
+import (
+	"context"
+
+	"app/core/database"
+)
+
+func init() {
+	database.Register(&database.Migration{
+		ID:      "20261017_note_tsv",
+		Dialect: database.POSTGRES,
+		Stmts: []string{
+			// terms are segmented by Segment, the simple configuration keeps them as is without stemming
+			`ALTER TABLE notes ADD COLUMN terms TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE notes ADD COLUMN tsv tsvector GENERATED ALWAYS AS (to_tsvector('simple', terms)) STORED`,
+			`CREATE INDEX idx_notes_tsv ON notes USING GIN (tsv)`,
+		},
+	})
+	indexers[database.POSTGRES] = tsvector{}
+}
+
+type tsvector struct{}
+
+func (tsvector) add(ctx context.Context, ex database.Executor, n *Note) error {
+	_, err := ex.ExecContext(ctx, "UPDATE notes SET terms = $1 WHERE id = $2", Segment(n.Text), n.ID)
+	return err
+}
+
+// remove is a no-op, as the index is dropped along with the row.
+func (tsvector) remove(ctx context.Context, ex database.Executor, id string) error {
+	return nil
+}
+
+// search ranks by ts_rank, the higher the more relevant.
+func (tsvector) search(ctx context.Context, userID string, terms []string, offset, limit int) ([]*Note, int, error) {
+	rows, err := database.DB().QueryContext(ctx, `SELECT id, user_id, chat_id, text, created_at, COUNT(*) OVER ()
+		FROM notes, websearch_to_tsquery('simple', $1) q
+		WHERE user_id = $2 AND tsv @@ q
+		ORDER BY ts_rank(tsv, q) DESC, created_at DESC LIMIT $3 OFFSET $4`, phrases(terms), userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	list, total := []*Note{}, 0
+	for rows.Next() {
+		n, err := scan(rows, &total)
+		if err != nil {
+			return nil, 0, err
+		}
+		list = append(list, n)
+	}
+	if len(list) == 0 && offset > 0 {
+		// the window count is not returned without rows
+		err = database.DB().QueryRowContext(ctx, `SELECT COUNT(*) FROM notes, websearch_to_tsquery('simple', $1) q
+			WHERE user_id = $2 AND tsv @@ q`, phrases(terms), userID).Scan(&total)
+	}
+	if err == nil {
+		err = rows.Err()
+	}
+	return list, total, err
+}
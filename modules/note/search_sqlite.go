@@ -0,0 +1,78 @@
+//go:build !nosqlite
+
+/*
+	search_sqlite.go
+	Purpose: Full-text index of notes on SQLite FTS5.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package note
+
+import (
+	"context"
+
+	"app/core/database"
+)
+
+func init() {
+	database.Register(&database.Migration{
+		ID:      "20261017_note_fts",
+		Dialect: database.SQLITE,
+		Stmts: []string{
+			// terms are segmented by Segment, so the unicode61 tokenizer splits them by spaces only
+			`CREATE VIRTUAL TABLE notes_fts USING fts5(
+				note_id UNINDEXED,
+				user_id UNINDEXED,
+				terms,
+				tokenize = 'unicode61 remove_diacritics 2'
+			)`,
+		},
+	})
+	indexers[database.SQLITE] = fts5{}
+}
+
+type fts5 struct{}
+
+func (fts5) add(ctx context.Context, ex database.Executor, n *Note) error {
+	_, err := ex.ExecContext(ctx, "INSERT INTO notes_fts (note_id, user_id, terms) VALUES ($1, $2, $3)",
+		n.ID, n.UserID, Segment(n.Text))
+	return err
+}
+
+func (fts5) remove(ctx context.Context, ex database.Executor, id string) error {
+	_, err := ex.ExecContext(ctx, "DELETE FROM notes_fts WHERE note_id = $1", id)
+	return err
+}
+
+// search ranks by bm25, the lower the more relevant.
+func (fts5) search(ctx context.Context, userID string, terms []string, offset, limit int) ([]*Note, int, error) {
+	q := phrases(terms)
+	var total int
+	if err := database.DB().QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM notes_fts WHERE notes_fts MATCH $1 AND user_id = $2", q, userID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	rows, err := database.DB().QueryContext(ctx, `SELECT n.id, n.user_id, n.chat_id, n.text, n.created_at
+		FROM notes_fts f JOIN notes n ON n.id = f.note_id
+		WHERE notes_fts MATCH $1 AND f.user_id = $2
+		ORDER BY bm25(notes_fts), n.created_at DESC LIMIT $3 OFFSET $4`, q, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	list := []*Note{}
+	for rows.Next() {
+		n, err := scan(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		list = append(list, n)
+	}
+	return list, total, rows.Err()
+}
@@ -0,0 +1,79 @@
+/*
+	server.go
+	Purpose: gRPC service of notes.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package note
+
+import (
+	"context"
+	"strings"
+
+	"app/core/auth"
+	"app/core/errors"
+	pb "app/service"
+)
+
+func init() {
+	auth.Guard(auth.USER, pb.NoteService_ListNotes_FullMethodName)
+}
+
+// defaultPageSize is the page size if not given by the pager.
+const defaultPageSize = 20
+
+// Server is the implementation of [pb.NoteServiceServer].
+var Server = &server{}
+
+type server struct {
+	pb.UnimplementedNoteServiceServer
+}
+
+func (*server) ListNotes(ctx context.Context, req *pb.ListNotesRequest) (*pb.ListNotesResponse, error) {
+	usr, ok := auth.GetUser(ctx)
+	if !ok {
+		return nil, errors.ErrUnauthorized
+	}
+	size, page := int32(defaultPageSize), int32(1)
+	if p := req.GetPager(); p != nil {
+		if p.Size > 0 {
+			size = p.Size
+		}
+		if p.Page > 0 {
+			page = p.Page
+		}
+	}
+	offset, query := int((page-1)*size), strings.TrimSpace(req.GetQuery())
+
+	var notes []*Note
+	var total int
+	var err error
+	if len(Terms(query)) == 0 {
+		notes, total, err = Recent(ctx, usr.Username, offset, int(size))
+	} else {
+		notes, total, err = Search(ctx, usr.Username, query, offset, int(size))
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.ListNotesResponse{
+		Notes: make([]*pb.Note, len(notes)),
+		Pager: &pb.PagerResult{Size: size, Page: page, Total: int32(total)},
+	}
+	for i, n := range notes {
+		res.Notes[i] = &pb.Note{
+			Id:        n.ID,
+			ChatId:    n.ChatID,
+			Text:      n.Text,
+			Snippet:   Highlight(n.Text, query, "<mark>", "</mark>", 2*snippet),
+			CreatedAt: n.CreatedAt.Unix(),
+		}
+	}
+	return res, nil
+}
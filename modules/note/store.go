@@ -0,0 +1,135 @@
+/*
+	store.go
+	Purpose: Persist notes in the database.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package note
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"app/core/database"
+	"app/core/errors"
+
+	"github.com/rs/xid"
+)
+
+func init() {
+	database.Register(&database.Migration{
+		ID: "20261017_note",
+		Stmts: []string{
+			`CREATE TABLE notes (
+				id TEXT PRIMARY KEY,
+				user_id TEXT NOT NULL,
+				chat_id TEXT NOT NULL,
+				text TEXT NOT NULL,
+				created_at BIGINT NOT NULL
+			)`,
+			`CREATE INDEX idx_notes_user ON notes (user_id, created_at)`,
+		},
+	})
+}
+
+// Note is a free-form text saved by a user.
+type Note struct {
+	ID        string
+	UserID    string
+	ChatID    string // the chat the note is saved in
+	Text      string
+	CreatedAt time.Time
+}
+
+const columns = "id, user_id, chat_id, text, created_at"
+
+func scan(row interface{ Scan(...any) error }, extra ...any) (*Note, error) {
+	n := &Note{}
+	var created int64
+	if err := row.Scan(append([]any{&n.ID, &n.UserID, &n.ChatID, &n.Text, &created}, extra...)...); err != nil {
+		return nil, err
+	}
+	n.CreatedAt = time.Unix(created, 0)
+	return n, nil
+}
+
+// Create saves @n as a new note of its user and indexes it for search.
+func Create(ctx context.Context, n *Note) error {
+	n.ID, n.CreatedAt = xid.New().String(), time.Now()
+	return database.Err(database.Tx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO notes ("+columns+") VALUES ($1, $2, $3, $4, $5)",
+			n.ID, n.UserID, n.ChatID, n.Text, n.CreatedAt.Unix()); err != nil {
+			return err
+		}
+		return index().add(ctx, tx, n)
+	}))
+}
+
+// Get gets the note @id of @userID.
+func Get(ctx context.Context, userID, id string) (*Note, error) {
+	n, err := scan(database.DB().QueryRowContext(ctx, "SELECT "+columns+" FROM notes WHERE id = $1 AND user_id = $2", id, userID))
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	return n, nil
+}
+
+// Delete deletes the note @id of @userID.
+func Delete(ctx context.Context, userID, id string) error {
+	return database.Err(database.Tx(ctx, func(tx *sql.Tx) error {
+		if err := index().remove(ctx, tx, id); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, "DELETE FROM notes WHERE id = $1 AND user_id = $2", id, userID)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return errors.ErrNotFound
+		}
+		return nil
+	}))
+}
+
+// Recent lists the notes of @userID, latest first, and the total count.
+func Recent(ctx context.Context, userID string, offset, limit int) ([]*Note, int, error) {
+	var total int
+	if err := database.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM notes WHERE user_id = $1", userID).Scan(&total); err != nil {
+		return nil, 0, database.Err(err)
+	}
+	rows, err := database.DB().QueryContext(ctx,
+		"SELECT "+columns+" FROM notes WHERE user_id = $1 ORDER BY created_at DESC, id DESC LIMIT $2 OFFSET $3",
+		userID, limit, offset)
+	if err != nil {
+		return nil, 0, database.Err(err)
+	}
+	defer rows.Close()
+	list := []*Note{}
+	for rows.Next() {
+		n, err := scan(rows)
+		if err != nil {
+			return nil, 0, database.Err(err)
+		}
+		list = append(list, n)
+	}
+	return list, total, database.Err(rows.Err())
+}
+
+// Search finds the notes of @userID with every word of @query, most relevant first, and the total count.
+func Search(ctx context.Context, userID, query string, offset, limit int) ([]*Note, int, error) {
+	terms := Terms(query)
+	if len(terms) == 0 {
+		return nil, 0, errors.ErrBadRequest.SetInfo("query")
+	}
+	list, total, err := index().search(ctx, userID, terms, offset, limit)
+	return list, total, database.Err(err)
+}
@@ -0,0 +1,98 @@
+/*
+	confirm.go
+	Purpose: Confirm ambiguous due times with the user before creating reminders.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package reminder
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"app/core/command"
+	"app/core/conversation"
+	"app/core/digest"
+)
+
+// sure is the confidence of a due time under which it is confirmed before the reminder is created.
+const sure = 0.7
+
+var (
+	yes = []string{"是", "對", "好", "確定", "沒錯", "yes", "y", "ok"}
+	no  = []string{"否", "不是", "不對", "不要", "no", "n"}
+)
+
+func init() {
+	conversation.Register(&conversation.Dialog{
+		Name: "reminder.confirm",
+		Steps: []*conversation.Step{{
+			Name:   "answer",
+			Prompt: "reminder.confirm",
+			Parse:  answer,
+			// anything but an answer asks again
+			Next: func(c *conversation.Context) string {
+				if c.Slot("answer") == "" {
+					return "answer"
+				}
+				return conversation.End
+			},
+		}},
+		Done: confirmed,
+	})
+}
+
+// confirm asks the sender whether to remind of @text at @due.
+func confirm(c *command.Context, text string, due time.Time) error {
+	return conversation.Start(c, "reminder.confirm", map[string]string{
+		"text": text,
+		"due":  strconv.FormatInt(due.Unix(), 10),
+		"at":   due.Format("2006-01-02 15:04"),
+	})
+}
+
+// answer reads the reply to the confirmation, which is yes, no, or the right time.
+func answer(c *conversation.Context, text string) (string, error) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	for _, w := range yes {
+		if text == w {
+			return "yes", nil
+		}
+	}
+	for _, w := range no {
+		if text == w {
+			return "no", nil
+		}
+	}
+	_, userID := ids(c.Event.Source)
+	now := time.Now().In(digest.Location(c, userID))
+	if res, err := parseWhen(text, now); err == nil && res.Time.After(now) {
+		c.SetSlot("due", strconv.FormatInt(res.Time.Unix(), 10))
+		return "yes", nil
+	}
+	return "", nil
+}
+
+// confirmed creates the reminder if confirmed.
+func confirmed(c *conversation.Context) error {
+	if c.Slot("answer") != "yes" {
+		return c.ReplyText("reminder.not_created", nil)
+	}
+	due, err := strconv.ParseInt(c.Slot("due"), 10, 64)
+	if err != nil {
+		return err
+	}
+	chatID, userID := ids(c.Event.Source)
+	r, err := Create(c, chatID, userID, c.Slot("text"), time.Unix(due, 0))
+	if err != nil {
+		return err
+	}
+	return created(c.Context, r, digest.Location(c, userID))
+}
@@ -0,0 +1,43 @@
+/*
+	digest.go
+	Purpose: Section of today's reminders in the daily digest.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package reminder
+
+import (
+	"context"
+	"time"
+
+	"app/core/digest"
+)
+
+func init() {
+	digest.Register(&digest.Provider{
+		Name:    "reminder",
+		Order:   20,
+		Title:   "reminder.digest.title",
+		Key:     "reminder.digest",
+		Collect: today,
+	})
+}
+
+// today collects the reminders of @userID due on @day.
+func today(ctx context.Context, userID string, day time.Time) (any, error) {
+	list, err := Between(ctx, userID, day, day.AddDate(0, 0, 1))
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	views := make([]map[string]any, len(list))
+	for i, r := range list {
+		views[i] = map[string]any{"Time": r.DueAt.In(day.Location()).Format("15:04"), "Text": r.Text}
+	}
+	return map[string]any{"Reminders": views}, nil
+}
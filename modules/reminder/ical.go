@@ -0,0 +1,136 @@
+/*
+	ical.go
+	Purpose: Create reminders from the events of iCalendar files.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Import recurring events as recurring reminders
+*/
+
+package reminder
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"app/core/ical"
+	"app/core/rrule"
+)
+
+// allDayAt is the time of day all-day events are reminded at.
+const allDayAt = 9 * time.Hour
+
+// Imported is the result of [Import].
+type Imported struct {
+	Created   []*Reminder
+	Past      int // events already started
+	Duplicate int // events with the same reminder already pending
+	Cancelled int // cancelled events
+}
+
+// Import creates the reminders of @userID in @chatID for the events of @cal which are yet to come at @now.
+//
+// A reminder is due at the alarm of its event if any, otherwise at its start, or at 9 am of the day of
+// an all-day event. A recurring event becomes a recurring reminder due at its next occurrence,
+// or a reminder of its first occurrence only if the rule is not supported by [rrule].
+func Import(ctx context.Context, chatID, userID string, cal *ical.Calendar, now time.Time) (*Imported, error) {
+	pending, err := List(ctx, chatID, userID)
+	if err != nil {
+		return nil, err
+	}
+	exists := map[string]bool{}
+	for _, r := range pending {
+		exists[key(r.Text, r.DueAt)] = true
+	}
+	res := &Imported{Created: []*Reminder{}}
+	for _, e := range cal.Events {
+		if e.Status == "CANCELLED" {
+			res.Cancelled++
+			continue
+		}
+		text, due := fromEvent(e)
+		set := repeat(e, due)
+		if set != nil {
+			next, ok := set.After(now)
+			if !ok {
+				res.Past++
+				continue
+			}
+			due = next
+		}
+		if !due.After(now) {
+			res.Past++
+			continue
+		}
+		if exists[key(text, due)] {
+			res.Duplicate++
+			continue
+		}
+		var r *Reminder
+		if set != nil {
+			r, err = CreateRecurring(ctx, chatID, userID, text, set, due.Add(-time.Second))
+		} else {
+			r, err = Create(ctx, chatID, userID, text, due)
+		}
+		if err != nil {
+			return nil, err
+		}
+		exists[key(text, due)] = true
+		res.Created = append(res.Created, r)
+	}
+	if len(res.Created) > 0 {
+		wake()
+	}
+	return res, nil
+}
+
+// fromEvent returns the text and due time of the reminder of @e.
+func fromEvent(e *ical.Event) (string, time.Time) {
+	text := strings.TrimSpace(e.Summary)
+	if text == "" {
+		text = strings.TrimSpace(e.Description)
+	}
+	if loc := strings.TrimSpace(e.Location); loc != "" {
+		text += " @ " + loc
+	}
+	due := e.Start
+	if e.AllDay {
+		due = due.Add(allDayAt)
+	} else if e.Alarm > 0 {
+		due = due.Add(-e.Alarm)
+	}
+	return text, due.Truncate(time.Minute)
+}
+
+// repeat returns the recurrence of the reminder of @e due at @due, nil if @e is not recurring
+// or its rule is not supported.
+func repeat(e *ical.Event, due time.Time) *rrule.Set {
+	if !e.Recurring() {
+		return nil
+	}
+	rule, err := rrule.Parse(e.RRule, e.Start.Location())
+	if err != nil {
+		return nil
+	}
+	// an alarm on the day before would move the weekdays of the rule, it is reminded at the start instead
+	if y, m, d := e.Start.Date(); due.Year() != y || due.Month() != m || due.Day() != d {
+		due = e.Start.Truncate(time.Minute)
+	}
+	// the excluded dates are moved from the start of the occurrences to their reminders
+	shift := due.Sub(e.Start.Truncate(time.Minute))
+	set := &rrule.Set{Start: due, Rule: rule, ExDates: make([]time.Time, len(e.ExDates))}
+	for i, t := range e.ExDates {
+		set.ExDates[i] = t.Add(shift)
+	}
+	return set
+}
+
+// key identifies a reminder by its text and due time.
+func key(text string, due time.Time) string {
+	return text + "\x00" + due.Truncate(time.Minute).UTC().String()
+}
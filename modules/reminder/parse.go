@@ -0,0 +1,139 @@
+/*
+	parse.go
+	Purpose: Parse when a reminder is due from the text of the user.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Parse recurring times
+	2026/10/17  v1.0.2 Evan Chen   Read times in English and Chinese with the when package
+*/
+
+package reminder
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"app/core/rrule"
+	"app/core/when"
+)
+
+// DefaultHour is the hour of a reminder given only the day, e.g. "tomorrow".
+const DefaultHour = 9
+
+var errWhen = errors.New("unknown time")
+
+// split splits "me tomorrow 9am to call mom" into the time and the text to be reminded of.
+func split(s string) (at, text string, ok bool) {
+	s = strings.TrimSpace(s)
+	if lower := strings.ToLower(s); strings.HasPrefix(lower, "me ") {
+		s = strings.TrimSpace(s[3:])
+	}
+	at, text, ok = strings.Cut(s, " to ")
+	at, text = strings.TrimSpace(at), strings.TrimSpace(text)
+	return at, text, ok && at != "" && text != ""
+}
+
+// parseWhen resolves @s, which should be nothing but a date or time read by [when], against @now.
+// A day without time is at [DefaultHour], e.g. "tomorrow" or "下週五".
+func parseWhen(s string, now time.Time) (*when.Result, error) {
+	res, err := when.Exact(s, now)
+	if err != nil {
+		return nil, errWhen
+	}
+	return atDefault(res), nil
+}
+
+// find finds the due time in @s without " to ", e.g. "明天下午三點開會",
+// and returns the rest of @s as the text to be reminded of.
+func find(s string, now time.Time) (*when.Result, string, error) {
+	res, err := when.Parse(s, now)
+	if err != nil {
+		return nil, "", errWhen
+	}
+	text := strings.TrimSpace(s[:res.Start]) + " " + strings.TrimSpace(s[res.End:])
+	for prev := ""; prev != text; {
+		prev = text
+		text = strings.TrimSpace(strings.Trim(text, ",，:：、"))
+		for _, w := range []string{"me ", "to ", "我要", "我", "要", "去"} {
+			if len(text) > len(w) && strings.EqualFold(text[:len(w)], w) {
+				text = text[len(w):]
+			}
+		}
+	}
+	return atDefault(res), text, nil
+}
+
+// atDefault moves a day without time to [DefaultHour].
+func atDefault(res *when.Result) *when.Result {
+	if !res.HasTime {
+		t := res.Time
+		res.Time = time.Date(t.Year(), t.Month(), t.Day(), DefaultHour, 0, 0, 0, t.Location())
+	}
+	return res
+}
+
+// parseClock parses a time of day into @hour and @min.
+func parseClock(w string, hour, min *int) bool {
+	if w == "noon" {
+		*hour, *min = 12, 0
+		return true
+	}
+	if w == "midnight" {
+		*hour, *min = 0, 0
+		return true
+	}
+	for _, layout := range []string{"15:04", "3pm", "3:04pm"} {
+		if t, err := time.Parse(layout, w); err == nil {
+			*hour, *min = t.Hour(), t.Minute()
+			return true
+		}
+	}
+	return false
+}
+
+// repeating are the words of a recurring time.
+var repeating = map[string]bool{
+	"every": true, "each": true, "daily": true, "weekly": true, "monthly": true, "yearly": true, "annually": true,
+}
+
+// parseEvery parses a recurring time like "every weekday 8am" or "every 2 weeks on tue, thu at 19:30",
+// whose phrase is read by [rrule.FromText] and the time of day is [DefaultHour] if not given.
+// ok is false if @s is not recurring, the set starts at its first occurrence after @now.
+func parseEvery(s string, now time.Time) (set *rrule.Set, ok bool, err error) {
+	words := strings.Fields(strings.ToLower(s))
+	for _, w := range words {
+		ok = ok || repeating[w]
+	}
+	if !ok {
+		return nil, false, nil
+	}
+	hour, min := DefaultHour, 0
+	phrase := make([]string, 0, len(words))
+	for i := 0; i < len(words); i++ {
+		w := words[i]
+		if w == "at" && i+1 < len(words) && parseClock(words[i+1], &hour, &min) {
+			i++
+			continue
+		}
+		if !parseClock(w, &hour, &min) {
+			phrase = append(phrase, w)
+		}
+	}
+	rule, err := rrule.FromText(strings.Join(phrase, " "))
+	if err != nil {
+		return nil, true, errWhen
+	}
+	set = &rrule.Set{Start: time.Date(now.Year(), now.Month(), now.Day(), hour, min, 0, 0, now.Location()), Rule: rule}
+	first, found := set.After(now)
+	if !found {
+		return nil, true, errWhen
+	}
+	set.Start = first
+	return set, true, nil
+}
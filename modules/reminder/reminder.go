@@ -0,0 +1,260 @@
+/*
+	reminder.go
+	Purpose: Chat commands and postbacks of reminders.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Skip redelivered postbacks
+	2026/10/17  v1.0.2 Evan Chen   Push through the outbox
+	2026/10/17  v1.0.3 Evan Chen   Mark commands as the reminder skill
+	2026/10/17  v1.0.4 Evan Chen   Add recurring reminders
+	2026/10/17  v1.0.5 Evan Chen   Read times in the time zone of the user and confirm ambiguous ones
+	2026/10/17  v1.0.6 Evan Chen   Handle the buttons as signed postback actions
+	2026/10/17  v1.0.7 Evan Chen   Show the due times in the time zone of the user
+	2026/10/17  v1.0.7 Evan Chen   Own the wake channel by the service
+*/
+
+// Package reminder stores reminders like "/remind me tomorrow 9am to call mom"
+// and pushes them to the chat when due, with snooze and done buttons.
+// Recurring reminders like "/remind me every weekday 8am to stand up" are followed by
+// the next occurrence once pushed, deleting the pending one ends the series.
+// Times are read by the [when] package in the time zone of the user, e.g. "/提醒 明天下午三點開會",
+// and ambiguous ones like "3點" are confirmed with the user first.
+//
+// Reminders are kept in the database so they survive restarts,
+// the ones due while the server was down are still pushed if within [Option.Grace],
+// otherwise they are marked as missed.
+package reminder
+
+import (
+	"sync"
+	"time"
+
+	"app/core/command"
+	"app/core/digest"
+	"app/core/line"
+	"app/core/msg"
+	"app/core/postback"
+	"app/core/when"
+
+	"golang.org/x/exp/slog"
+)
+
+// Option configures the reminders.
+type Option struct {
+	// NoCron disables the scheduler, reminders could still be managed but will not be pushed.
+	NoCron bool
+	// Grace is how late a reminder could still be pushed, e.g. after the server was down.
+	Grace time.Duration
+	// Snooze is how long the snooze button delays a reminder.
+	Snooze time.Duration
+	// Interval is the longest time the scheduler sleeps between checks.
+	Interval time.Duration
+}
+
+var opt = Option{
+	Grace:    time.Hour,
+	Snooze:   10 * time.Minute,
+	Interval: time.Minute,
+}
+
+// Setup sets the options, empty durations are left unchanged.
+func Setup(o *Option) {
+	opt.NoCron = o.NoCron
+	if o.Grace > 0 {
+		opt.Grace = o.Grace
+	}
+	if o.Snooze > 0 {
+		opt.Snooze = o.Snooze
+	}
+	if o.Interval > 0 {
+		opt.Interval = o.Interval
+	}
+}
+
+func init() {
+	command.Register(
+		&command.Command{
+			Name:    "remind",
+			Aliases: []string{"提醒"},
+			Args:    []command.Arg{{Name: "when to text", Required: true, Rest: true}},
+			Desc:    "reminder.desc",
+			Skill:   "reminder",
+			Handler: add,
+		},
+		&command.Command{
+			Name:    "remind list",
+			Desc:    "reminder.list.desc",
+			Skill:   "reminder",
+			Handler: list,
+		},
+		&command.Command{
+			Name:    "remind del",
+			Args:    []command.Arg{{Name: "id", Required: true}},
+			Desc:    "reminder.del.desc",
+			Skill:   "reminder",
+			Handler: del,
+		},
+	)
+	postback.Register(
+		&postback.Action{Name: "reminder.snooze", Handler: snooze},
+		&postback.Action{Name: "reminder.done", Handler: done},
+	)
+}
+
+// Service is the [service.Service] which runs the scheduler.
+var Service = &reminder{wake: make(chan struct{}, 1)}
+
+type reminder struct {
+	lock  sync.Mutex
+	sched *scheduler
+	// wake is owned by the service, so it could be signaled while the scheduler is restarted
+	wake chan struct{}
+}
+
+func (s *reminder) Init() error { return nil }
+
+// Load (re)starts the scheduler unless disabled by [Option.NoCron].
+func (s *reminder) Load() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.sched != nil {
+		s.sched.close()
+		s.sched = nil
+	}
+	if opt.NoCron {
+		slog.Info("scheduler disabled", slog.String("mod", "reminder"), slog.String("act", "load"))
+		return
+	}
+	s.sched = newScheduler(s.wake)
+	go s.sched.run()
+}
+
+func (s *reminder) Del() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.sched != nil {
+		s.sched.close()
+		s.sched = nil
+	}
+}
+
+// wake wakes up the scheduler to pick up changed reminders without blocking.
+func wake() {
+	select {
+	case Service.wake <- struct{}{}:
+	default:
+	}
+}
+
+func add(c *command.Context) error {
+	arg := c.Args.Get("when to text")
+	chatID, userID := ids(c.Event.Source)
+	now := time.Now().In(digest.Location(c, userID))
+	at, text, ok := split(arg)
+	if ok {
+		set, every, err := parseEvery(at, now)
+		if err != nil {
+			return c.ReplyText("reminder.bad_time", msg.Plain(at))
+		}
+		if every {
+			r, err := CreateRecurring(c, chatID, userID, text, set, now)
+			if err != nil {
+				return err
+			}
+			return created(c, r, now.Location())
+		}
+	}
+
+	// without " to ", the time is found in the text, e.g. "明天下午三點開會"
+	var res *when.Result
+	var err error
+	if ok {
+		res, err = parseWhen(at, now)
+	} else {
+		at = arg
+		res, text, err = find(arg, now)
+	}
+	if err != nil {
+		return c.ReplyText("reminder.bad_time", msg.Plain(at))
+	}
+	if text == "" {
+		return c.ReplyText("command.usage", msg.Plain(c.Command.Usage()))
+	}
+	if !res.Time.After(now) {
+		return c.ReplyText("reminder.past", msg.Plain(res.Text))
+	}
+	if res.Confidence < sure {
+		return confirm(c, text, res.Time)
+	}
+	r, err := Create(c, chatID, userID, text, res.Time)
+	if err != nil {
+		return err
+	}
+	return created(c, r, now.Location())
+}
+
+// created wakes up the scheduler for the new @r, and replies it with the due time in @loc.
+func created(c *command.Context, r *Reminder, loc *time.Location) error {
+	wake()
+	return c.ReplyText("reminder.created", view(r, loc))
+}
+
+func list(c *command.Context) error {
+	chatID, userID := ids(c.Event.Source)
+	rs, err := List(c, chatID, userID)
+	if err != nil {
+		return err
+	}
+	loc := digest.Location(c, userID)
+	views := make([]map[string]any, len(rs))
+	for i, r := range rs {
+		views[i] = view(r, loc)
+	}
+	return c.ReplyText("reminder.list", map[string]any{"Reminders": views})
+}
+
+func del(c *command.Context) error {
+	_, userID := ids(c.Event.Source)
+	if err := Delete(c, userID, c.Args.Get("id")); err != nil {
+		return err
+	}
+	wake()
+	return c.ReplyText("reminder.deleted", msg.Plain(c.Args.Get("id")))
+}
+
+// snooze handles the snooze button of a pushed reminder.
+func snooze(c *postback.Context) error {
+	_, userID := ids(c.Event.Source)
+	due := time.Now().Add(opt.Snooze).Truncate(time.Minute)
+	if err := Snooze(c, userID, c.Args.String("id"), due); err != nil {
+		return err
+	}
+	wake()
+	return c.ReplyText("reminder.snoozed", msg.Plain(due.In(digest.Location(c, userID)).Format("15:04")))
+}
+
+// done handles the done button of a pushed reminder.
+func done(c *postback.Context) error {
+	_, userID := ids(c.Event.Source)
+	if err := Done(c, userID, c.Args.String("id")); err != nil {
+		return err
+	}
+	return c.ReplyText("reminder.done", nil)
+}
+
+// ids returns the chat to push to and the owner of the reminder.
+func ids(src *line.Source) (chatID, userID string) {
+	if src == nil {
+		return "", ""
+	}
+	chatID, userID = src.ID(), src.UserID
+	if userID == "" {
+		userID = chatID
+	}
+	return chatID, userID
+}
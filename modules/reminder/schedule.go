@@ -0,0 +1,211 @@
+/*
+	schedule.go
+	Purpose: Push reminders when they are due.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Push through the outbox
+	2026/10/17  v1.0.2 Evan Chen   Render in the locale of the group
+	2026/10/17  v1.0.3 Evan Chen   Advance recurring reminders
+	2026/10/17  v1.0.4 Evan Chen   Sign the data of the buttons
+	2026/10/17  v1.0.5 Evan Chen   Emit pushed reminders
+	2026/10/17  v1.0.6 Evan Chen   Mark reminders failed to render as failed
+	2026/10/17  v1.0.7 Evan Chen   Show the due times in the time zone of the user
+*/
+
+package reminder
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"app/core/database"
+	"app/core/digest"
+	"app/core/group"
+	"app/core/msg"
+	"app/core/outbox"
+	"app/core/postback"
+	"app/core/signal"
+	"app/core/util"
+
+	"golang.org/x/exp/slog"
+)
+
+// batch is how many due reminders are pushed in a round.
+const batch = 50
+
+// scheduler pushes due reminders, it sleeps until the next reminder is due
+// or at most [Option.Interval], and could be woken up early when reminders change.
+type scheduler struct {
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newScheduler(wake chan struct{}) *scheduler {
+	return &scheduler{
+		wake: wake,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+func (s *scheduler) run() {
+	defer close(s.done)
+	for {
+		s.tick(context.Background(), time.Now())
+
+		wait := opt.Interval
+		if t, ok, err := next(context.Background()); err == nil && ok {
+			if d := time.Until(t); d < wait {
+				wait = d
+			}
+		}
+		if wait < time.Second {
+			wait = time.Second
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-s.stop:
+			timer.Stop()
+			return
+		case <-s.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// close stops the scheduler and waits for the current round to finish.
+func (s *scheduler) close() {
+	close(s.stop)
+	<-s.done
+}
+
+// tick marks reminders older than the grace period as missed and pushes the due ones through the outbox.
+// A recurring reminder is followed by its next occurrence once pushed or missed.
+func (s *scheduler) tick(ctx context.Context, now time.Time) {
+	skip(ctx, now)
+	if n, err := expire(ctx, now.Add(-opt.Grace)); err != nil {
+		slog.Error("expire reminders failed", util.ErrAtrr(err), slog.String("mod", "reminder"), slog.String("act", "expire"))
+	} else if n > 0 {
+		slog.Warn("reminders missed", slog.Int64("count", n), slog.String("mod", "reminder"), slog.String("act", "expire"))
+	}
+
+	list, err := due(ctx, now, batch)
+	if err != nil {
+		slog.Error("list due reminders failed", util.ErrAtrr(err), slog.String("mod", "reminder"), slog.String("act", "tick"))
+		return
+	}
+	pushed := 0
+	for _, r := range list {
+		m, err := msg.Flex("reminder.push", group.Locale(ctx, r.ChatID), view(r, digest.Location(ctx, r.UserID)))
+		if err != nil {
+			slog.Error("render reminder failed", util.ErrAtrr(err),
+				slog.String("mod", "reminder"), slog.String("act", "push"), slog.String("id", r.ID))
+			// retrying will not fix the template, so it is not picked up again
+			fail(ctx, r, now)
+			continue
+		}
+		// the reminder is marked sent together with its message put into the outbox
+		err = database.Tx(ctx, func(tx *sql.Tx) error {
+			ok, err := setStatus(ctx, tx, r.ID, PENDING, SENT)
+			if err != nil || !ok {
+				return err
+			}
+			if _, err = outbox.Enqueue(ctx, tx, r.ChatID, m); err != nil || r.Recurrence == nil {
+				return err
+			}
+			after := r.DueAt
+			if now.After(after) {
+				after = now
+			}
+			_, err = advance(ctx, tx, r, after)
+			return err
+		})
+		if err != nil {
+			slog.Error("push reminder failed", util.ErrAtrr(err),
+				slog.String("mod", "reminder"), slog.String("act", "push"), slog.String("id", r.ID))
+			continue
+		}
+		pushed++
+		slog.Info("reminder pushed", slog.String("mod", "reminder"), slog.String("act", "push"),
+			slog.String("id", r.ID), slog.Duration("delay", now.Sub(r.DueAt)))
+		r.Status = SENT
+		signal.Emit(TopicPushed, r)
+	}
+	if pushed > 0 {
+		outbox.Notify()
+	}
+}
+
+// skip marks recurring reminders older than the grace period as missed and moves them to their next occurrence,
+// so that a series is not ended by the server being down.
+func skip(ctx context.Context, now time.Time) {
+	list, err := overdue(ctx, now.Add(-opt.Grace))
+	if err != nil {
+		slog.Error("list overdue reminders failed", util.ErrAtrr(err), slog.String("mod", "reminder"), slog.String("act", "expire"))
+		return
+	}
+	for _, r := range list {
+		err := database.Tx(ctx, func(tx *sql.Tx) error {
+			ok, err := setStatus(ctx, tx, r.ID, PENDING, MISSED)
+			if err != nil || !ok {
+				return err
+			}
+			_, err = advance(ctx, tx, r, now)
+			return err
+		})
+		if err != nil {
+			slog.Error("skip reminder failed", util.ErrAtrr(err),
+				slog.String("mod", "reminder"), slog.String("act", "expire"), slog.String("id", r.ID))
+			continue
+		}
+		slog.Warn("recurring reminder missed", slog.String("mod", "reminder"), slog.String("act", "expire"), slog.String("id", r.ID))
+	}
+}
+
+// fail marks @r as failed, and moves a recurring reminder to its next occurrence.
+func fail(ctx context.Context, r *Reminder, now time.Time) {
+	err := database.Tx(ctx, func(tx *sql.Tx) error {
+		ok, err := setStatus(ctx, tx, r.ID, PENDING, FAILED)
+		if err != nil || !ok || r.Recurrence == nil {
+			return err
+		}
+		_, err = advance(ctx, tx, r, now)
+		return err
+	})
+	if err != nil {
+		slog.Error("mark reminder failed failed", util.ErrAtrr(err),
+			slog.String("mod", "reminder"), slog.String("act", "push"), slog.String("id", r.ID))
+	}
+}
+
+// view is the data of the message templates of a reminder, with the times in @loc.
+func view(r *Reminder, loc *time.Location) map[string]any {
+	v := map[string]any{
+		"ID":   r.ID,
+		"Text": r.Text,
+		"Due":  r.DueAt.In(loc).Format("2006-01-02 15:04"),
+		// signed data of the buttons
+		"Snooze": postback.Data("reminder.snooze", postback.Args{"id": r.ID}),
+		"Done":   postback.Data("reminder.done", postback.Args{"id": r.ID}),
+	}
+	if r.Recurrence != nil {
+		v["Repeat"] = r.Recurrence.Rule.String()
+		next := []string{}
+		r.Recurrence.Each(func(t time.Time) bool {
+			if t.After(r.DueAt) {
+				next = append(next, t.In(loc).Format("2006-01-02 15:04"))
+			}
+			return len(next) < 3
+		})
+		v["Next"] = next
+	}
+	return v
+}
@@ -0,0 +1,299 @@
+/*
+	store.go
+	Purpose: Persist reminders in the database.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Push through the outbox
+	2026/10/17  v1.0.2 Evan Chen   Add Between for the digest
+	2026/10/17  v1.0.3 Evan Chen   Add recurring reminders
+	2026/10/17  v1.0.4 Evan Chen   Emit reminder events
+	2026/10/17  v1.0.5 Evan Chen   Add the failed status
+	2026/10/17  v1.0.6 Evan Chen   Drop the attempts column by a new migration
+	2026/10/17  v1.0.7 Evan Chen   Put reminders left sending back to pending
+*/
+
+package reminder
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"app/core/database"
+	"app/core/errors"
+	"app/core/rrule"
+	"app/core/signal"
+
+	"github.com/rs/xid"
+)
+
+func init() {
+	database.Register(&database.Migration{
+		ID: "20261017_reminder",
+		Stmts: []string{
+			`CREATE TABLE reminders (
+				id TEXT PRIMARY KEY,
+				chat_id TEXT NOT NULL,
+				user_id TEXT NOT NULL,
+				text TEXT NOT NULL,
+				due_at BIGINT NOT NULL,
+				status TEXT NOT NULL,
+				attempts INTEGER NOT NULL DEFAULT 0,
+				created_at BIGINT NOT NULL,
+				updated_at BIGINT NOT NULL
+			)`,
+			`CREATE INDEX idx_reminders_status_due ON reminders (status, due_at)`,
+		},
+	})
+	database.Register(&database.Migration{
+		ID: "20261017_reminder_recurrence",
+		Stmts: []string{
+			`ALTER TABLE reminders ADD COLUMN recurrence TEXT NOT NULL DEFAULT ''`,
+		},
+	})
+	database.Register(&database.Migration{
+		// pushes are retried by the outbox since v1.0.1, so the attempts are no longer counted here
+		ID: "20261017_reminder_drop_attempts",
+		Stmts: []string{
+			`ALTER TABLE reminders DROP COLUMN attempts`,
+		},
+	})
+	database.Register(&database.Migration{
+		// the sending status is gone with the outbox, reminders left in it are pushed again or expired
+		ID: "20261017_reminder_drop_sending",
+		Stmts: []string{
+			`UPDATE reminders SET status = 'pending' WHERE status = 'sending'`,
+		},
+	})
+}
+
+// Status of a reminder.
+const (
+	PENDING = "pending" // waiting to be pushed
+	SENT    = "sent"    // put into the outbox and waiting for done or snooze
+	DONE    = "done"    // marked as done by the user
+	MISSED  = "missed"  // due longer than the grace period ago, it will not be pushed
+	FAILED  = "failed"  // its message could not be rendered, it will not be pushed
+)
+
+// Topics that reminders emit through [signal], listeners will receive the *Reminder as the first argument.
+const (
+	TopicCreated = "reminder.created"
+	TopicPushed  = "reminder.pushed"
+	TopicDone    = "reminder.done"
+)
+
+// Reminder is a message to be pushed to a chat at a given time.
+type Reminder struct {
+	ID     string
+	ChatID string
+	UserID string
+	Text   string
+	DueAt  time.Time
+	Status string
+	// Recurrence repeats the reminder, nil if it is pushed once. Only the pending reminder of a series
+	// has it, the next one is created when it is pushed.
+	Recurrence *rrule.Set
+}
+
+const columns = "id, chat_id, user_id, text, due_at, status, recurrence"
+
+func scan(row interface{ Scan(...any) error }) (*Reminder, error) {
+	r := &Reminder{}
+	var due int64
+	var recurrence string
+	if err := row.Scan(&r.ID, &r.ChatID, &r.UserID, &r.Text, &due, &r.Status, &recurrence); err != nil {
+		return nil, err
+	}
+	r.DueAt = time.Unix(due, 0)
+	if recurrence != "" {
+		// a rule that could not be parsed leaves a one-off reminder, rather than failing the whole list
+		r.Recurrence, _ = rrule.ParseSet(recurrence, time.Local)
+	}
+	return r, nil
+}
+
+// recurrence returns the text of the recurrence of @r to be stored.
+func recurrence(r *Reminder) string {
+	if r.Recurrence == nil {
+		return ""
+	}
+	return r.Recurrence.String()
+}
+
+// insert stores @r within the transaction of @ex.
+func insert(ctx context.Context, ex database.Executor, r *Reminder) error {
+	now := time.Now().Unix()
+	_, err := ex.ExecContext(ctx, `INSERT INTO reminders
+		(id, chat_id, user_id, text, due_at, status, recurrence, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)`,
+		r.ID, r.ChatID, r.UserID, r.Text, r.DueAt.Unix(), r.Status, recurrence(r), now)
+	return database.Err(err)
+}
+
+// Create stores a new pending reminder.
+func Create(ctx context.Context, chatID, userID, text string, due time.Time) (*Reminder, error) {
+	r := &Reminder{ID: xid.New().String(), ChatID: chatID, UserID: userID, Text: text, DueAt: due, Status: PENDING}
+	if err := insert(ctx, database.DB(), r); err != nil {
+		return nil, err
+	}
+	signal.Emit(TopicCreated, r)
+	return r, nil
+}
+
+// CreateRecurring stores a new pending reminder repeating by @set, which is first due at its first occurrence after @now.
+func CreateRecurring(ctx context.Context, chatID, userID, text string, set *rrule.Set, now time.Time) (*Reminder, error) {
+	due, ok := set.After(now)
+	if !ok {
+		return nil, errors.ErrBadRequest.SetInfo("no occurrence after " + now.Format(time.RFC3339))
+	}
+	r := &Reminder{ID: xid.New().String(), ChatID: chatID, UserID: userID, Text: text, DueAt: due, Status: PENDING, Recurrence: set}
+	if err := insert(ctx, database.DB(), r); err != nil {
+		return nil, err
+	}
+	signal.Emit(TopicCreated, r)
+	return r, nil
+}
+
+// advance moves the series of the recurring @r to a new pending reminder due at the first occurrence after @after,
+// within the transaction of @ex. The series ends if there is no more occurrence.
+func advance(ctx context.Context, ex database.Executor, r *Reminder, after time.Time) (*Reminder, error) {
+	if _, err := ex.ExecContext(ctx, "UPDATE reminders SET recurrence = '' WHERE id = $1", r.ID); err != nil {
+		return nil, database.Err(err)
+	}
+	due, ok := r.Recurrence.After(after)
+	if !ok {
+		return nil, nil
+	}
+	n := &Reminder{ID: xid.New().String(), ChatID: r.ChatID, UserID: r.UserID, Text: r.Text, DueAt: due, Status: PENDING,
+		Recurrence: r.Recurrence}
+	return n, insert(ctx, ex, n)
+}
+
+// Get gets the reminder @id of @userID.
+func Get(ctx context.Context, userID, id string) (*Reminder, error) {
+	r, err := scan(database.DB().QueryRowContext(ctx,
+		"SELECT "+columns+" FROM reminders WHERE id = $1 AND user_id = $2", id, userID))
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	return r, nil
+}
+
+// List lists the pending reminders of @userID in @chatID ordered by due time.
+func List(ctx context.Context, chatID, userID string) ([]*Reminder, error) {
+	return query(ctx, "SELECT "+columns+" FROM reminders WHERE chat_id = $1 AND user_id = $2 AND status = $3 ORDER BY due_at",
+		chatID, userID, PENDING)
+}
+
+// Between lists the pending reminders of @userID in every chat due in [@from, @to), ordered by due time.
+func Between(ctx context.Context, userID string, from, to time.Time) ([]*Reminder, error) {
+	return query(ctx, "SELECT "+columns+" FROM reminders WHERE user_id = $1 AND status = $2 AND due_at >= $3 AND due_at < $4 ORDER BY due_at",
+		userID, PENDING, from.Unix(), to.Unix())
+}
+
+// Delete deletes the reminder @id of @userID.
+func Delete(ctx context.Context, userID, id string) error {
+	res, err := database.DB().ExecContext(ctx, "DELETE FROM reminders WHERE id = $1 AND user_id = $2", id, userID)
+	return affected(res, err)
+}
+
+// Snooze reschedules the reminder @id of @userID to @due.
+func Snooze(ctx context.Context, userID, id string, due time.Time) error {
+	res, err := database.DB().ExecContext(ctx,
+		"UPDATE reminders SET due_at = $1, status = $2, updated_at = $3 WHERE id = $4 AND user_id = $5",
+		due.Unix(), PENDING, time.Now().Unix(), id, userID)
+	return affected(res, err)
+}
+
+// Done marks the reminder @id of @userID as done.
+func Done(ctx context.Context, userID, id string) error {
+	res, err := database.DB().ExecContext(ctx,
+		"UPDATE reminders SET status = $1, updated_at = $2 WHERE id = $3 AND user_id = $4",
+		DONE, time.Now().Unix(), id, userID)
+	if err := affected(res, err); err != nil {
+		return err
+	}
+	if r, err := Get(ctx, userID, id); err == nil {
+		signal.Emit(TopicDone, r)
+	}
+	return nil
+}
+
+// due lists the pending reminders that are due at @now.
+func due(ctx context.Context, now time.Time, limit int) ([]*Reminder, error) {
+	return query(ctx, "SELECT "+columns+" FROM reminders WHERE status = $1 AND due_at <= $2 ORDER BY due_at LIMIT $3",
+		PENDING, now.Unix(), limit)
+}
+
+// next returns the due time of the next pending reminder, ok is false if there is none.
+func next(ctx context.Context) (t time.Time, ok bool, err error) {
+	var due sql.NullInt64
+	err = database.DB().QueryRowContext(ctx, "SELECT MIN(due_at) FROM reminders WHERE status = $1", PENDING).Scan(&due)
+	if err != nil || !due.Valid {
+		return t, false, database.Err(err)
+	}
+	return time.Unix(due.Int64, 0), true, nil
+}
+
+// setStatus moves the reminder @id from status @from to @to, ok is false if it is not in @from.
+func setStatus(ctx context.Context, ex database.Executor, id, from, to string) (bool, error) {
+	res, err := ex.ExecContext(ctx,
+		"UPDATE reminders SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4",
+		to, time.Now().Unix(), id, from)
+	if err != nil {
+		return false, database.Err(err)
+	}
+	n, err := res.RowsAffected()
+	return n > 0, database.Err(err)
+}
+
+// overdue lists the pending recurring reminders due before @before.
+func overdue(ctx context.Context, before time.Time) ([]*Reminder, error) {
+	return query(ctx, "SELECT "+columns+" FROM reminders WHERE status = $1 AND due_at < $2 AND recurrence <> ''",
+		PENDING, before.Unix())
+}
+
+// expire marks pending reminders due before @before as missed.
+func expire(ctx context.Context, before time.Time) (int64, error) {
+	res, err := database.DB().ExecContext(ctx,
+		"UPDATE reminders SET status = $1, updated_at = $2 WHERE status = $3 AND due_at < $4",
+		MISSED, time.Now().Unix(), PENDING, before.Unix())
+	if err != nil {
+		return 0, database.Err(err)
+	}
+	return res.RowsAffected()
+}
+
+func query(ctx context.Context, q string, args ...any) ([]*Reminder, error) {
+	rows, err := database.DB().QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	defer rows.Close()
+	list := []*Reminder{}
+	for rows.Next() {
+		r, err := scan(rows)
+		if err != nil {
+			return nil, database.Err(err)
+		}
+		list = append(list, r)
+	}
+	return list, database.Err(rows.Err())
+}
+
+func affected(res sql.Result, err error) error {
+	if err != nil {
+		return database.Err(err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return database.Err(err)
+	} else if n == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+/*
+	digest.go
+	Purpose: Section of overdue todos in the daily digest.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package todo
+
+import (
+	"context"
+	"time"
+
+	"app/core/digest"
+)
+
+func init() {
+	digest.Register(&digest.Provider{
+		Name:    "todo",
+		Order:   30,
+		Title:   "todo.digest.title",
+		Key:     "todo.digest",
+		Collect: overdue,
+	})
+}
+
+// overdue collects the personal todos of @userID that are overdue or due on @day.
+func overdue(ctx context.Context, userID string, day time.Time) (any, error) {
+	list, err := Due(ctx, userID, day.AddDate(0, 0, 1))
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	views := make([]map[string]any, len(list))
+	for i, t := range list {
+		views[i] = map[string]any{
+			"Title":   t.Title,
+			"Due":     t.DueAt.In(day.Location()).Format("01/02"),
+			"Overdue": t.DueAt.Before(day),
+		}
+	}
+	return map[string]any{"Todos": views}, nil
+}
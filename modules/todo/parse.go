@@ -0,0 +1,116 @@
+/*
+	parse.go
+	Purpose: Parse the title, due date, priority and tags of a todo from the text of the user.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Parse every:rule of recurring todos
+	2026/10/17  v1.0.2 Evan Chen   Read due dates in English and Chinese with the when package
+*/
+
+package todo
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"app/core/rrule"
+	"app/core/when"
+)
+
+var (
+	errDue   = errors.New("unknown due date")
+	errEvery = errors.New("unknown recurrence")
+)
+
+// parse parses "buy milk #home !high due:tomorrow" into a todo, the words other than
+//
+//   - #tag: adds a tag
+//   - !low, !medium, !high, or !, !!, !!!: sets the priority
+//   - due:date: sets the due date, see [parseDue]
+//   - every:rule: repeats the todo, e.g. every:weekday, every:2weeks, every:mon,thu, read by [rrule.FromText]
+//
+// make up the title. A recurring todo is due at its first occurrence from the due date, or from today if not given.
+func parse(s string, now time.Time) (*Todo, error) {
+	t := &Todo{Tags: []string{}}
+	title := []string{}
+	for _, w := range strings.Fields(s) {
+		lower := strings.ToLower(w)
+		switch {
+		case len(w) > 1 && (w[0] == '#' || strings.HasPrefix(w, "＃")):
+			tag := strings.ToLower(strings.ReplaceAll(strings.TrimLeft(w, "#＃"), ",", ""))
+			if tag != "" && !contains(t.Tags, tag) {
+				t.Tags = append(t.Tags, tag)
+			}
+		case w[0] == '!' && priority(lower) >= 0:
+			t.Priority = priority(lower)
+		case strings.HasPrefix(lower, "due:"):
+			due, err := parseDue(lower[4:], now)
+			if err != nil {
+				return nil, err
+			}
+			t.DueAt = due
+		case strings.HasPrefix(lower, "every:"):
+			rule, err := rrule.FromText(lower[6:])
+			if err != nil {
+				return nil, errEvery
+			}
+			t.Recurrence = &rrule.Set{AllDay: true, Rule: rule}
+		default:
+			title = append(title, w)
+		}
+	}
+	t.Title = strings.Join(title, " ")
+	if t.Recurrence != nil {
+		start := t.DueAt
+		if start.IsZero() {
+			start = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		}
+		t.Recurrence.Start = start
+		due, ok := t.Recurrence.After(start.Add(-time.Second))
+		if !ok {
+			return nil, errEvery
+		}
+		t.DueAt = due
+	}
+	return t, nil
+}
+
+// priority parses "!high" or "!!!", -1 if unknown.
+func priority(s string) int {
+	switch s {
+	case "!", "!low":
+		return LOW
+	case "!!", "!medium", "!mid":
+		return MEDIUM
+	case "!!!", "!high":
+		return HIGH
+	}
+	return -1
+}
+
+// parseDue parses a due date read by [when] relative to @now, e.g. "today", "tmr", "fri", "下週五",
+// "2026-10-20" or "10/20", the next year if the month and day have passed.
+// The due time is the start of the day.
+func parseDue(s string, now time.Time) (time.Time, error) {
+	res, err := when.Exact(s, now)
+	if err != nil {
+		return time.Time{}, errDue
+	}
+	t := res.Time
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()), nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+/*
+	server.go
+	Purpose: gRPC service of todos.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package todo
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"app/core/auth"
+	"app/core/errors"
+	"app/core/group"
+	pb "app/service"
+)
+
+func init() {
+	auth.Guard(auth.USER,
+		pb.TodoService_CreateTodo_FullMethodName,
+		pb.TodoService_ListTodos_FullMethodName,
+		pb.TodoService_UpdateTodo_FullMethodName,
+		pb.TodoService_CompleteTodo_FullMethodName,
+		pb.TodoService_DeleteTodo_FullMethodName,
+	)
+}
+
+// defaultPageSize is the page size if not given by the pager.
+const defaultPageSize = 20
+
+// Server is the implementation of [pb.TodoServiceServer].
+var Server = &server{}
+
+type server struct {
+	pb.UnimplementedTodoServiceServer
+}
+
+func (*server) CreateTodo(ctx context.Context, req *pb.CreateTodoRequest) (*pb.Todo, error) {
+	usr, chatID, err := access(ctx, req.GetChatId())
+	if err != nil {
+		return nil, err
+	}
+	t := &Todo{ChatID: chatID, CreatedBy: usr}
+	if err := fill(t, req.GetTitle(), req.GetDueAt(), req.GetPriority(), req.GetTags()); err != nil {
+		return nil, err
+	}
+	if err := Create(ctx, t); err != nil {
+		return nil, err
+	}
+	return toPB(t), nil
+}
+
+func (*server) ListTodos(ctx context.Context, req *pb.ListTodosRequest) (*pb.ListTodosResponse, error) {
+	_, chatID, err := access(ctx, req.GetChatId())
+	if err != nil {
+		return nil, err
+	}
+	size, page := int32(defaultPageSize), int32(1)
+	if p := req.GetPager(); p != nil {
+		if p.Size > 0 {
+			size = p.Size
+		}
+		if p.Page > 0 {
+			page = p.Page
+		}
+	}
+	tag := strings.ToLower(strings.TrimLeft(req.GetTag(), "#"))
+	todos, total, err := List(ctx, chatID, tag, req.GetDone(), int((page-1)*size), int(size))
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.ListTodosResponse{
+		Todos: make([]*pb.Todo, len(todos)),
+		Pager: &pb.PagerResult{Size: size, Page: page, Total: int32(total)},
+	}
+	for i, t := range todos {
+		res.Todos[i] = toPB(t)
+	}
+	return res, nil
+}
+
+func (*server) UpdateTodo(ctx context.Context, req *pb.UpdateTodoRequest) (*pb.Todo, error) {
+	_, t, err := get(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := fill(t, req.GetTitle(), req.GetDueAt(), req.GetPriority(), req.GetTags()); err != nil {
+		return nil, err
+	}
+	if err := Update(ctx, t); err != nil {
+		return nil, err
+	}
+	return toPB(t), nil
+}
+
+func (*server) CompleteTodo(ctx context.Context, req *pb.CompleteTodoRequest) (*pb.Todo, error) {
+	usr, t, err := get(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := Complete(ctx, t.ID, usr, !req.GetUndo()); err != nil {
+		return nil, err
+	}
+	if t, err = Get(ctx, t.ID); err != nil {
+		return nil, err
+	}
+	return toPB(t), nil
+}
+
+func (*server) DeleteTodo(ctx context.Context, req *pb.DeleteTodoRequest) (*pb.DeleteTodoResponse, error) {
+	_, t, err := get(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeleteTodoResponse{}, Delete(ctx, t.ID)
+}
+
+// access returns the signed in user and the list @chatID refers to,
+// which is the personal list if empty, or a group chat the user is a member of.
+func access(ctx context.Context, chatID string) (usr, list string, err error) {
+	u, ok := auth.GetUser(ctx)
+	if !ok {
+		return "", "", errors.ErrUnauthorized
+	}
+	if chatID == "" || chatID == u.Username {
+		return u.Username, u.Username, nil
+	}
+	if u.Group >= auth.ADMIN {
+		return u.Username, chatID, nil
+	}
+	member, err := group.IsMember(ctx, chatID, u.Username)
+	if err != nil {
+		return "", "", err
+	}
+	if !member {
+		return "", "", errors.ErrForbidden
+	}
+	return u.Username, chatID, nil
+}
+
+// get gets the todo @id if the signed in user could access it.
+func get(ctx context.Context, id string) (string, *Todo, error) {
+	t, err := Get(ctx, id)
+	if err != nil {
+		return "", nil, err
+	}
+	usr, _, err := access(ctx, t.ChatID)
+	if errors.Is(err, errors.ErrForbidden) {
+		// not telling whether the todo exists
+		return "", nil, errors.ErrNotFound
+	}
+	return usr, t, err
+}
+
+// fill validates and sets the editable fields of @t.
+func fill(t *Todo, title string, due int64, priority int32, tags []string) error {
+	t.Title = strings.TrimSpace(title)
+	if t.Title == "" {
+		return errors.ErrBadRequest.SetInfo("title")
+	}
+	if priority < NONE || priority > HIGH {
+		return errors.ErrBadRequest.SetInfo("priority")
+	}
+	t.Priority = int(priority)
+	t.DueAt = time.Time{}
+	if due > 0 {
+		t.DueAt = time.Unix(due, 0)
+	}
+	t.Tags = []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimLeft(strings.TrimSpace(tag), "#"))
+		if strings.ContainsAny(tag, ", ") {
+			return errors.ErrBadRequest.SetInfo("tag: " + tag)
+		}
+		if tag != "" && !contains(t.Tags, tag) {
+			t.Tags = append(t.Tags, tag)
+		}
+	}
+	return nil
+}
+
+func toPB(t *Todo) *pb.Todo {
+	return &pb.Todo{
+		Id:        t.ID,
+		ChatId:    t.ChatID,
+		CreatedBy: t.CreatedBy,
+		Title:     t.Title,
+		DueAt:     seconds(t.DueAt),
+		Priority:  int32(t.Priority),
+		Tags:      t.Tags,
+		Done:      t.Done,
+		DoneBy:    t.DoneBy,
+		DoneAt:    seconds(t.DoneAt),
+		CreatedAt: t.CreatedAt.Unix(),
+		UpdatedAt: t.UpdatedAt.Unix(),
+	}
+}
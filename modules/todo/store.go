@@ -0,0 +1,322 @@
+/*
+	store.go
+	Purpose: Persist todos in the database.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Add Due for the digest
+	2026/10/17  v1.0.2 Evan Chen   Add recurring todos
+	2026/10/17  v1.0.3 Evan Chen   Emit todo events
+	2026/10/17  v1.0.4 Evan Chen   Emit the creation of the next todo of a series
+*/
+
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"app/core/database"
+	"app/core/errors"
+	"app/core/rrule"
+	"app/core/signal"
+
+	"github.com/rs/xid"
+)
+
+func init() {
+	database.Register(&database.Migration{
+		ID: "20261017_todo",
+		Stmts: []string{
+			`CREATE TABLE todos (
+				id TEXT PRIMARY KEY,
+				chat_id TEXT NOT NULL,
+				created_by TEXT NOT NULL,
+				title TEXT NOT NULL,
+				due_at BIGINT NOT NULL,
+				priority INTEGER NOT NULL,
+				tags TEXT NOT NULL,
+				done INTEGER NOT NULL,
+				done_by TEXT NOT NULL,
+				done_at BIGINT NOT NULL,
+				created_at BIGINT NOT NULL,
+				updated_at BIGINT NOT NULL
+			)`,
+			`CREATE INDEX idx_todos_chat ON todos (chat_id, done)`,
+		},
+	})
+	database.Register(&database.Migration{
+		ID: "20261017_todo_recurrence",
+		Stmts: []string{
+			`ALTER TABLE todos ADD COLUMN recurrence TEXT NOT NULL DEFAULT ''`,
+		},
+	})
+}
+
+// Topics that todos emit through [signal], listeners will receive the *Todo as the first argument.
+const (
+	TopicCreated   = "todo.created"
+	TopicCompleted = "todo.completed"
+)
+
+// Priorities of a todo.
+const (
+	NONE   = 0
+	LOW    = 1
+	MEDIUM = 2
+	HIGH   = 3
+)
+
+// Todo is an item of the todo list of a user, or of a group chat if shared.
+type Todo struct {
+	ID        string
+	ChatID    string // user ID of a personal todo, or the group or room ID of a shared one
+	CreatedBy string
+	Title     string
+	DueAt     time.Time // zero if not set
+	Priority  int
+	Tags      []string
+	Done      bool
+	DoneBy    string
+	DoneAt    time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// Recurrence repeats the todo by all-day occurrences, nil if not. Only the open todo of a series has it,
+	// the next one is created when it is completed.
+	Recurrence *rrule.Set
+}
+
+const columns = "id, chat_id, created_by, title, due_at, priority, tags, done, done_by, done_at, created_at, updated_at, recurrence"
+
+// order lists by priority, then todos with due dates by the earliest.
+const order = " ORDER BY priority DESC, CASE WHEN due_at = 0 THEN 1 ELSE 0 END, due_at, created_at, id"
+
+func scan(row interface{ Scan(...any) error }) (*Todo, error) {
+	t := &Todo{}
+	var due, doneAt, created, updated int64
+	var tags, recurrence string
+	var done int
+	if err := row.Scan(&t.ID, &t.ChatID, &t.CreatedBy, &t.Title, &due, &t.Priority, &tags,
+		&done, &t.DoneBy, &doneAt, &created, &updated, &recurrence); err != nil {
+		return nil, err
+	}
+	t.DueAt, t.DoneAt = unix(due), unix(doneAt)
+	t.CreatedAt, t.UpdatedAt = time.Unix(created, 0), time.Unix(updated, 0)
+	t.Tags, t.Done = splitTags(tags), done == 1
+	if recurrence != "" {
+		t.Recurrence, _ = rrule.ParseSet(recurrence, time.Local)
+	}
+	return t, nil
+}
+
+// unix converts unix seconds to time, 0 is the zero time.
+func unix(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+// seconds converts time to unix seconds, the zero time is 0.
+func seconds(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// joinTags stores tags as ",a,b," so a tag could be matched with LIKE '%,a,%'.
+func joinTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return "," + strings.Join(tags, ",") + ","
+}
+
+func splitTags(s string) []string {
+	tags := []string{}
+	for _, t := range strings.Split(s, ",") {
+		if t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// Create stores @t as a new todo, its ID and times are filled.
+func Create(ctx context.Context, t *Todo) error {
+	if err := insert(ctx, database.DB(), t); err != nil {
+		return err
+	}
+	signal.Emit(TopicCreated, t)
+	return nil
+}
+
+// insert stores @t as a new todo within the transaction of @ex.
+func insert(ctx context.Context, ex database.Executor, t *Todo) error {
+	now := time.Now()
+	t.ID, t.CreatedAt, t.UpdatedAt = xid.New().String(), now, now
+	recurrence := ""
+	if t.Recurrence != nil {
+		recurrence = t.Recurrence.String()
+	}
+	_, err := ex.ExecContext(ctx, `INSERT INTO todos (`+columns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, 0, '', 0, $8, $8, $9)`,
+		t.ID, t.ChatID, t.CreatedBy, t.Title, seconds(t.DueAt), t.Priority, joinTags(t.Tags), now.Unix(), recurrence)
+	return database.Err(err)
+}
+
+// Get gets the todo @id.
+func Get(ctx context.Context, id string) (*Todo, error) {
+	t, err := scan(database.DB().QueryRowContext(ctx, "SELECT "+columns+" FROM todos WHERE id = $1", id))
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	return t, nil
+}
+
+// List lists the todos of @chatID which are @done or not, with @tag if not empty, and the total count.
+func List(ctx context.Context, chatID, tag string, done bool, offset, limit int) ([]*Todo, int, error) {
+	where := " FROM todos WHERE chat_id = $1 AND done = $2 AND ($3 = '' OR tags LIKE '%,' || $3 || ',%')"
+	flag := 0
+	if done {
+		flag = 1
+	}
+	var total int
+	if err := database.DB().QueryRowContext(ctx, "SELECT COUNT(*)"+where, chatID, flag, tag).Scan(&total); err != nil {
+		return nil, 0, database.Err(err)
+	}
+	q := "SELECT " + columns + where + order
+	if done {
+		q = "SELECT " + columns + where + " ORDER BY done_at DESC, id"
+	}
+	rows, err := database.DB().QueryContext(ctx, q+" LIMIT $4 OFFSET $5", chatID, flag, tag, limit, offset)
+	if err != nil {
+		return nil, 0, database.Err(err)
+	}
+	defer rows.Close()
+	list := []*Todo{}
+	for rows.Next() {
+		t, err := scan(rows)
+		if err != nil {
+			return nil, 0, database.Err(err)
+		}
+		list = append(list, t)
+	}
+	return list, total, database.Err(rows.Err())
+}
+
+// Due lists the open todos of @chatID due before @before, the earliest first.
+func Due(ctx context.Context, chatID string, before time.Time) ([]*Todo, error) {
+	rows, err := database.DB().QueryContext(ctx, "SELECT "+columns+
+		" FROM todos WHERE chat_id = $1 AND done = 0 AND due_at > 0 AND due_at < $2 ORDER BY due_at, priority DESC, id",
+		chatID, before.Unix())
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	defer rows.Close()
+	list := []*Todo{}
+	for rows.Next() {
+		t, err := scan(rows)
+		if err != nil {
+			return nil, database.Err(err)
+		}
+		list = append(list, t)
+	}
+	return list, database.Err(rows.Err())
+}
+
+// Update saves the title, due date, priority and tags of @t.
+func Update(ctx context.Context, t *Todo) error {
+	t.UpdatedAt = time.Now()
+	res, err := database.DB().ExecContext(ctx,
+		"UPDATE todos SET title = $1, due_at = $2, priority = $3, tags = $4, updated_at = $5 WHERE id = $6",
+		t.Title, seconds(t.DueAt), t.Priority, joinTags(t.Tags), t.UpdatedAt.Unix(), t.ID)
+	return affected(res, err)
+}
+
+// Complete marks the todo @id as done by @userID, or not done if @done is false.
+// Completing a recurring todo creates the next one of the series, which is kept if undone.
+func Complete(ctx context.Context, id, userID string, done bool) error {
+	now := time.Now().Unix()
+	if !done {
+		res, err := database.DB().ExecContext(ctx,
+			"UPDATE todos SET done = 0, done_by = '', done_at = 0, updated_at = $1 WHERE id = $2", now, id)
+		return affected(res, err)
+	}
+
+	t, err := Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	var next *Todo
+	if t.Recurrence != nil && !t.Done {
+		err = database.Tx(ctx, func(tx *sql.Tx) error {
+			res, err := tx.ExecContext(ctx, `UPDATE todos SET done = 1, done_by = $1, done_at = $2, updated_at = $2,
+				recurrence = '' WHERE id = $3 AND done = 0`, userID, now, id)
+			if err := affected(res, err); err != nil {
+				return err
+			}
+			next, err = advance(ctx, tx, t, time.Unix(now, 0))
+			return err
+		})
+	} else {
+		err = affected(database.DB().ExecContext(ctx,
+			"UPDATE todos SET done = 1, done_by = $1, done_at = $2, updated_at = $2 WHERE id = $3", userID, now, id))
+	}
+	if err != nil {
+		return err
+	}
+	if !t.Done {
+		t.Done, t.DoneBy, t.DoneAt, t.UpdatedAt = true, userID, time.Unix(now, 0), time.Unix(now, 0)
+		signal.Emit(TopicCompleted, t)
+	}
+	if next != nil {
+		signal.Emit(TopicCreated, next)
+	}
+	return nil
+}
+
+// advance creates the todo after the recurring @t completed at @now within the transaction of @ex,
+// due at the next occurrence after its due date, or from today on if it was overdue.
+// The series ends if there is none, and the returned todo is nil.
+func advance(ctx context.Context, ex database.Executor, t *Todo, now time.Time) (*Todo, error) {
+	after := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, t.Recurrence.Start.Location()).Add(-time.Second)
+	if t.DueAt.After(after) {
+		after = t.DueAt
+	}
+	due, ok := t.Recurrence.After(after)
+	if !ok {
+		return nil, nil
+	}
+	n := &Todo{ChatID: t.ChatID, CreatedBy: t.CreatedBy, Title: t.Title, DueAt: due, Priority: t.Priority, Tags: t.Tags,
+		Recurrence: t.Recurrence}
+	if err := insert(ctx, ex, n); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// Delete deletes the todo @id.
+func Delete(ctx context.Context, id string) error {
+	res, err := database.DB().ExecContext(ctx, "DELETE FROM todos WHERE id = $1", id)
+	return affected(res, err)
+}
+
+func affected(res sql.Result, err error) error {
+	if err != nil {
+		return database.Err(err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return database.Err(err)
+	} else if n == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+/*
+	todo.go
+	Purpose: Chat commands and postbacks of todos.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Add recurring todos
+	2026/10/17  v1.0.2 Evan Chen   Handle the done buttons as signed postback actions
+*/
+
+// Package todo keeps todo lists, like "/todo buy milk #home !high due:fri".
+// A todo with "every:weekday" repeats, completing it adds the next one.
+//
+// A todo added in a one-on-one chat is personal, while a todo added in a group or room
+// is shared with the chat, so every member could see and complete it.
+// Todos are managed with chat commands, the "done" buttons of the listed todos, or the [pb.TodoServiceServer].
+package todo
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"app/core/command"
+	"app/core/errors"
+	"app/core/line"
+	"app/core/msg"
+	"app/core/postback"
+)
+
+// shown is how many todos are shown in the list of a chat.
+const shown = 10
+
+func init() {
+	command.Register(
+		&command.Command{
+			Name:    "todo",
+			Aliases: []string{"待辦"},
+			Args:    []command.Arg{{Name: "title #tag !priority due:date", Rest: true}},
+			Desc:    "todo.desc",
+			Skill:   "todo",
+			Handler: add,
+		},
+		&command.Command{
+			Name:    "todo list",
+			Args:    []command.Arg{{Name: "tag"}},
+			Desc:    "todo.list.desc",
+			Skill:   "todo",
+			Handler: list,
+		},
+		&command.Command{
+			Name:    "todo done",
+			Args:    []command.Arg{{Name: "no|id", Required: true}},
+			Desc:    "todo.done.desc",
+			Skill:   "todo",
+			Handler: done,
+		},
+		&command.Command{
+			Name:    "todo del",
+			Args:    []command.Arg{{Name: "no|id", Required: true}},
+			Desc:    "todo.del.desc",
+			Skill:   "todo",
+			Handler: del,
+		},
+	)
+	postback.Register(&postback.Action{Name: "todo.done", Handler: finish})
+}
+
+// Service is the [service.Service] of todos.
+var Service = &todo{}
+
+type todo struct{}
+
+func (s *todo) Init() error { return nil }
+
+func (s *todo) Load() {}
+
+func (s *todo) Del() {}
+
+// add adds a todo to the chat, or lists the todos if nothing is given.
+func add(c *command.Context) error {
+	text := c.Args.Get("title #tag !priority due:date")
+	if text == "" {
+		return list(c)
+	}
+	t, err := parse(text, time.Now())
+	if err == errEvery {
+		return c.ReplyText("todo.bad_every", nil)
+	} else if err != nil {
+		return c.ReplyText("todo.bad_due", nil)
+	}
+	if t.Title == "" {
+		return c.ReplyText("command.usage", msg.Plain(c.Command.Usage()))
+	}
+	t.ChatID, t.CreatedBy = c.ChatID(), sender(c.Event.Source)
+	if err := Create(c, t); err != nil {
+		return err
+	}
+	return c.ReplyText("todo.created", view(t, 0))
+}
+
+func list(c *command.Context) error {
+	tag := strings.ToLower(strings.TrimLeft(c.Args.Get("tag"), "#＃"))
+	todos, total, err := List(c, c.ChatID(), tag, false, 0, shown)
+	if err != nil {
+		return err
+	}
+	if total == 0 {
+		return c.ReplyText("todo.empty", nil)
+	}
+	views := make([]map[string]any, len(todos))
+	for i, t := range todos {
+		views[i] = view(t, i+1)
+	}
+	return c.ReplyFlex("todo.list", map[string]any{
+		"Todos":  views,
+		"Total":  total,
+		"More":   total - len(todos),
+		"Shared": c.InGroup(),
+		"Tag":    tag,
+	})
+}
+
+func done(c *command.Context) error {
+	t, err := find(c, c.Args.Get("no|id"))
+	if err != nil {
+		return err
+	}
+	if err := Complete(c, t.ID, sender(c.Event.Source), true); err != nil {
+		return err
+	}
+	return c.ReplyText("todo.done", view(t, 0))
+}
+
+func del(c *command.Context) error {
+	t, err := find(c, c.Args.Get("no|id"))
+	if err != nil {
+		return err
+	}
+	if err := Delete(c, t.ID); err != nil {
+		return err
+	}
+	return c.ReplyText("todo.deleted", view(t, 0))
+}
+
+// find finds the open todo of the chat by its number in the list, or by its ID.
+func find(c *command.Context, ref string) (*Todo, error) {
+	if n, err := strconv.Atoi(ref); err == nil {
+		if n < 1 {
+			return nil, errors.ErrNotFound
+		}
+		todos, _, err := List(c, c.ChatID(), "", false, n-1, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(todos) == 0 {
+			return nil, errors.ErrNotFound
+		}
+		return todos[0], nil
+	}
+	t, err := Get(c, ref)
+	if err != nil {
+		return nil, err
+	}
+	// todos of other chats are treated as not existing
+	if t.ChatID != c.ChatID() {
+		return nil, errors.ErrNotFound
+	}
+	return t, nil
+}
+
+// finish handles the done button of a listed todo.
+func finish(c *postback.Context) error {
+	t, err := Get(c, c.Args.String("id"))
+	if err != nil {
+		return err
+	}
+	if t.ChatID != c.ChatID() {
+		return errors.ErrNotFound
+	}
+	if t.Done {
+		return c.ReplyText("todo.already_done", view(t, 0))
+	}
+	if err := Complete(c, t.ID, sender(c.Event.Source), true); err != nil {
+		return err
+	}
+	return c.ReplyText("todo.done", view(t, 0))
+}
+
+// sender returns the user who sent the event, or the chat if the user is unknown.
+func sender(src *line.Source) string {
+	if src == nil {
+		return ""
+	}
+	if src.UserID != "" {
+		return src.UserID
+	}
+	return src.ID()
+}
+
+// view is the template data of a todo, @no is its number in the list.
+func view(t *Todo, no int) map[string]any {
+	due, overdue := "", false
+	if !t.DueAt.IsZero() {
+		now := time.Now()
+		due = t.DueAt.Format("01/02")
+		overdue = t.DueAt.Before(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()))
+	}
+	return map[string]any{
+		"No":       no,
+		"ID":       t.ID,
+		"Title":    t.Title,
+		"Due":      due,
+		"Overdue":  overdue,
+		"Priority": t.Priority,
+		"Tags":     t.Tags,
+		"Repeat":   t.Recurrence != nil,
+		"Finish":   postback.Data("todo.done", postback.Args{"id": t.ID}),
+	}
+}
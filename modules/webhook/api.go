@@ -0,0 +1,238 @@
+/*
+	api.go
+	Purpose: The api to register hooks, list their deliveries and redeliver them.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"app/core/auth"
+	"app/core/errors"
+	"app/core/server"
+
+	"golang.org/x/exp/slog"
+)
+
+// Paths of the api in the gateway mux.
+const (
+	HooksPath      = "/api/webhooks"
+	HookPath       = "/api/webhooks/{id}"
+	DeliveriesPath = "/api/webhooks/{id}/deliveries"
+	RedeliverPath  = "/api/webhooks/{id}/deliveries/{delivery}/redeliver"
+)
+
+const (
+	// maxHooks is how many hooks a user who is not an admin could register.
+	maxHooks = 10
+	// maxDeliveries is the most deliveries listed at once.
+	maxDeliveries = 100
+)
+
+// List lists the hooks of the signed in user, or every hook for admins, with the topics could be subscribed,
+// e.g. {"webhooks": [...], "topics": ["todo.completed", ...]}.
+// It is a handler of the gateway mux, see [runtime.ServeMux.HandlePath].
+func List(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	u, ok := auth.GetUserFromToken(server.GetHttpAuthToken(r))
+	if !ok {
+		server.HttpAbort(w, r, errors.ErrUnauthorized)
+		return
+	}
+	owner := u.Username
+	if u.Group >= auth.ADMIN {
+		owner = ""
+	}
+	list, err := listHooks(r.Context(), owner)
+	if err != nil {
+		server.HttpAbort(w, r, errors.Convert(err))
+		return
+	}
+	reply(w, http.StatusOK, map[string]any{"webhooks": list, "topics": Topics})
+}
+
+// Create registers a hook of the signed in user with the body {"url": "https://...", "topics": ["todo.completed"]},
+// admins could also set "all" to receive the events of every chat.
+// The hook is replied with its secret, which is not shown again.
+// It is a handler of the gateway mux, see [runtime.ServeMux.HandlePath].
+func Create(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	u, ok := auth.GetUserFromToken(server.GetHttpAuthToken(r))
+	if !ok {
+		server.HttpAbort(w, r, errors.ErrUnauthorized)
+		return
+	}
+	h := &Hook{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10)).Decode(h); err != nil {
+		server.HttpAbort(w, r, errors.ErrBadRequest.SetInfo(err.Error()))
+		return
+	}
+	if err := validate(h); err != nil {
+		server.HttpAbort(w, r, err)
+		return
+	}
+	if u.Group < auth.ADMIN {
+		if h.All {
+			server.HttpAbort(w, r, errors.ErrForbidden.SetInfo("only admins could receive every chat"))
+			return
+		}
+		n, err := countHooks(r.Context(), u.Username)
+		if err != nil {
+			server.HttpAbort(w, r, errors.Convert(err))
+			return
+		}
+		if n >= maxHooks {
+			server.HttpAbort(w, r, errors.ErrBadRequest.SetInfo("too many webhooks"))
+			return
+		}
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		server.HttpAbort(w, r, errors.ErrInternal.SetInfo(err.Error()))
+		return
+	}
+	h.Owner, h.Secret = u.Username, hex.EncodeToString(secret)
+	if err := create(r.Context(), h); err != nil {
+		server.HttpAbort(w, r, errors.Convert(err))
+		return
+	}
+	if err := reload(r.Context()); err != nil {
+		server.HttpAbort(w, r, errors.Convert(err))
+		return
+	}
+	slog.Info("webhook created", slog.String("mod", "webhook"), slog.String("act", "create"), slog.String("usr", u.Username),
+		slog.String("id", h.ID), slog.Any("topics", h.Topics), slog.Bool("all", h.All))
+	reply(w, http.StatusCreated, h)
+}
+
+// Remove deletes the hook {id} with its deliveries.
+// It is a handler of the gateway mux, see [runtime.ServeMux.HandlePath].
+func Remove(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	u, h, err := access(r, params["id"])
+	if err != nil {
+		server.HttpAbort(w, r, err)
+		return
+	}
+	if err := deleteHook(r.Context(), h.ID); err != nil {
+		server.HttpAbort(w, r, errors.Convert(err))
+		return
+	}
+	if err := reload(r.Context()); err != nil {
+		server.HttpAbort(w, r, errors.Convert(err))
+		return
+	}
+	slog.Info("webhook deleted", slog.String("mod", "webhook"), slog.String("act", "delete"), slog.String("usr", u.Username),
+		slog.String("id", h.ID))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Deliveries lists the latest deliveries of the hook {id} with their attempts, up to ?limit= which defaults to 20,
+// e.g. {"deliveries": [...]}.
+// It is a handler of the gateway mux, see [runtime.ServeMux.HandlePath].
+func Deliveries(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	_, h, err := access(r, params["id"])
+	if err != nil {
+		server.HttpAbort(w, r, err)
+		return
+	}
+	limit := 20
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			server.HttpAbort(w, r, errors.ErrBadRequest.SetInfo("invalid limit"))
+			return
+		}
+		limit = n
+	}
+	if limit > maxDeliveries {
+		limit = maxDeliveries
+	}
+	list, lerr := listDeliveries(r.Context(), h.ID, limit)
+	if lerr != nil {
+		server.HttpAbort(w, r, errors.Convert(lerr))
+		return
+	}
+	reply(w, http.StatusOK, map[string]any{"deliveries": list})
+}
+
+// Redeliver posts the delivery {delivery} of the hook {id} again with its retries reset, it replies 202 once queued.
+// It is a handler of the gateway mux, see [runtime.ServeMux.HandlePath].
+func Redeliver(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	u, h, err := access(r, params["id"])
+	if err != nil {
+		server.HttpAbort(w, r, err)
+		return
+	}
+	if err := redeliver(r.Context(), h.ID, params["delivery"]); err != nil {
+		server.HttpAbort(w, r, errors.Convert(err))
+		return
+	}
+	Notify()
+	slog.Info("redelivery queued", slog.String("mod", "webhook"), slog.String("act", "redeliver"), slog.String("usr", u.Username),
+		slog.String("hook", h.ID), slog.String("id", params["delivery"]))
+	reply(w, http.StatusAccepted, map[string]string{"id": params["delivery"]})
+}
+
+// access returns the signed in user and the hook @id, if the user owns it or is an admin.
+func access(r *http.Request, id string) (*auth.UserInfo, *Hook, *errors.Error) {
+	u, ok := auth.GetUserFromToken(server.GetHttpAuthToken(r))
+	if !ok {
+		return nil, nil, errors.ErrUnauthorized
+	}
+	h, err := getHook(r.Context(), id)
+	if err != nil {
+		return nil, nil, errors.Convert(err)
+	}
+	if h.Owner != u.Username && u.Group < auth.ADMIN {
+		// not telling whether the hook exists
+		return nil, nil, errors.ErrNotFound
+	}
+	return u, h, nil
+}
+
+// validate checks the url and topics of @h, duplicated topics are removed.
+func validate(h *Hook) *errors.Error {
+	u, err := url.Parse(h.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.ErrBadRequest.SetInfo("url should be an absolute http or https url")
+	}
+	if len(h.Topics) == 0 {
+		return errors.ErrBadRequest.SetInfo("topics are required")
+	}
+	known := map[string]bool{}
+	for _, t := range Topics {
+		known[t] = true
+	}
+	seen := map[string]bool{}
+	topics := []string{}
+	for _, t := range h.Topics {
+		if !known[t] {
+			return errors.ErrBadRequest.SetInfo("unknown topic " + strconv.Quote(t))
+		}
+		if !seen[t] {
+			seen[t] = true
+			topics = append(topics, t)
+		}
+	}
+	h.Topics = topics
+	return nil
+}
+
+// reply writes @v as JSON with @code.
+func reply(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
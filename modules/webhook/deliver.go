@@ -0,0 +1,216 @@
+/*
+	deliver.go
+	Purpose: Post deliveries to the hooks with retries.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Count the retries from the last redelivery
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"app/core/util"
+
+	"golang.org/x/exp/slog"
+)
+
+// maxBackoff caps the wait between retries.
+const maxBackoff = time.Hour
+
+// batch is how many deliveries are posted in a round.
+const batch = 50
+
+// Headers of a delivery.
+const (
+	HeaderTopic     = "X-Webhook-Topic"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// errPrivate is returned when a hook resolves to an address not allowed by [Option.AllowPrivate].
+var errPrivate = fmt.Errorf("private address is not allowed")
+
+var client = newClient()
+
+// newClient returns the client to post deliveries with, which does not follow redirects
+// and refuses private addresses unless allowed.
+func newClient() *http.Client {
+	dialer := &net.Dialer{Timeout: opt.Timeout, Control: guard}
+	return &http.Client{
+		Timeout:   opt.Timeout,
+		Transport: &http.Transport{DialContext: dialer.DialContext, TLSHandshakeTimeout: opt.Timeout},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// guard checks the resolved address before connecting, so a hook could not reach internal services by dns tricks.
+func guard(_, address string, _ syscall.RawConn) error {
+	if opt.AllowPrivate {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return errPrivate
+	}
+	return nil
+}
+
+// Sign returns the signature of @body sent at @timestamp with @secret, as in [HeaderSignature].
+func Sign(secret string, timestamp int64, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	h.Write(body)
+	return "sha256=" + hex.EncodeToString(h.Sum(nil))
+}
+
+func (s *webhook) run(stop, done chan struct{}) {
+	defer close(done)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stop
+		cancel()
+	}()
+
+	for {
+		deliver(ctx, time.Now())
+
+		wait := opt.Interval
+		if t, ok, err := next(ctx); err == nil && ok {
+			if d := time.Until(t); d < wait {
+				wait = d
+			}
+		}
+		if wait < time.Second {
+			wait = time.Second
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-s.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// deliver posts a batch of due deliveries, it returns how many were attempted.
+func deliver(ctx context.Context, now time.Time) int {
+	list, err := due(ctx, now, batch)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("list deliveries failed", util.ErrAtrr(err), slog.String("mod", "webhook"), slog.String("act", "deliver"))
+		}
+		return 0
+	}
+	for i, d := range list {
+		if ctx.Err() != nil {
+			return i
+		}
+		send(ctx, d)
+	}
+	return len(list)
+}
+
+// send posts @d once, and records the attempt.
+func send(ctx context.Context, d *Delivery) {
+	start := time.Now()
+	code, err := post(ctx, d, start.Unix())
+	if err != nil && ctx.Err() != nil {
+		// interrupted by shutdown, leave it pending to be posted on next start
+		return
+	}
+	a := &Attempt{Attempt: d.Attempts + 1, StatusCode: code, DurationMS: time.Since(start).Milliseconds(), CreatedAt: start.Unix()}
+	if err != nil {
+		a.Error = err.Error()
+	}
+
+	status, retryAt := SENT, time.Now()
+	if err != nil {
+		// the retries are counted from the last redelivery, while the attempts are numbered on
+		status, retryAt = PENDING, time.Now().Add(backoff(d.Attempts-d.base))
+		if a.Attempt-d.base >= opt.MaxAttempts {
+			status = DEAD
+		}
+	}
+	// the outcome is recorded even if ctx is cancelled afterwards
+	if err := record(context.Background(), d, a, status, retryAt); err != nil {
+		slog.Error("record attempt failed", util.ErrAtrr(err), slog.String("mod", "webhook"), slog.String("act", "deliver"),
+			slog.String("id", d.ID))
+	}
+
+	args := []any{slog.String("mod", "webhook"), slog.String("act", "deliver"), slog.String("id", d.ID),
+		slog.String("hook", d.HookID), slog.String("topic", d.Topic), slog.Int("attempt", a.Attempt), slog.Int("code", code)}
+	switch status {
+	case SENT:
+		slog.Info("delivery posted", args...)
+	case DEAD:
+		slog.Error("delivery dead", append(args, util.ErrAtrr(err))...)
+	default:
+		slog.Warn("delivery failed", append(args, util.ErrAtrr(err), slog.Time("retry_at", retryAt))...)
+	}
+}
+
+// post posts the payload of @d signed at @timestamp, it returns the status code replied.
+// A reply other than 2xx is an error.
+func post(ctx context.Context, d *Delivery, timestamp int64) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "lineasst-webhook")
+	req.Header.Set(HeaderTopic, d.Topic)
+	req.Header.Set(HeaderDelivery, d.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(d.secret, timestamp, d.Payload))
+
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	// the reply is read a little so the connection could be reused
+	io.Copy(io.Discard, io.LimitReader(res.Body, 4<<10))
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("replied %s", res.Status)
+	}
+	return res.StatusCode, nil
+}
+
+// backoff returns the wait after @attempts failed attempts.
+func backoff(attempts int) time.Duration {
+	d := opt.Backoff << attempts
+	if d > maxBackoff || d <= 0 {
+		d = maxBackoff
+	}
+	return d
+}
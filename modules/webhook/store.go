@@ -0,0 +1,374 @@
+/*
+	store.go
+	Purpose: Persist outgoing webhooks, their deliveries and attempts in the database.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Keep numbering the attempts across redeliveries
+*/
+
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"app/core/database"
+	"app/core/errors"
+
+	"github.com/rs/xid"
+)
+
+func init() {
+	database.Register(&database.Migration{
+		ID: "20261017_webhook",
+		Stmts: []string{
+			`CREATE TABLE webhooks (
+				id TEXT PRIMARY KEY,
+				owner TEXT NOT NULL,
+				url TEXT NOT NULL,
+				secret TEXT NOT NULL,
+				topics TEXT NOT NULL,
+				all_chats INTEGER NOT NULL,
+				created_at BIGINT NOT NULL,
+				updated_at BIGINT NOT NULL
+			)`,
+			`CREATE INDEX idx_webhooks_owner ON webhooks (owner)`,
+			`CREATE TABLE webhook_deliveries (
+				id TEXT PRIMARY KEY,
+				hook_id TEXT NOT NULL,
+				topic TEXT NOT NULL,
+				payload TEXT NOT NULL,
+				status TEXT NOT NULL,
+				attempts INTEGER NOT NULL DEFAULT 0,
+				last_error TEXT NOT NULL DEFAULT '',
+				next_attempt_at BIGINT NOT NULL,
+				created_at BIGINT NOT NULL,
+				updated_at BIGINT NOT NULL
+			)`,
+			`CREATE INDEX idx_webhook_deliveries_status_next ON webhook_deliveries (status, next_attempt_at)`,
+			`CREATE INDEX idx_webhook_deliveries_hook ON webhook_deliveries (hook_id, created_at)`,
+			`CREATE TABLE webhook_attempts (
+				id TEXT PRIMARY KEY,
+				delivery_id TEXT NOT NULL,
+				hook_id TEXT NOT NULL,
+				attempt INTEGER NOT NULL,
+				status_code INTEGER NOT NULL,
+				error TEXT NOT NULL,
+				duration_ms BIGINT NOT NULL,
+				created_at BIGINT NOT NULL
+			)`,
+			`CREATE INDEX idx_webhook_attempts_delivery ON webhook_attempts (delivery_id)`,
+		},
+	})
+	database.Register(&database.Migration{
+		ID: "20261017_webhook_redeliver",
+		Stmts: []string{
+			`ALTER TABLE webhook_deliveries ADD COLUMN base_attempts INTEGER NOT NULL DEFAULT 0`,
+			`ALTER TABLE webhook_deliveries ADD COLUMN redelivered_at BIGINT NOT NULL DEFAULT 0`,
+		},
+	})
+}
+
+// Status of a delivery.
+const (
+	PENDING = "pending" // waiting to be delivered
+	SENT    = "sent"    // replied with 2xx by the receiver
+	DEAD    = "dead"    // failed after exhausting the retries, it could be redelivered
+)
+
+// Hook is an outgoing webhook that receives the events of its topics.
+type Hook struct {
+	ID string `json:"id"`
+	// Owner is the user who registered the hook.
+	Owner string `json:"owner"`
+	URL   string `json:"url"`
+	// Secret signs the deliveries, it is only shown when the hook is created.
+	Secret string   `json:"secret,omitempty"`
+	Topics []string `json:"topics"`
+	// All receives the events of every chat, which only admins could set.
+	// Otherwise only the events of the owner's own chat and the groups the owner is a member of are received.
+	All       bool  `json:"all"`
+	CreatedAt int64 `json:"created_at"`
+	UpdatedAt int64 `json:"updated_at"`
+}
+
+// Delivery is an event to be posted to a hook.
+type Delivery struct {
+	ID      string          `json:"id"`
+	HookID  string          `json:"hook_id"`
+	Topic   string          `json:"topic"`
+	Payload json.RawMessage `json:"payload"`
+	Status  string          `json:"status"`
+	// Attempts is how many times it was posted, counted on across redeliveries.
+	Attempts      int    `json:"attempts"`
+	LastError     string `json:"last_error"`
+	NextAttemptAt int64  `json:"next_attempt_at"`
+	// RedeliveredAt is when it was last redelivered, 0 if never.
+	RedeliveredAt int64 `json:"redelivered_at"`
+	CreatedAt     int64 `json:"created_at"`
+	UpdatedAt     int64 `json:"updated_at"`
+	// Log are the attempts of the delivery, oldest first.
+	Log []*Attempt `json:"log"`
+
+	// base is how many attempts were made before the last redelivery, the retries are counted from it.
+	base        int
+	url, secret string
+}
+
+// Attempt is the outcome of posting a delivery once.
+type Attempt struct {
+	Attempt int `json:"attempt"`
+	// StatusCode is the http status replied, 0 if no reply, e.g. timed out.
+	StatusCode int    `json:"status_code"`
+	Error      string `json:"error"`
+	DurationMS int64  `json:"duration_ms"`
+	CreatedAt  int64  `json:"created_at"`
+
+	deliveryID string
+}
+
+const hookColumns = "id, owner, url, topics, all_chats, created_at, updated_at"
+
+func scanHook(row interface{ Scan(...any) error }) (*Hook, error) {
+	h := &Hook{}
+	var topics string
+	var all int
+	if err := row.Scan(&h.ID, &h.Owner, &h.URL, &topics, &all, &h.CreatedAt, &h.UpdatedAt); err != nil {
+		return nil, err
+	}
+	h.Topics, h.All = strings.Split(topics, ","), all == 1
+	return h, nil
+}
+
+// create stores @h as a new hook, its ID and times are filled.
+func create(ctx context.Context, h *Hook) error {
+	all := 0
+	if h.All {
+		all = 1
+	}
+	now := time.Now().Unix()
+	h.ID, h.CreatedAt, h.UpdatedAt = xid.New().String(), now, now
+	_, err := database.DB().ExecContext(ctx, `INSERT INTO webhooks
+		(id, owner, url, secret, topics, all_chats, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $7)`,
+		h.ID, h.Owner, h.URL, h.Secret, strings.Join(h.Topics, ","), all, now)
+	return database.Err(err)
+}
+
+// getHook gets the hook @id.
+func getHook(ctx context.Context, id string) (*Hook, error) {
+	h, err := scanHook(database.DB().QueryRowContext(ctx, "SELECT "+hookColumns+" FROM webhooks WHERE id = $1", id))
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	return h, nil
+}
+
+// listHooks lists the hooks of @owner, every hook if empty, oldest first.
+func listHooks(ctx context.Context, owner string) ([]*Hook, error) {
+	rows, err := database.DB().QueryContext(ctx,
+		"SELECT "+hookColumns+" FROM webhooks WHERE $1 = '' OR owner = $1 ORDER BY created_at, id", owner)
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	defer rows.Close()
+	list := []*Hook{}
+	for rows.Next() {
+		h, err := scanHook(rows)
+		if err != nil {
+			return nil, database.Err(err)
+		}
+		list = append(list, h)
+	}
+	return list, database.Err(rows.Err())
+}
+
+// countHooks counts the hooks of @owner.
+func countHooks(ctx context.Context, owner string) (int, error) {
+	var n int
+	err := database.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM webhooks WHERE owner = $1", owner).Scan(&n)
+	return n, database.Err(err)
+}
+
+// deleteHook deletes the hook @id with its deliveries and attempts.
+func deleteHook(ctx context.Context, id string) error {
+	return database.Err(database.Tx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, "DELETE FROM webhooks WHERE id = $1", id)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return errors.ErrNotFound
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM webhook_attempts WHERE hook_id = $1", id); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, "DELETE FROM webhook_deliveries WHERE hook_id = $1", id)
+		return err
+	}))
+}
+
+// enqueue adds a delivery of @payload on @topic to the hook @hookID.
+func enqueue(ctx context.Context, id, hookID, topic string, payload []byte) error {
+	now := time.Now().Unix()
+	_, err := database.DB().ExecContext(ctx, `INSERT INTO webhook_deliveries
+		(id, hook_id, topic, payload, status, next_attempt_at, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $6, $6)`,
+		id, hookID, topic, string(payload), PENDING, now)
+	return database.Err(err)
+}
+
+const deliveryColumns = "d.id, d.hook_id, d.topic, d.payload, d.status, d.attempts, d.base_attempts, d.last_error, d.next_attempt_at, " +
+	"d.redelivered_at, d.created_at, d.updated_at"
+
+func scanDelivery(row interface{ Scan(...any) error }, dest ...any) (*Delivery, error) {
+	d := &Delivery{Log: []*Attempt{}}
+	var payload string
+	err := row.Scan(append([]any{&d.ID, &d.HookID, &d.Topic, &payload, &d.Status, &d.Attempts, &d.base, &d.LastError,
+		&d.NextAttemptAt, &d.RedeliveredAt, &d.CreatedAt, &d.UpdatedAt}, dest...)...)
+	if err != nil {
+		return nil, err
+	}
+	d.Payload = json.RawMessage(payload)
+	return d, nil
+}
+
+// due lists pending deliveries to be attempted at @now with the url and secret of their hooks, oldest first.
+func due(ctx context.Context, now time.Time, limit int) ([]*Delivery, error) {
+	rows, err := database.DB().QueryContext(ctx, "SELECT "+deliveryColumns+`, h.url, h.secret
+		FROM webhook_deliveries d JOIN webhooks h ON h.id = d.hook_id
+		WHERE d.status = $1 AND d.next_attempt_at <= $2 ORDER BY d.created_at, d.id LIMIT $3`,
+		PENDING, now.Unix(), limit)
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	defer rows.Close()
+	list := []*Delivery{}
+	for rows.Next() {
+		var url, secret string
+		d, err := scanDelivery(rows, &url, &secret)
+		if err != nil {
+			return nil, database.Err(err)
+		}
+		d.url, d.secret = url, secret
+		list = append(list, d)
+	}
+	return list, database.Err(rows.Err())
+}
+
+// next returns when the next pending delivery should be attempted, ok is false if there is none.
+func next(ctx context.Context) (t time.Time, ok bool, err error) {
+	var at sql.NullInt64
+	err = database.DB().QueryRowContext(ctx,
+		"SELECT MIN(next_attempt_at) FROM webhook_deliveries WHERE status = $1", PENDING).Scan(&at)
+	if err != nil || !at.Valid {
+		return t, false, database.Err(err)
+	}
+	return time.Unix(at.Int64, 0), true, nil
+}
+
+// record logs attempt @a of @d, and moves @d to @status to be retried at @retryAt if still pending.
+func record(ctx context.Context, d *Delivery, a *Attempt, status string, retryAt time.Time) error {
+	return database.Err(database.Tx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO webhook_attempts
+			(id, delivery_id, hook_id, attempt, status_code, error, duration_ms, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			xid.New().String(), d.ID, d.HookID, a.Attempt, a.StatusCode, a.Error, a.DurationMS, a.CreatedAt)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE webhook_deliveries
+			SET status = $1, attempts = attempts + 1, last_error = $2, next_attempt_at = $3, updated_at = $4 WHERE id = $5`,
+			status, a.Error, retryAt.Unix(), time.Now().Unix(), d.ID)
+		return err
+	}))
+}
+
+// listDeliveries lists the latest deliveries of the hook @hookID with their attempts.
+func listDeliveries(ctx context.Context, hookID string, limit int) ([]*Delivery, error) {
+	rows, err := database.DB().QueryContext(ctx, "SELECT "+deliveryColumns+
+		" FROM webhook_deliveries d WHERE d.hook_id = $1 ORDER BY d.created_at DESC, d.id DESC LIMIT $2", hookID, limit)
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	list := []*Delivery{}
+	byID := map[string]*Delivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			rows.Close()
+			return nil, database.Err(err)
+		}
+		list = append(list, d)
+		byID[d.ID] = d
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil || len(list) == 0 {
+		return list, database.Err(err)
+	}
+
+	// attempts are queried after the deliveries are read, as sqlite runs with a single connection
+	rows, err = database.DB().QueryContext(ctx, `SELECT delivery_id, attempt, status_code, error, duration_ms, created_at
+		FROM webhook_attempts WHERE hook_id = $1 AND created_at >= $2 ORDER BY created_at, attempt`,
+		hookID, list[len(list)-1].CreatedAt)
+	if err != nil {
+		return nil, database.Err(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		a := &Attempt{}
+		if err := rows.Scan(&a.deliveryID, &a.Attempt, &a.StatusCode, &a.Error, &a.DurationMS, &a.CreatedAt); err != nil {
+			return nil, database.Err(err)
+		}
+		if d, ok := byID[a.deliveryID]; ok {
+			d.Log = append(d.Log, a)
+		}
+	}
+	return list, database.Err(rows.Err())
+}
+
+// redeliver puts the delivery @id of the hook @hookID back to pending with its retries reset,
+// the attempts so far are kept in the log and the next ones are numbered after them.
+func redeliver(ctx context.Context, hookID, id string) error {
+	now := time.Now().Unix()
+	res, err := database.DB().ExecContext(ctx, `UPDATE webhook_deliveries
+		SET status = $1, base_attempts = attempts, next_attempt_at = $2, redelivered_at = $2, updated_at = $2
+		WHERE id = $3 AND hook_id = $4`,
+		PENDING, now, id, hookID)
+	if err != nil {
+		return database.Err(err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return database.Err(err)
+	} else if n == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// purge deletes the deliveries which are no longer pending and last updated before @before, with their attempts.
+func purge(ctx context.Context, before time.Time) (int64, error) {
+	var n int64
+	err := database.Tx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM webhook_attempts WHERE delivery_id IN
+			(SELECT id FROM webhook_deliveries WHERE status <> $1 AND updated_at < $2)`, PENDING, before.Unix())
+		if err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, "DELETE FROM webhook_deliveries WHERE status <> $1 AND updated_at < $2",
+			PENDING, before.Unix())
+		if err != nil {
+			return err
+		}
+		n, err = res.RowsAffected()
+		return err
+	})
+	return n, database.Err(err)
+}
@@ -0,0 +1,309 @@
+/*
+	webhook.go
+	Purpose: Outgoing webhooks subscribed to the events of the assistant.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+	2026/10/17  v1.0.1 Evan Chen   Post LINE events without their reply and quote tokens
+*/
+
+// Package webhook posts the events of the assistant to the endpoints of users, e.g. when a todo is completed.
+//
+// Users register hooks with the url and the [signal] topics of interest through the api at [HooksPath],
+// and receive the events of their own chat and the groups they are members of, while admins could receive every chat.
+// Every event is persisted as a delivery and posted by the worker of [Service] as JSON
+//
+//	{ "id": "delivery id", "topic": "todo.completed", "created_at": 1792224000, "data": { ... } }
+//
+// signed with the secret of the hook in the headers
+//
+//	X-Webhook-Timestamp: 1792224000
+//	X-Webhook-Signature: sha256=hex(HMAC-SHA256(secret, timestamp + "." + body))
+//
+// A delivery not replied with 2xx is retried with exponential backoff, every attempt is logged,
+// and the recent deliveries could be listed and redelivered. For inbound webhooks see package bridge.
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"app/core/cron"
+	"app/core/dedup"
+	"app/core/group"
+	"app/core/line"
+	"app/core/rrule"
+	"app/core/signal"
+	"app/core/util"
+	"app/modules/reminder"
+	"app/modules/todo"
+
+	"github.com/rs/xid"
+	"golang.org/x/exp/slog"
+)
+
+// Option configures the webhooks.
+type Option struct {
+	// MaxAttempts is how many failed attempts a delivery is marked dead after.
+	MaxAttempts int
+	// Backoff is the wait before the first retry, it doubles on every retry up to an hour.
+	Backoff time.Duration
+	// Interval is the longest time the worker sleeps between checks.
+	Interval time.Duration
+	// Timeout is how long an attempt waits for the reply.
+	Timeout time.Duration
+	// Retention is how long finished deliveries are kept in the log.
+	Retention time.Duration
+	// AllowPrivate allows posting to loopback and private addresses, which should only be set for testing
+	// or trusted users, as hooks could otherwise reach internal services.
+	AllowPrivate bool
+}
+
+var opt = Option{
+	MaxAttempts: 8,
+	Backoff:     30 * time.Second,
+	Interval:    30 * time.Second,
+	Timeout:     10 * time.Second,
+	Retention:   30 * 24 * time.Hour,
+}
+
+// Setup sets the options, empty durations and attempts are left unchanged.
+func Setup(o *Option) {
+	if o.MaxAttempts > 0 {
+		opt.MaxAttempts = o.MaxAttempts
+	}
+	if o.Backoff > 0 {
+		opt.Backoff = o.Backoff
+	}
+	if o.Interval > 0 {
+		opt.Interval = o.Interval
+	}
+	if o.Timeout > 0 {
+		opt.Timeout = o.Timeout
+	}
+	if o.Retention > 0 {
+		opt.Retention = o.Retention
+	}
+	opt.AllowPrivate = o.AllowPrivate
+	client = newClient()
+}
+
+// Topics are the topics hooks could subscribe to.
+var Topics = []string{
+	todo.TopicCreated, todo.TopicCompleted,
+	reminder.TopicCreated, reminder.TopicPushed, reminder.TopicDone,
+	line.TopicText, line.TopicImage, line.TopicVideo, line.TopicAudio, line.TopicFile, line.TopicLocation, line.TopicSticker,
+	line.TopicFollow, line.TopicUnfollow, line.TopicJoin, line.TopicLeave, line.TopicMemberJoined, line.TopicMemberLeft,
+	line.TopicPostback,
+}
+
+func init() {
+	cron.Register(&cron.Job{
+		Name: "webhook.purge",
+		Spec: "@daily",
+		Run: func(ctx context.Context) error {
+			n, err := purge(ctx, time.Now().Add(-opt.Retention))
+			if n > 0 {
+				slog.Info("deliveries purged", slog.Int64("count", n), slog.String("mod", "webhook"), slog.String("act", "purge"))
+			}
+			return err
+		},
+	})
+}
+
+// Service is the [service.Service] which subscribes to the topics and runs the worker.
+var Service = &webhook{wake: make(chan struct{}, 1)}
+
+type webhook struct {
+	lock   sync.Mutex
+	closes []func()
+	wake   chan struct{}
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+func (s *webhook) Init() error {
+	for _, topic := range Topics {
+		topic := topic
+		if strings.HasPrefix(topic, "line.") {
+			// events of the LINE webhook are claimed, so they are not published twice when redelivered
+			s.closes = append(s.closes, dedup.Handle("webhook", topic, dedup.ExactlyOnce, func(ctx context.Context, evt *line.Event) error {
+				return publish(ctx, topic, evt)
+			}))
+			continue
+		}
+		s.closes = append(s.closes, signal.Handle(topic, func(e signal.Event) {
+			if len(e.Args) == 0 {
+				return
+			}
+			if err := publish(context.Background(), topic, e.Args[0]); err != nil {
+				slog.Error("publish event failed", util.ErrAtrr(err), slog.String("mod", "webhook"), slog.String("act", "publish"),
+					slog.String("topic", topic))
+			}
+		}))
+	}
+	return nil
+}
+
+// Load loads the hooks and starts the worker if not started.
+func (s *webhook) Load() {
+	if err := reload(context.Background()); err != nil {
+		slog.Error("load hooks failed", util.ErrAtrr(err), slog.String("mod", "webhook"), slog.String("act", "load"))
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.stop != nil {
+		return
+	}
+	s.stop, s.done = make(chan struct{}), make(chan struct{})
+	go s.run(s.stop, s.done)
+}
+
+// Del stops the worker and unsubscribes the topics, pending deliveries are posted on next start.
+func (s *webhook) Del() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, off := range s.closes {
+		off()
+	}
+	s.closes = nil
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	<-s.done
+	s.stop = nil
+}
+
+// Notify wakes up the worker to post newly enqueued deliveries.
+func Notify() {
+	select {
+	case Service.wake <- struct{}{}:
+	default:
+	}
+}
+
+// hooks are the loaded hooks by topic, so events are matched without querying the database.
+var hooks struct {
+	sync.RWMutex
+	byTopic map[string][]*Hook
+}
+
+// reload loads every hook from the database, it is called whenever hooks are changed.
+func reload(ctx context.Context) error {
+	list, err := listHooks(ctx, "")
+	if err != nil {
+		return err
+	}
+	byTopic := map[string][]*Hook{}
+	for _, h := range list {
+		for _, t := range h.Topics {
+			byTopic[t] = append(byTopic[t], h)
+		}
+	}
+	hooks.Lock()
+	hooks.byTopic = byTopic
+	hooks.Unlock()
+	return nil
+}
+
+// publish enqueues a delivery of the event @arg on @topic to every hook subscribed and allowed to see it.
+func publish(ctx context.Context, topic string, arg any) error {
+	hooks.RLock()
+	subs := hooks.byTopic[topic]
+	hooks.RUnlock()
+	if len(subs) == 0 {
+		return nil
+	}
+	chat, data := view(arg)
+	queued := 0
+	for _, h := range subs {
+		if !h.All {
+			ok, err := visible(ctx, h.Owner, chat)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+		}
+		id := xid.New().String()
+		body, err := json.Marshal(map[string]any{"id": id, "topic": topic, "created_at": time.Now().Unix(), "data": data})
+		if err != nil {
+			return err
+		}
+		if err := enqueue(ctx, id, h.ID, topic, body); err != nil {
+			return err
+		}
+		queued++
+	}
+	if queued > 0 {
+		Notify()
+	}
+	return nil
+}
+
+// visible checks if the events of @chat could be seen by @owner, which is the owner's own chat or a group of the owner.
+func visible(ctx context.Context, owner, chat string) (bool, error) {
+	if chat == "" {
+		return false, nil
+	}
+	if chat == owner {
+		return true, nil
+	}
+	return group.IsMember(ctx, chat, owner)
+}
+
+// view returns the chat of the event @arg and its data to be posted.
+func view(arg any) (chat string, data any) {
+	switch v := arg.(type) {
+	case *line.Event:
+		// a copy without the reply and quote tokens, they are for the bot only
+		e := *v
+		e.ReplyToken = ""
+		if v.Message != nil {
+			m := *v.Message
+			m.QuoteToken = ""
+			e.Message = &m
+		}
+		if v.Source == nil {
+			return "", &e
+		}
+		return v.Source.ID(), &e
+	case *todo.Todo:
+		return v.ChatID, map[string]any{
+			"id": v.ID, "chat_id": v.ChatID, "created_by": v.CreatedBy, "title": v.Title, "due_at": unix(v.DueAt),
+			"priority": v.Priority, "tags": v.Tags, "done": v.Done, "done_by": v.DoneBy, "done_at": unix(v.DoneAt),
+			"recurrence": recurrence(v.Recurrence),
+		}
+	case *reminder.Reminder:
+		return v.ChatID, map[string]any{
+			"id": v.ID, "chat_id": v.ChatID, "user_id": v.UserID, "text": v.Text, "due_at": unix(v.DueAt), "status": v.Status,
+			"recurrence": recurrence(v.Recurrence),
+		}
+	}
+	return "", arg
+}
+
+// unix returns the unix seconds of @t, 0 if not set.
+func unix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// recurrence returns the recurrence rules of @set, empty if not recurring.
+func recurrence(set *rrule.Set) string {
+	if set == nil {
+		return ""
+	}
+	return set.String()
+}
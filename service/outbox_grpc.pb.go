@@ -0,0 +1,160 @@
+//
+//outbox.proto
+//Purpose: Admin APIs of the outbound message outbox.
+//
+//MODIFICATION HISTORY
+//Date        Ver    Name     Description
+//---------- ------- ----------- -------------------------------------------
+//2026/10/17  v1.0.0 Evan Chen   Initial release
+//
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: outbox.proto
+
+package service
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	OutboxService_ListFailed_FullMethodName = "/pms.OutboxService/ListFailed"
+	OutboxService_Requeue_FullMethodName    = "/pms.OutboxService/Requeue"
+)
+
+// OutboxServiceClient is the client API for OutboxService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OutboxServiceClient interface {
+	// ListFailed lists messages that are dead after exhausting their retries, latest first.
+	ListFailed(ctx context.Context, in *ListFailedRequest, opts ...grpc.CallOption) (*ListFailedResponse, error)
+	// Requeue puts dead messages back to be delivered again.
+	Requeue(ctx context.Context, in *RequeueRequest, opts ...grpc.CallOption) (*RequeueResponse, error)
+}
+
+type outboxServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOutboxServiceClient(cc grpc.ClientConnInterface) OutboxServiceClient {
+	return &outboxServiceClient{cc}
+}
+
+func (c *outboxServiceClient) ListFailed(ctx context.Context, in *ListFailedRequest, opts ...grpc.CallOption) (*ListFailedResponse, error) {
+	out := new(ListFailedResponse)
+	err := c.cc.Invoke(ctx, OutboxService_ListFailed_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outboxServiceClient) Requeue(ctx context.Context, in *RequeueRequest, opts ...grpc.CallOption) (*RequeueResponse, error) {
+	out := new(RequeueResponse)
+	err := c.cc.Invoke(ctx, OutboxService_Requeue_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OutboxServiceServer is the server API for OutboxService service.
+// All implementations must embed UnimplementedOutboxServiceServer
+// for forward compatibility
+type OutboxServiceServer interface {
+	// ListFailed lists messages that are dead after exhausting their retries, latest first.
+	ListFailed(context.Context, *ListFailedRequest) (*ListFailedResponse, error)
+	// Requeue puts dead messages back to be delivered again.
+	Requeue(context.Context, *RequeueRequest) (*RequeueResponse, error)
+	mustEmbedUnimplementedOutboxServiceServer()
+}
+
+// UnimplementedOutboxServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedOutboxServiceServer struct {
+}
+
+func (UnimplementedOutboxServiceServer) ListFailed(context.Context, *ListFailedRequest) (*ListFailedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFailed not implemented")
+}
+func (UnimplementedOutboxServiceServer) Requeue(context.Context, *RequeueRequest) (*RequeueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Requeue not implemented")
+}
+func (UnimplementedOutboxServiceServer) mustEmbedUnimplementedOutboxServiceServer() {}
+
+// UnsafeOutboxServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OutboxServiceServer will
+// result in compilation errors.
+type UnsafeOutboxServiceServer interface {
+	mustEmbedUnimplementedOutboxServiceServer()
+}
+
+func RegisterOutboxServiceServer(s grpc.ServiceRegistrar, srv OutboxServiceServer) {
+	s.RegisterService(&OutboxService_ServiceDesc, srv)
+}
+
+func _OutboxService_ListFailed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFailedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxServiceServer).ListFailed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OutboxService_ListFailed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxServiceServer).ListFailed(ctx, req.(*ListFailedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutboxService_Requeue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequeueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxServiceServer).Requeue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OutboxService_Requeue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxServiceServer).Requeue(ctx, req.(*RequeueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OutboxService_ServiceDesc is the grpc.ServiceDesc for OutboxService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OutboxService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pms.OutboxService",
+	HandlerType: (*OutboxServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListFailed",
+			Handler:    _OutboxService_ListFailed_Handler,
+		},
+		{
+			MethodName: "Requeue",
+			Handler:    _OutboxService_Requeue_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "outbox.proto",
+}
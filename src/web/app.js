@@ -0,0 +1,146 @@
+/*
+	app.js
+	Purpose: The web app opened in LINE with LIFF, signs in with the LIFF token and edits todos.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+"use strict";
+
+const app = document.getElementById("app");
+// chat is the group of the todos, e.g. opened from https://liff.line.me/{liffId}?chat=C123, the user's own by default
+const chat = new URLSearchParams(location.search).get("chat") || "";
+let todos = [];
+
+// api calls the gateway with our token, errors are thrown with the message of the server.
+async function api(method, path, body) {
+  const res = await fetch(path, {
+    method,
+    headers: { "Content-Type": "application/json", Authorization: "Bearer " + sessionStorage.getItem("token") },
+    body: body && JSON.stringify(body),
+  });
+  if (res.status === 401) {
+    // our token expired, sign in again
+    sessionStorage.removeItem("token");
+    location.reload();
+    return new Promise(() => {});
+  }
+  const data = await res.json().catch(() => ({}));
+  if (!res.ok) {
+    throw new Error(data.message || res.statusText);
+  }
+  return data;
+}
+
+// signIn initializes LIFF and exchanges its token for ours.
+async function signIn() {
+  if (sessionStorage.getItem("token")) {
+    return;
+  }
+  const cfg = await (await fetch("/api/auth/liff")).json();
+  await liff.init({ liffId: cfg.liffId });
+  if (!liff.isLoggedIn()) {
+    liff.login({ redirectUri: location.href });
+    return new Promise(() => {});
+  }
+  const idToken = liff.getIDToken();
+  const res = await fetch("/api/auth/liff", {
+    method: "POST",
+    headers: { "Content-Type": "application/json" },
+    body: JSON.stringify(idToken ? { idToken } : { accessToken: liff.getAccessToken() }),
+  });
+  const data = await res.json();
+  if (!res.ok) {
+    throw new Error(data.message || res.statusText);
+  }
+  sessionStorage.setItem("token", data.token);
+}
+
+// esc escapes a text to be put in html.
+function esc(s) {
+  const div = document.createElement("div");
+  div.textContent = s;
+  return div.innerHTML;
+}
+
+// day formats unix seconds as yyyy-mm-dd, which is the value of a date input.
+function day(unix) {
+  if (!Number(unix)) {
+    return "";
+  }
+  const d = new Date(Number(unix) * 1000);
+  return [d.getFullYear(), String(d.getMonth() + 1).padStart(2, "0"), String(d.getDate()).padStart(2, "0")].join("-");
+}
+
+async function list() {
+  const q = new URLSearchParams({ chat_id: chat, "pager.size": "100" });
+  todos = (await api("GET", "/api/todos?" + q)).todos;
+  if (todos.length === 0) {
+    app.innerHTML = '<p class="muted">沒有待辦事項</p>';
+    return;
+  }
+  app.innerHTML = "<ul>" + todos.map((t) => `
+    <li class="${t.done ? "done" : ""}">
+      <a href="#/todo/${t.id}">${esc(t.title)}<br><span class="muted">${day(t.due_at)} ${t.tags.map((x) => "#" + esc(x)).join(" ")}</span></a>
+      ${t.done ? "" : `<button data-done="${t.id}">完成</button>`}
+    </li>`).join("") + "</ul>";
+  app.querySelectorAll("[data-done]").forEach((b) => b.addEventListener("click", async () => {
+    await api("POST", `/api/todos/${b.dataset.done}/complete`, {});
+    render();
+  }));
+}
+
+function edit(id) {
+  const t = todos.find((x) => x.id === id);
+  if (!t) {
+    location.hash = "#/";
+    return;
+  }
+  app.innerHTML = `
+    <form>
+      <label>標題<input name="title" required value="${esc(t.title)}"></label>
+      <label>期限<input name="due" type="date" value="${day(t.due_at)}"></label>
+      <label>優先度<select name="priority">
+        ${["無", "低", "中", "高"].map((p, i) => `<option value="${i}" ${i === t.priority ? "selected" : ""}>${p}</option>`).join("")}
+      </select></label>
+      <label>標籤<input name="tags" value="${esc(t.tags.join(" "))}" placeholder="home work"></label>
+      <button>儲存</button> <button type="button" class="secondary" onclick="location.hash='#/'">取消</button>
+    </form>`;
+  app.querySelector("form").addEventListener("submit", async (e) => {
+    e.preventDefault();
+    const f = new FormData(e.target);
+    const due = f.get("due") ? new Date(f.get("due") + "T00:00:00").getTime() / 1000 : 0;
+    await api("PUT", "/api/todos/" + id, {
+      title: f.get("title"),
+      due_at: String(due),
+      priority: Number(f.get("priority")),
+      tags: f.get("tags").split(/\s+/).filter(Boolean),
+    });
+    location.hash = "#/";
+  });
+}
+
+async function render() {
+  try {
+    await signIn();
+    const m = location.hash.match(/^#\/todo\/(\w+)$/);
+    if (m) {
+      if (todos.length === 0) {
+        await list();
+      }
+      edit(m[1]);
+    } else {
+      await list();
+    }
+  } catch (err) {
+    app.innerHTML = `<p class="error">${esc(err.message)}</p>`;
+  }
+}
+
+window.addEventListener("hashchange", render);
+render();
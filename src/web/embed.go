@@ -0,0 +1,20 @@
+/*
+	embed.go
+	Purpose: Embed the web app opened in LINE with LIFF as fs.FS.
+
+	@author Evan Chen
+
+	MODIFICATION HISTORY
+	   Date        Ver    Name     Description
+	---------- ------- ----------- -------------------------------------------
+	2026/10/17  v1.0.0 Evan Chen   Initial release
+*/
+
+// Package web is the single page app served at the root of the server,
+// it is opened in LINE with LIFF and signs in by exchanging the LIFF token at /api/auth/liff.
+package web
+
+import "embed"
+
+//go:embed index.html app.js app.css
+var FS embed.FS